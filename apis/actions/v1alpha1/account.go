@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "k8s.io/utils/pointer"
+
+// GetAccount returns the owner of the repository of this RepositoryOIDCSubjectClaim.
+func (mg *RepositoryOIDCSubjectClaim) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this Workflow.
+func (mg *Workflow) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the organization of this RunnerGroup.
+func (mg *RunnerGroup) GetAccount() string {
+	return mg.Spec.ForProvider.Org
+}
+
+// GetAccount returns the organization, or the owner of the repository, this
+// JITRunnerConfig registers a runner with.
+func (mg *JITRunnerConfig) GetAccount() string {
+	return pointer.StringDeref(mg.Spec.ForProvider.Org, mg.Spec.ForProvider.Owner)
+}
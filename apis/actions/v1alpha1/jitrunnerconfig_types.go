@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// JITRunnerConfigParameters are the configurable fields of a JITRunnerConfig.
+type JITRunnerConfigParameters struct {
+	// The organization to register the runner with. Either this or a
+	// repository must be set.
+	// +optional
+	Org *string `json:"org,omitempty"`
+
+	// The account owner of the repository to register the runner with. Set
+	// from the referenced repository when repositoryRef or
+	// repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository to register the runner with. Set from the
+	// referenced repository when repositoryRef or repositorySelector is
+	// used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The name of the runner.
+	Name string `json:"name"`
+
+	// The custom labels of the runner, which workflows select it by.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Labels []string `json:"labels"`
+
+	// The ID of the runner group of the runner. Runners of organizations
+	// default to the Default group, and runners of repositories always
+	// join it.
+	// +optional
+	RunnerGroupID *int64 `json:"runnerGroupID,omitempty"`
+
+	// RunnerGroupRef refers to the RunnerGroup of the runner.
+	// +optional
+	RunnerGroupRef *xpv1.Reference `json:"runnerGroupRef,omitempty"`
+
+	// RunnerGroupSelector selects the RunnerGroup of the runner.
+	// +optional
+	RunnerGroupSelector *xpv1.Selector `json:"runnerGroupSelector,omitempty"`
+
+	// The working directory of the runner, relative to where it is
+	// installed.
+	// +kubebuilder:default=_work
+	// +optional
+	WorkFolder *string `json:"workFolder,omitempty"`
+}
+
+// AnnotationKeyRegenerate is the key of the annotation that regenerates the
+// configuration of a JITRunnerConfig. A new configuration is generated, and
+// the runner of the old one is removed, whenever its value changes.
+const AnnotationKeyRegenerate = "actions.github.hasheddan.io/regenerate"
+
+// JITRunnerConfigObservation are the observable fields of a JITRunnerConfig.
+type JITRunnerConfigObservation struct {
+	RunnerID int64  `json:"runnerID,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Busy     bool   `json:"busy,omitempty"`
+
+	// The value of the regenerate annotation the current configuration was
+	// generated for.
+	Regenerated string `json:"regenerated,omitempty"`
+}
+
+// A JITRunnerConfigSpec defines the desired state of a JITRunnerConfig.
+type JITRunnerConfigSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       JITRunnerConfigParameters `json:"forProvider"`
+}
+
+// A JITRunnerConfigStatus represents the observed state of a JITRunnerConfig.
+type JITRunnerConfigStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          JITRunnerConfigObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A JITRunnerConfig is the just-in-time configuration of an ephemeral
+// self-hosted runner. Its external name is the ID of the runner, and the
+// configuration is published as the encodedJITConfig connection detail. Each
+// configuration can be used once; set the
+// actions.github.hasheddan.io/regenerate annotation to a new value to generate
+// a fresh one.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type JITRunnerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JITRunnerConfigSpec   `json:"spec"`
+	Status JITRunnerConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JITRunnerConfigList contains a list of JITRunnerConfig
+type JITRunnerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JITRunnerConfig `json:"items"`
+}
+
+// JITRunnerConfig type metadata.
+var (
+	JITRunnerConfigKind             = reflect.TypeOf(JITRunnerConfig{}).Name()
+	JITRunnerConfigGroupKind        = schema.GroupKind{Group: Group, Kind: JITRunnerConfigKind}.String()
+	JITRunnerConfigKindAPIVersion   = JITRunnerConfigKind + "." + SchemeGroupVersion.String()
+	JITRunnerConfigGroupVersionKind = SchemeGroupVersion.WithKind(JITRunnerConfigKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&JITRunnerConfig{}, &JITRunnerConfigList{})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationOIDCSubjectClaimParameters are the configurable fields of an
+// OrganizationOIDCSubjectClaim.
+type OrganizationOIDCSubjectClaimParameters struct {
+	// The name of the organization.
+	Org string `json:"org"`
+
+	// The claim keys that make up the subject claim, in order. GitHub
+	// joins them in the order given.
+	// +kubebuilder:validation:MinItems=1
+	IncludeClaimKeys []string `json:"includeClaimKeys"`
+}
+
+// OrganizationOIDCSubjectClaimObservation are the observable fields of an
+// OrganizationOIDCSubjectClaim.
+type OrganizationOIDCSubjectClaimObservation struct {
+	IncludeClaimKeys []string `json:"includeClaimKeys,omitempty"`
+}
+
+// An OrganizationOIDCSubjectClaimSpec defines the desired state of an
+// OrganizationOIDCSubjectClaim.
+type OrganizationOIDCSubjectClaimSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationOIDCSubjectClaimParameters `json:"forProvider"`
+}
+
+// An OrganizationOIDCSubjectClaimStatus represents the observed state of an
+// OrganizationOIDCSubjectClaim.
+type OrganizationOIDCSubjectClaimStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationOIDCSubjectClaimObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationOIDCSubjectClaim manages the customized OIDC subject claim
+// template of an organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationOIDCSubjectClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationOIDCSubjectClaimSpec   `json:"spec"`
+	Status OrganizationOIDCSubjectClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationOIDCSubjectClaimList contains a list of OrganizationOIDCSubjectClaim
+type OrganizationOIDCSubjectClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationOIDCSubjectClaim `json:"items"`
+}
+
+// OrganizationOIDCSubjectClaim type metadata.
+var (
+	OrganizationOIDCSubjectClaimKind             = reflect.TypeOf(OrganizationOIDCSubjectClaim{}).Name()
+	OrganizationOIDCSubjectClaimGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationOIDCSubjectClaimKind}.String()
+	OrganizationOIDCSubjectClaimKindAPIVersion   = OrganizationOIDCSubjectClaimKind + "." + SchemeGroupVersion.String()
+	OrganizationOIDCSubjectClaimGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationOIDCSubjectClaimKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationOIDCSubjectClaim{}, &OrganizationOIDCSubjectClaimList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	repov1alpha1 "github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+)
+
+// The referencers of this file are written by hand because the owner and
+// name of a repository are resolved from a single reference, which angryjet
+// cannot generate.
+
+func repositoryTo() reference.To {
+	return reference.To{Managed: &repov1alpha1.Repository{}, List: &repov1alpha1.RepositoryList{}}
+}
+
+// ResolveReferences of this RepositoryOIDCSubjectClaim.
+func (mg *RepositoryOIDCSubjectClaim) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this Workflow.
+func (mg *Workflow) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this RunnerGroup. Resolution stops at the first
+// selected workflow whose repository is not ready yet.
+func (mg *RunnerGroup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	for i := range mg.Spec.ForProvider.SelectedWorkflows {
+		w := &mg.Spec.ForProvider.SelectedWorkflows[i]
+		if err := common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+			Owner: &w.Owner, Repository: &w.Repository, Reference: &w.RepositoryRef, Selector: w.RepositorySelector,
+		}); err != nil {
+			return err
+		}
+		if common.IsWaitingForRepository(mg) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// RunnerGroupID returns an extractor that returns the ID of a RunnerGroup,
+// which is its external name.
+func RunnerGroupID() reference.ExtractValueFn {
+	return reference.ExternalName()
+}
+
+// ResolveReferences of this JITRunnerConfig.
+func (mg *JITRunnerConfig) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	if p.RepositoryRef != nil || p.RepositorySelector != nil {
+		if err := common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+			Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+		}); err != nil {
+			return err
+		}
+	}
+
+	current := ""
+	if p.RunnerGroupID != nil {
+		current = strconv.FormatInt(*p.RunnerGroupID, 10)
+	}
+	rsp, err := reference.NewAPIResolver(c, mg).Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: current,
+		Extract:      RunnerGroupID(),
+		Reference:    p.RunnerGroupRef,
+		Selector:     p.RunnerGroupSelector,
+		To:           reference.To{Managed: &RunnerGroup{}, List: &RunnerGroupList{}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.runnerGroupID")
+	}
+	p.RunnerGroupRef = rsp.ResolvedReference
+	if rsp.ResolvedValue == "" || rsp.ResolvedValue == current {
+		return nil
+	}
+	id, err := strconv.ParseInt(rsp.ResolvedValue, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.runnerGroupID")
+	}
+	p.RunnerGroupID = &id
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RepositoryOIDCSubjectClaimParameters are the configurable fields of a
+// RepositoryOIDCSubjectClaim.
+type RepositoryOIDCSubjectClaimParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// Whether to use the default subject claim template. When true, the
+	// includeClaimKeys are ignored by GitHub.
+	UseDefault bool `json:"useDefault"`
+
+	// The claim keys that make up the subject claim, in order. GitHub
+	// joins them in the order given.
+	// +optional
+	IncludeClaimKeys []string `json:"includeClaimKeys,omitempty"`
+}
+
+// RepositoryOIDCSubjectClaimObservation are the observable fields of a
+// RepositoryOIDCSubjectClaim.
+type RepositoryOIDCSubjectClaimObservation struct {
+	UseDefault       bool     `json:"useDefault,omitempty"`
+	IncludeClaimKeys []string `json:"includeClaimKeys,omitempty"`
+}
+
+// A RepositoryOIDCSubjectClaimSpec defines the desired state of a
+// RepositoryOIDCSubjectClaim.
+type RepositoryOIDCSubjectClaimSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryOIDCSubjectClaimParameters `json:"forProvider"`
+}
+
+// A RepositoryOIDCSubjectClaimStatus represents the observed state of a
+// RepositoryOIDCSubjectClaim.
+type RepositoryOIDCSubjectClaimStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RepositoryOIDCSubjectClaimObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryOIDCSubjectClaim manages the customized OIDC subject claim
+// template of a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="USE-DEFAULT",type="boolean",JSONPath=".spec.forProvider.useDefault"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RepositoryOIDCSubjectClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryOIDCSubjectClaimSpec   `json:"spec"`
+	Status RepositoryOIDCSubjectClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryOIDCSubjectClaimList contains a list of RepositoryOIDCSubjectClaim
+type RepositoryOIDCSubjectClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryOIDCSubjectClaim `json:"items"`
+}
+
+// RepositoryOIDCSubjectClaim type metadata.
+var (
+	RepositoryOIDCSubjectClaimKind             = reflect.TypeOf(RepositoryOIDCSubjectClaim{}).Name()
+	RepositoryOIDCSubjectClaimGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryOIDCSubjectClaimKind}.String()
+	RepositoryOIDCSubjectClaimKindAPIVersion   = RepositoryOIDCSubjectClaimKind + "." + SchemeGroupVersion.String()
+	RepositoryOIDCSubjectClaimGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryOIDCSubjectClaimKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryOIDCSubjectClaim{}, &RepositoryOIDCSubjectClaimList{})
+}
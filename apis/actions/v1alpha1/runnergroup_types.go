@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RunnerGroupParameters are the configurable fields of a RunnerGroup.
+type RunnerGroupParameters struct {
+	// The organization of the runner group.
+	Org string `json:"org"`
+
+	// The name of the runner group.
+	Name string `json:"name"`
+
+	// Which repositories of the organization can use the runner group.
+	// +kubebuilder:validation:Enum=all;selected;private
+	// +kubebuilder:default=all
+	// +optional
+	Visibility string `json:"visibility,omitempty"`
+
+	// Whether public repositories can use the runner group.
+	// +optional
+	AllowsPublicRepositories bool `json:"allowsPublicRepositories,omitempty"`
+
+	// The workflows the runner group is restricted to. The runner group
+	// runs any workflow if there are none.
+	// +optional
+	SelectedWorkflows []SelectedWorkflow `json:"selectedWorkflows,omitempty"`
+}
+
+// A SelectedWorkflow is a workflow file at a ref of a repository.
+type SelectedWorkflow struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The path of the workflow file, such as .github/workflows/deploy.yml.
+	// +kubebuilder:validation:Pattern=`^[^@]+\.ya?ml$`
+	Path string `json:"path"`
+
+	// The ref of the workflow file, such as refs/heads/main or a commit
+	// SHA.
+	// +kubebuilder:validation:Pattern=`^[^@]+$`
+	Ref string `json:"ref"`
+}
+
+// RunnerGroupObservation are the observable fields of a RunnerGroup.
+type RunnerGroupObservation struct {
+	ID                           int64 `json:"id,omitempty"`
+	Default                      bool  `json:"default,omitempty"`
+	Inherited                    bool  `json:"inherited,omitempty"`
+	WorkflowRestrictionsReadOnly bool  `json:"workflowRestrictionsReadOnly,omitempty"`
+}
+
+// A RunnerGroupSpec defines the desired state of a RunnerGroup.
+type RunnerGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RunnerGroupParameters `json:"forProvider"`
+}
+
+// A RunnerGroupStatus represents the observed state of a RunnerGroup.
+type RunnerGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RunnerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RunnerGroup is a group of self-hosted runners of an organization. Its
+// external name is the ID GitHub assigns it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="VISIBILITY",type="string",JSONPath=".spec.forProvider.visibility"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RunnerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RunnerGroupSpec   `json:"spec"`
+	Status RunnerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RunnerGroupList contains a list of RunnerGroup
+type RunnerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RunnerGroup `json:"items"`
+}
+
+// RunnerGroup type metadata.
+var (
+	RunnerGroupKind             = reflect.TypeOf(RunnerGroup{}).Name()
+	RunnerGroupGroupKind        = schema.GroupKind{Group: Group, Kind: RunnerGroupKind}.String()
+	RunnerGroupKindAPIVersion   = RunnerGroupKind + "." + SchemeGroupVersion.String()
+	RunnerGroupGroupVersionKind = SchemeGroupVersion.WithKind(RunnerGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RunnerGroup{}, &RunnerGroupList{})
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "github.com/hasheddan/kc-provider-github/apis/common"
+
+// GetSyncStatus returns when this OrganizationOIDCSubjectClaim was last compared with its external
+// resource.
+func (mg *OrganizationOIDCSubjectClaim) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this RepositoryOIDCSubjectClaim was last compared with its external
+// resource.
+func (mg *RepositoryOIDCSubjectClaim) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Workflow was last compared with its external
+// resource.
+func (mg *Workflow) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this RunnerGroup was last compared with its external
+// resource.
+func (mg *RunnerGroup) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this JITRunnerConfig was last compared with its external
+// resource.
+func (mg *JITRunnerConfig) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
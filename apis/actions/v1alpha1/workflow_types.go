@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// WorkflowParameters are the configurable fields of a Workflow.
+type WorkflowParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The file name of the workflow, such as main.yml. Either this or
+	// workflowID must be set.
+	// +optional
+	WorkflowFileName *string `json:"workflowFileName,omitempty"`
+
+	// The numeric ID of the workflow. Takes precedence over
+	// workflowFileName when both are set.
+	// +optional
+	WorkflowID *int64 `json:"workflowID,omitempty"`
+
+	// The state the workflow should be pinned to.
+	// +kubebuilder:validation:Enum=active;disabled_manually
+	State string `json:"state"`
+}
+
+// WorkflowObservation are the observable fields of a Workflow.
+type WorkflowObservation struct {
+	ID       int64  `json:"id,omitempty"`
+	NodeID   string `json:"nodeId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Path     string `json:"path,omitempty"`
+	State    string `json:"state,omitempty"`
+	BadgeURL string `json:"badgeURL,omitempty"`
+}
+
+// A WorkflowSpec defines the desired state of a Workflow.
+type WorkflowSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkflowParameters `json:"forProvider"`
+}
+
+// A WorkflowStatus represents the observed state of a Workflow.
+type WorkflowStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          WorkflowObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Workflow pins the enabled or disabled state of a workflow in a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Workflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkflowSpec   `json:"spec"`
+	Status WorkflowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkflowList contains a list of Workflow
+type WorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Workflow `json:"items"`
+}
+
+// Workflow type metadata.
+var (
+	WorkflowKind             = reflect.TypeOf(Workflow{}).Name()
+	WorkflowGroupKind        = schema.GroupKind{Group: Group, Kind: WorkflowKind}.String()
+	WorkflowKindAPIVersion   = WorkflowKind + "." + SchemeGroupVersion.String()
+	WorkflowGroupVersionKind = SchemeGroupVersion.WithKind(WorkflowKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Workflow{}, &WorkflowList{})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains types and functions shared by all API groups.
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeConflict indicates whether a managed resource manages the same external
+// fields as another managed resource.
+const TypeConflict xpv1.ConditionType = "Conflict"
+
+// Reasons a managed resource does or does not conflict with another.
+const (
+	ReasonConflicting    xpv1.ConditionReason = "Conflicting"
+	ReasonNotConflicting xpv1.ConditionReason = "NotConflicting"
+)
+
+// Conflicting returns a condition that indicates the managed resource manages
+// the same external fields as another managed resource.
+func Conflicting(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConflicting,
+		Message:            msg,
+	}
+}
+
+// NotConflicting returns a condition that indicates the managed resource does
+// not manage the same external fields as any other managed resource.
+func NotConflicting() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotConflicting,
+	}
+}
+
+// TypeMutationsPaused indicates whether changes to the external resource of a
+// managed resource are paused by its ProviderConfig.
+const TypeMutationsPaused xpv1.ConditionType = "MutationsPaused"
+
+// Reasons changes to the external resource of a managed resource are or are
+// not paused.
+const (
+	ReasonMutationsPaused  xpv1.ConditionReason = "MutationsPaused"
+	ReasonMutationsResumed xpv1.ConditionReason = "MutationsResumed"
+)
+
+// MutationsPaused returns a condition that indicates that changes to the
+// external resource of the managed resource are paused, so drift is reported
+// but not corrected.
+func MutationsPaused(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMutationsPaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMutationsPaused,
+		Message:            msg,
+	}
+}
+
+// MutationsResumed returns a condition that indicates that changes to the
+// external resource of the managed resource are no longer paused.
+func MutationsResumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMutationsPaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMutationsResumed,
+	}
+}
+
+// ReasonWaiting indicates that a managed resource is not ready because an
+// external resource it depends on does not exist yet.
+const ReasonWaiting xpv1.ConditionReason = "Waiting"
+
+// Waiting returns a condition that indicates the managed resource waits for
+// an external resource it depends on, such as the repository it belongs to,
+// to exist.
+func Waiting(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaiting,
+		Message:            msg,
+	}
+}
+
+// ReasonDeletionForbidden indicates that a managed resource cannot be deleted
+// because the credentials of its ProviderConfig lack permission to delete
+// its external resource.
+const ReasonDeletionForbidden xpv1.ConditionReason = "DeletionForbidden"
+
+// DeletionForbidden returns a condition that indicates the managed resource
+// cannot be deleted with the credentials of its ProviderConfig.
+func DeletionForbidden(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionForbidden,
+		Message:            msg,
+	}
+}
+
+// ReasonInvalidProviderConfig indicates that a managed resource is not ready
+// because the credentials of its ProviderConfig cannot access its external
+// resource.
+const ReasonInvalidProviderConfig xpv1.ConditionReason = "InvalidProviderConfig"
+
+// InvalidProviderConfig returns a condition that indicates the credentials
+// of the ProviderConfig of the managed resource cannot access its external
+// resource.
+func InvalidProviderConfig(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidProviderConfig,
+		Message:            msg,
+	}
+}
+
+// ReasonWaitingForRepository indicates that a managed resource is not ready
+// because the Repository it references is not ready yet.
+const ReasonWaitingForRepository xpv1.ConditionReason = "WaitingForRepository"
+
+// WaitingForRepository returns a condition that indicates the managed
+// resource waits for the Repository it references to become ready.
+func WaitingForRepository(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForRepository,
+		Message:            msg,
+	}
+}
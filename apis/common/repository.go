@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const errResolveRepository = "cannot resolve repository reference"
+
+// A RepositoryTarget is a managed resource that represents a repository and
+// can therefore be referenced by repository-scoped kinds.
+type RepositoryTarget interface {
+	resource.Managed
+
+	// GetRepositoryOwner returns the login of the account that owns the
+	// repository.
+	GetRepositoryOwner() string
+}
+
+// An AccountScoped managed resource belongs to the account, a user or an
+// organization, that owns its external resource. Credentials of a GitHub App
+// installation can only access the account the app is installed on.
+type AccountScoped interface {
+	resource.Managed
+
+	// GetAccount returns the login of the account, or an empty string if it
+	// is not known yet.
+	GetAccount() string
+}
+
+// A RepositoryReferencer is the part of the parameters of a
+// repository-scoped kind that identifies its repository, either directly or
+// through a reference or selector.
+type RepositoryReferencer struct {
+	Owner      *string
+	Repository *string
+	Reference  **xpv1.Reference
+	Selector   *xpv1.Selector
+}
+
+// ExtractRepository returns an extractor that returns the owner and name of a
+// repository, separated by a slash. It returns nothing until the repository
+// is ready, so that resolution is retried until the repository exists.
+func ExtractRepository() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(RepositoryTarget)
+		if !ok || r.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			return ""
+		}
+		return r.GetRepositoryOwner() + "/" + meta.GetExternalName(r)
+	}
+}
+
+// ResolveRepository resolves the repository reference or selector of the
+// supplied referencer to the owner and name of the referenced repository. A
+// referenced repository is resolved on every call, so that a referencer
+// follows the repository when it is renamed or moves to another owner. A
+// repository that is named directly is not resolved.
+//
+// A referenced repository that exists but is not ready yet, as is the case
+// while a composition is bootstrapped, is not an error. Instead the supplied
+// managed resource is marked as waiting for its repository, which its
+// controller does not observe until the repository is ready. A referencer
+// that already knows its repository keeps it until the repository is ready
+// again.
+func ResolveRepository(ctx context.Context, c client.Reader, mg resource.Managed, to reference.To, rr RepositoryReferencer) error {
+	if *rr.Repository == "" || *rr.Reference != nil {
+		name, err := unreadyRepository(ctx, c, mg, to, rr)
+		if err != nil {
+			return errors.Wrap(err, errResolveRepository)
+		}
+		if name != "" && *rr.Repository == "" {
+			mg.SetConditions(WaitingForRepository(fmt.Sprintf("waiting for Repository %s to become ready", name)))
+			return nil
+		}
+		if name != "" {
+			return nil
+		}
+	}
+
+	// The resolver does not resolve a reference again once it yielded a
+	// value, so the current name is only passed if nothing is referenced.
+	current := *rr.Repository
+	if *rr.Reference != nil {
+		current = ""
+	}
+	rsp, err := reference.NewAPIResolver(c, mg).Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: current,
+		Extract:      ExtractRepository(),
+		Reference:    *rr.Reference,
+		Selector:     rr.Selector,
+		To:           to,
+	})
+	if err != nil {
+		return errors.Wrap(err, errResolveRepository)
+	}
+	*rr.Reference = rsp.ResolvedReference
+
+	// An unresolved response carries the current name only.
+	owner, name, resolved := strings.Cut(rsp.ResolvedValue, "/")
+	if resolved {
+		*rr.Owner = owner
+		*rr.Repository = name
+	}
+	if IsWaitingForRepository(mg) {
+		mg.SetConditions(xpv1.Unavailable())
+	}
+	return nil
+}
+
+// IsWaitingForRepository returns true if the supplied managed resource waits
+// for the repository it references to become ready.
+func IsWaitingForRepository(mg resource.Managed) bool {
+	return mg.GetCondition(xpv1.TypeReady).Reason == ReasonWaitingForRepository
+}
+
+// unreadyRepository returns the name of the Repository the supplied referencer
+// refers to, or selects, if it exists but is not ready. It returns nothing if
+// the repository is ready or does not exist, which is left to the resolver.
+func unreadyRepository(ctx context.Context, c client.Reader, mg resource.Managed, to reference.To, rr RepositoryReferencer) (string, error) {
+	var candidates []resource.Managed
+	switch {
+	case *rr.Reference != nil:
+		err := c.Get(ctx, types.NamespacedName{Name: (*rr.Reference).Name}, to.Managed)
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidates = []resource.Managed{to.Managed}
+	case rr.Selector != nil:
+		if err := c.List(ctx, to.List, client.MatchingLabels(rr.Selector.MatchLabels)); err != nil {
+			return "", err
+		}
+		for _, m := range to.List.GetItems() {
+			if pointer.BoolDeref(rr.Selector.MatchControllerRef, false) && !meta.HaveSameController(mg, m) {
+				continue
+			}
+			candidates = append(candidates, m)
+		}
+	}
+
+	for _, m := range candidates {
+		if m.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+			return "", nil
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	return candidates[0].GetName(), nil
+}
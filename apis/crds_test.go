@@ -0,0 +1,281 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// crds is the directory the CRDs are generated into.
+const crds = "../package/crds"
+
+// columns are the printer columns every managed kind has, in order.
+var columns = []string{"READY", "SYNCED", "EXTERNAL-NAME"}
+
+// categories are the categories every managed kind belongs to.
+var categories = []string{"crossplane", "managed", "github"}
+
+// readCRDs returns the generated CRDs by group and kind.
+func readCRDs(t *testing.T) map[string]*extv1.CustomResourceDefinition {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(crds, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := map[string]*extv1.CustomResourceDefinition{}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		crd := &extv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(b, crd); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		out[crd.Spec.Names.Kind+"."+crd.Spec.Group] = crd
+	}
+	return out
+}
+
+func TestPrinterColumnsAndCategories(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	defined := readCRDs(t)
+
+	for gvk := range s.AllKnownTypes() {
+		obj, err := s.New(gvk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := obj.(resource.Managed); !ok {
+			continue
+		}
+		crd, ok := defined[gvk.GroupKind().String()]
+		if !ok {
+			t.Errorf("%s: no CRD was generated", gvk)
+			continue
+		}
+
+		var version *extv1.CustomResourceDefinitionVersion
+		for i := range crd.Spec.Versions {
+			if crd.Spec.Versions[i].Name == gvk.Version {
+				version = &crd.Spec.Versions[i]
+			}
+		}
+		if version == nil {
+			t.Errorf("%s: the CRD does not define this version", gvk)
+			continue
+		}
+
+		var names []string
+		forProvider := false
+		for _, c := range version.AdditionalPrinterColumns {
+			names = append(names, c.Name)
+			forProvider = forProvider || strings.HasPrefix(c.JSONPath, ".spec.forProvider.")
+		}
+		if len(names) < len(columns) || !cmp.Equal(columns, names[:len(columns)]) {
+			t.Errorf("%s: want printer columns to start with %v, got %v", gvk, columns, names)
+		}
+		if !forProvider {
+			t.Errorf("%s: want a printer column of a field of spec.forProvider, got %v", gvk, names)
+		}
+		for _, c := range categories {
+			if !contains(crd.Spec.Names.Categories, c) {
+				t.Errorf("%s: want category %s, got %v", gvk, c, crd.Spec.Names.Categories)
+			}
+		}
+	}
+}
+
+func TestConversion(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, crd := range readCRDs(t) {
+		if len(crd.Spec.Versions) < 2 {
+			continue
+		}
+		// Without webhook conversion the API server serves objects of every
+		// version as they were stored, without converting them.
+		c := crd.Spec.Conversion
+		if c == nil || c.Strategy != extv1.WebhookConverter || c.Webhook == nil {
+			t.Errorf("%s: want webhook conversion between its versions, got %+v", name, c)
+			continue
+		}
+		if c.Webhook.ClientConfig == nil || c.Webhook.ClientConfig.Service == nil || pointer.StringDeref(c.Webhook.ClientConfig.Service.Path, "") != "/convert" {
+			t.Errorf("%s: want the conversion webhook to be called at /convert, got %+v", name, c.Webhook.ClientConfig)
+		}
+		if !contains(c.Webhook.ConversionReviewVersions, "v1") {
+			t.Errorf("%s: want v1 conversion reviews, got %v", name, c.Webhook.ConversionReviewVersions)
+		}
+
+		for _, v := range crd.Spec.Versions {
+			obj, err := s.New(schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind})
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			_, hub := obj.(conversion.Hub)
+			_, convertible := obj.(conversion.Convertible)
+			switch {
+			case v.Storage && !hub:
+				t.Errorf("%s: want storage version %s to be the conversion hub", name, v.Name)
+			case !v.Storage && !convertible:
+				t.Errorf("%s: want version %s to be convertible to the hub", name, v.Name)
+			}
+		}
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		kind        string
+		forProvider map[string]interface{}
+		field       string
+		want        string
+	}{
+		"LabelColor": {
+			reason:      "A label color that is not hexadecimal should be rejected.",
+			kind:        "Label.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"color": "red"},
+			field:       "spec.forProvider.color",
+			want:        `spec.forProvider.color: Invalid value: "red": spec.forProvider.color in body should match '^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$'`,
+		},
+		"LabelColorLength": {
+			reason:      "A label color with neither three nor six digits should be rejected.",
+			kind:        "Label.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"color": "#ff00"},
+			field:       "spec.forProvider.color",
+			want:        `spec.forProvider.color: Invalid value: "#ff00": spec.forProvider.color in body should match '^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$'`,
+		},
+		"LabelColorShort": {
+			reason:      "A three digit label color with a leading # should be accepted.",
+			kind:        "Label.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"color": "#F00"},
+			field:       "spec.forProvider.color",
+		},
+		"LabelSetColor": {
+			reason:      "A label color of a label set that is not hexadecimal should be rejected.",
+			kind:        "LabelSet.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"labels": []interface{}{map[string]interface{}{"name": "bug", "color": "#gg0000"}}},
+			field:       "spec.forProvider.labels.color",
+			want:        `spec.forProvider.labels.color: Invalid value: "#gg0000": spec.forProvider.labels.color in body should match '^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$'`,
+		},
+		"DiscussionCategoryEmoji": {
+			reason:      "An emoji that is not in :shortcode: form should be rejected.",
+			kind:        "DiscussionCategory.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"emoji": "🎉"},
+			field:       "spec.forProvider.emoji",
+			want:        `spec.forProvider.emoji: Invalid value: "🎉": spec.forProvider.emoji in body should match '^:[a-z0-9_+-]+:$'`,
+		},
+		"DiscussionCategoryEmojiUnclosed": {
+			reason:      "A shortcode without its closing colon should be rejected.",
+			kind:        "DiscussionCategory.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"emoji": ":tada"},
+			field:       "spec.forProvider.emoji",
+			want:        `spec.forProvider.emoji: Invalid value: ":tada": spec.forProvider.emoji in body should match '^:[a-z0-9_+-]+:$'`,
+		},
+		"DiscussionCategoryShortcode": {
+			reason:      "An emoji in :shortcode: form should be accepted.",
+			kind:        "DiscussionCategory.repo.github.hasheddan.io",
+			forProvider: map[string]interface{}{"emoji": ":+1:"},
+			field:       "spec.forProvider.emoji",
+		},
+		"OrganizationWebhookContentType": {
+			reason:      "A webhook content type other than json or form should be rejected.",
+			kind:        "OrganizationWebhook.org.github.hasheddan.io",
+			forProvider: map[string]interface{}{"contentType": "xml"},
+			field:       "spec.forProvider.contentType",
+			want:        `spec.forProvider.contentType: Unsupported value: "xml": supported values: "json", "form"`,
+		},
+		"OrganizationWebhookForm": {
+			reason:      "The form webhook content type should be accepted.",
+			kind:        "OrganizationWebhook.org.github.hasheddan.io",
+			forProvider: map[string]interface{}{"contentType": "form"},
+			field:       "spec.forProvider.contentType",
+		},
+	}
+
+	defined := readCRDs(t)
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			crd, ok := defined[tc.kind]
+			if !ok {
+				t.Fatalf("%s: no CRD was generated", tc.kind)
+			}
+			v := crd.Spec.Versions[0]
+			in := &apiextensions.CustomResourceValidation{}
+			if err := extv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(v.Schema, in, nil); err != nil {
+				t.Fatal(err)
+			}
+			validator, _, err := validation.NewSchemaValidator(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj := map[string]interface{}{
+				"apiVersion": crd.Spec.Group + "/" + v.Name,
+				"kind":       crd.Spec.Names.Kind,
+				"spec":       map[string]interface{}{"forProvider": tc.forProvider},
+			}
+
+			// Only the errors of the field under test matter; the objects
+			// leave out other required fields.
+			var got []string
+			for _, err := range validation.ValidateCustomResource(nil, obj, validator) {
+				if err.Field == tc.field {
+					got = append(got, err.Error())
+				}
+			}
+			var want []string
+			if tc.want != "" {
+				want = []string{tc.want}
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\nValidateCustomResource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
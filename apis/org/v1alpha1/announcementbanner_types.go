@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// AnnouncementBannerParameters are the configurable fields of an
+// AnnouncementBanner.
+type AnnouncementBannerParameters struct {
+	// The login of the organization whose members see the banner. The
+	// banner is shown to every user of the enterprise when unset.
+	// +optional
+	Org *string `json:"org,omitempty"`
+
+	// The message of the banner. GitHub Flavored Markdown is supported.
+	Message string `json:"message"`
+
+	// The time the banner expires. The banner does not expire when unset.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Whether users can dismiss the banner.
+	// +optional
+	UserDismissible *bool `json:"userDismissible,omitempty"`
+}
+
+// AnnouncementBannerObservation are the observable fields of an
+// AnnouncementBanner.
+type AnnouncementBannerObservation struct {
+	// The time the banner expires, as reported by GitHub.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// An AnnouncementBannerSpec defines the desired state of an AnnouncementBanner.
+type AnnouncementBannerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AnnouncementBannerParameters `json:"forProvider"`
+}
+
+// An AnnouncementBannerStatus represents the observed state of an
+// AnnouncementBanner.
+type AnnouncementBannerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          AnnouncementBannerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AnnouncementBanner is an announcement banner of a GitHub Enterprise Server
+// or one of its organizations. It is not available on github.com.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="EXPIRES-AT",type="string",JSONPath=".spec.forProvider.expiresAt"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type AnnouncementBanner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AnnouncementBannerSpec   `json:"spec"`
+	Status AnnouncementBannerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AnnouncementBannerList contains a list of AnnouncementBanner
+type AnnouncementBannerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AnnouncementBanner `json:"items"`
+}
+
+// AnnouncementBanner type metadata.
+var (
+	AnnouncementBannerKind             = reflect.TypeOf(AnnouncementBanner{}).Name()
+	AnnouncementBannerGroupKind        = schema.GroupKind{Group: Group, Kind: AnnouncementBannerKind}.String()
+	AnnouncementBannerKindAPIVersion   = AnnouncementBannerKind + "." + SchemeGroupVersion.String()
+	AnnouncementBannerGroupVersionKind = SchemeGroupVersion.WithKind(AnnouncementBannerKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AnnouncementBanner{}, &AnnouncementBannerList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// CustomRepositoryRoleParameters are the configurable fields of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleParameters struct {
+	// The login of the organization.
+	Org string `json:"org"`
+
+	// The name of the role.
+	Name string `json:"name"`
+
+	// A short description of the role.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The system role the custom role inherits permissions from.
+	// +kubebuilder:validation:Enum=read;triage;write;maintain
+	BaseRole string `json:"baseRole"`
+
+	// The additional permissions granted by the role, for example
+	// delete_alerts_code_scanning. The order is insignificant.
+	// +optional
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// CustomRepositoryRoleObservation are the observable fields of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleObservation struct {
+	// The ID of the role.
+	ID int64 `json:"id,omitempty"`
+}
+
+// A CustomRepositoryRoleSpec defines the desired state of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CustomRepositoryRoleParameters `json:"forProvider"`
+}
+
+// A CustomRepositoryRoleStatus represents the observed state of a
+// CustomRepositoryRole.
+type CustomRepositoryRoleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          CustomRepositoryRoleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CustomRepositoryRole is a repository role of an organization that extends a
+// base role with additional permissions.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="BASE-ROLE",type="string",JSONPath=".spec.forProvider.baseRole"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type CustomRepositoryRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomRepositoryRoleSpec   `json:"spec"`
+	Status CustomRepositoryRoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomRepositoryRoleList contains a list of CustomRepositoryRole
+type CustomRepositoryRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomRepositoryRole `json:"items"`
+}
+
+// CustomRepositoryRole type metadata.
+var (
+	CustomRepositoryRoleKind             = reflect.TypeOf(CustomRepositoryRole{}).Name()
+	CustomRepositoryRoleGroupKind        = schema.GroupKind{Group: Group, Kind: CustomRepositoryRoleKind}.String()
+	CustomRepositoryRoleKindAPIVersion   = CustomRepositoryRoleKind + "." + SchemeGroupVersion.String()
+	CustomRepositoryRoleGroupVersionKind = SchemeGroupVersion.WithKind(CustomRepositoryRoleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CustomRepositoryRole{}, &CustomRepositoryRoleList{})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationCustomPropertyParameters are the configurable fields of an
+// OrganizationCustomProperty.
+type OrganizationCustomPropertyParameters struct {
+	// The login of the organization. The name of the property is the
+	// external name of the resource.
+	Org string `json:"org"`
+
+	// The type of the values of the property.
+	// +kubebuilder:validation:Enum=string;single_select;multi_select;true_false
+	ValueType string `json:"valueType"`
+
+	// Whether every repository must have a value for the property.
+	// +optional
+	Required *bool `json:"required,omitempty"`
+
+	// The value of the property for repositories that do not set one.
+	// Required properties must have a default value.
+	// +optional
+	DefaultValue *string `json:"defaultValue,omitempty"`
+
+	// A short description of the property.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The values a repository may choose from. Only applies to
+	// single_select and multi_select properties. GitHub keeps the order
+	// and case of the values as supplied.
+	// +optional
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// Whether the property is protected from deletion. Deleting a property
+	// removes its values from every repository of the organization, so
+	// the resource cannot be deleted while this is true.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+}
+
+// OrganizationCustomPropertyObservation are the observable fields of an
+// OrganizationCustomProperty.
+type OrganizationCustomPropertyObservation struct {
+	// Who may edit the values of the property, either org_actors or
+	// org_and_repo_actors.
+	ValuesEditableBy string `json:"valuesEditableBy,omitempty"`
+}
+
+// An OrganizationCustomPropertySpec defines the desired state of an
+// OrganizationCustomProperty.
+type OrganizationCustomPropertySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationCustomPropertyParameters `json:"forProvider"`
+}
+
+// An OrganizationCustomPropertyStatus represents the observed state of an
+// OrganizationCustomProperty.
+type OrganizationCustomPropertyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationCustomPropertyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationCustomProperty defines a custom property that repositories of
+// an organization can set values for.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="VALUE-TYPE",type="string",JSONPath=".spec.forProvider.valueType"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationCustomProperty struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationCustomPropertySpec   `json:"spec"`
+	Status OrganizationCustomPropertyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationCustomPropertyList contains a list of OrganizationCustomProperty
+type OrganizationCustomPropertyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationCustomProperty `json:"items"`
+}
+
+// OrganizationCustomProperty type metadata.
+var (
+	OrganizationCustomPropertyKind             = reflect.TypeOf(OrganizationCustomProperty{}).Name()
+	OrganizationCustomPropertyGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationCustomPropertyKind}.String()
+	OrganizationCustomPropertyKindAPIVersion   = OrganizationCustomPropertyKind + "." + SchemeGroupVersion.String()
+	OrganizationCustomPropertyGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationCustomPropertyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationCustomProperty{}, &OrganizationCustomPropertyList{})
+}
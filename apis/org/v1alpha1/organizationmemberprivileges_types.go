@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationMemberPrivilegesParameters are the configurable fields of an
+// OrganizationMemberPrivileges.
+type OrganizationMemberPrivilegesParameters struct {
+	// The login of the organization.
+	Org string `json:"org"`
+
+	// The default permission members have on the organization's
+	// repositories.
+	// +kubebuilder:validation:Enum=read;write;admin;none
+	// +optional
+	DefaultRepositoryPermission *string `json:"defaultRepositoryPermission,omitempty"`
+
+	// Whether members can create repositories.
+	// +optional
+	MembersCanCreateRepositories *bool `json:"membersCanCreateRepositories,omitempty"`
+}
+
+// OrganizationMemberPrivilegesObservation are the observable fields of an
+// OrganizationMemberPrivileges.
+type OrganizationMemberPrivilegesObservation struct {
+	// Whether members must enable two-factor authentication. This is only
+	// observed, since enabling it removes noncompliant members.
+	TwoFactorRequirementEnabled bool `json:"twoFactorRequirementEnabled,omitempty"`
+}
+
+// An OrganizationMemberPrivilegesSpec defines the desired state of an
+// OrganizationMemberPrivileges.
+type OrganizationMemberPrivilegesSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationMemberPrivilegesParameters `json:"forProvider"`
+}
+
+// An OrganizationMemberPrivilegesStatus represents the observed state of an
+// OrganizationMemberPrivileges.
+type OrganizationMemberPrivilegesStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationMemberPrivilegesObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationMemberPrivileges manages the base privileges of an
+// organization's members. It is a narrower alternative to OrganizationSettings;
+// the two should not manage the same fields of an organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="DEFAULT-PERMISSION",type="string",JSONPath=".spec.forProvider.defaultRepositoryPermission"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationMemberPrivileges struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationMemberPrivilegesSpec   `json:"spec"`
+	Status OrganizationMemberPrivilegesStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationMemberPrivilegesList contains a list of OrganizationMemberPrivileges
+type OrganizationMemberPrivilegesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationMemberPrivileges `json:"items"`
+}
+
+// OrganizationMemberPrivileges type metadata.
+var (
+	OrganizationMemberPrivilegesKind             = reflect.TypeOf(OrganizationMemberPrivileges{}).Name()
+	OrganizationMemberPrivilegesGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationMemberPrivilegesKind}.String()
+	OrganizationMemberPrivilegesKindAPIVersion   = OrganizationMemberPrivilegesKind + "." + SchemeGroupVersion.String()
+	OrganizationMemberPrivilegesGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationMemberPrivilegesKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationMemberPrivileges{}, &OrganizationMemberPrivilegesList{})
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationMembershipSetParameters are the configurable fields of an
+// OrganizationMembershipSet.
+type OrganizationMembershipSetParameters struct {
+	// The organization to manage the members of.
+	Org string `json:"org"`
+
+	// The users that should be members of the organization.
+	Members []OrganizationMember `json:"members"`
+
+	// Whether members of the organization that are not part of the set
+	// should be removed. Removing members also requires allowRemovals.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+
+	// AllowRemovals confirms that pruning may remove members from the
+	// organization. Pruning without it fails instead. The authenticated user
+	// and the last admin of the organization are never removed.
+	// +optional
+	AllowRemovals bool `json:"allowRemovals,omitempty"`
+}
+
+// An OrganizationMember is a member of an organization.
+type OrganizationMember struct {
+	// The login of the user.
+	User string `json:"user"`
+
+	// The role of the user in the organization.
+	// +kubebuilder:validation:Enum=member;admin
+	// +kubebuilder:default=member
+	// +optional
+	Role string `json:"role,omitempty"`
+}
+
+// An OrganizationMemberFailure is a user whose membership could not be
+// synced.
+type OrganizationMemberFailure struct {
+	User    string `json:"user"`
+	Message string `json:"message"`
+}
+
+// OrganizationMembershipSetObservation are the observable fields of an
+// OrganizationMembershipSet.
+type OrganizationMembershipSetObservation struct {
+	// The number of members of the organization.
+	Total int `json:"total,omitempty"`
+
+	// The number of admins of the organization.
+	Admins int `json:"admins,omitempty"`
+
+	// The number of users that are invited but have not accepted yet.
+	Pending int `json:"pending,omitempty"`
+
+	// The number of users that are missing, have a different role than the
+	// set, or would be pruned.
+	OutOfSync int `json:"outOfSync,omitempty"`
+
+	// The members that would be pruned, or demoted, but are kept because
+	// they are the authenticated user or the last admin.
+	Protected []string `json:"protected,omitempty"`
+
+	// The users whose membership could not be synced during the last sync.
+	Failed []OrganizationMemberFailure `json:"failed,omitempty"`
+}
+
+// An OrganizationMembershipSetSpec defines the desired state of an
+// OrganizationMembershipSet.
+type OrganizationMembershipSetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationMembershipSetParameters `json:"forProvider"`
+}
+
+// An OrganizationMembershipSetStatus represents the observed state of an
+// OrganizationMembershipSet.
+type OrganizationMembershipSetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationMembershipSetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationMembershipSet syncs the members of an organization, and their
+// roles.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="OUT-OF-SYNC",type="integer",JSONPath=".status.atProvider.outOfSync"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationMembershipSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationMembershipSetSpec   `json:"spec"`
+	Status OrganizationMembershipSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationMembershipSetList contains a list of OrganizationMembershipSet
+type OrganizationMembershipSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationMembershipSet `json:"items"`
+}
+
+// OrganizationMembershipSet type metadata.
+var (
+	OrganizationMembershipSetKind             = reflect.TypeOf(OrganizationMembershipSet{}).Name()
+	OrganizationMembershipSetGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationMembershipSetKind}.String()
+	OrganizationMembershipSetKindAPIVersion   = OrganizationMembershipSetKind + "." + SchemeGroupVersion.String()
+	OrganizationMembershipSetGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationMembershipSetKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationMembershipSet{}, &OrganizationMembershipSetList{})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationRoleAssignmentParameters are the configurable fields of an
+// OrganizationRoleAssignment.
+type OrganizationRoleAssignmentParameters struct {
+	// The login of the organization.
+	Org string `json:"org"`
+
+	// The ID of the organization role. Either roleID or roleName must be
+	// set.
+	// +optional
+	RoleID *int64 `json:"roleID,omitempty"`
+
+	// The name of the organization role, for example security_manager.
+	// Either roleID or roleName must be set.
+	// +optional
+	RoleName *string `json:"roleName,omitempty"`
+
+	// The login of the user to assign the role to. Either user or team
+	// must be set.
+	// +optional
+	User *string `json:"user,omitempty"`
+
+	// The slug of the team to assign the role to. Either user or team must
+	// be set.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:extractor=TeamSlug()
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+}
+
+// OrganizationRoleAssignmentObservation are the observable fields of an
+// OrganizationRoleAssignment.
+type OrganizationRoleAssignmentObservation struct {
+	// The ID of the assigned organization role.
+	RoleID int64 `json:"roleID,omitempty"`
+
+	// The name of the assigned organization role.
+	RoleName string `json:"roleName,omitempty"`
+}
+
+// An OrganizationRoleAssignmentSpec defines the desired state of an
+// OrganizationRoleAssignment.
+type OrganizationRoleAssignmentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationRoleAssignmentParameters `json:"forProvider"`
+}
+
+// An OrganizationRoleAssignmentStatus represents the observed state of an
+// OrganizationRoleAssignment.
+type OrganizationRoleAssignmentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationRoleAssignmentObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationRoleAssignment assigns an organization role to a user or a
+// team.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.roleName"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationRoleAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationRoleAssignmentSpec   `json:"spec"`
+	Status OrganizationRoleAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationRoleAssignmentList contains a list of OrganizationRoleAssignment
+type OrganizationRoleAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationRoleAssignment `json:"items"`
+}
+
+// OrganizationRoleAssignment type metadata.
+var (
+	OrganizationRoleAssignmentKind             = reflect.TypeOf(OrganizationRoleAssignment{}).Name()
+	OrganizationRoleAssignmentGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationRoleAssignmentKind}.String()
+	OrganizationRoleAssignmentKindAPIVersion   = OrganizationRoleAssignmentKind + "." + SchemeGroupVersion.String()
+	OrganizationRoleAssignmentGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationRoleAssignmentKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationRoleAssignment{}, &OrganizationRoleAssignmentList{})
+}
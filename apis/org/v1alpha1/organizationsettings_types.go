@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationSettingsParameters are the configurable fields of an
+// OrganizationSettings.
+type OrganizationSettingsParameters struct {
+	// The login of the organization. Each organization should be managed by
+	// at most one OrganizationSettings.
+	Org string `json:"org"`
+
+	// The billing email address of the organization.
+	// +optional
+	BillingEmail *string `json:"billingEmail,omitempty"`
+
+	// The company name of the organization.
+	// +optional
+	Company *string `json:"company,omitempty"`
+
+	// The description of the organization.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The default permission members have on the organization's
+	// repositories.
+	// +kubebuilder:validation:Enum=read;write;admin;none
+	// +optional
+	DefaultRepositoryPermission *string `json:"defaultRepositoryPermission,omitempty"`
+
+	// The name of the default branch of new repositories. It must be a valid
+	// Git branch name.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	DefaultRepositoryBranch *string `json:"defaultRepositoryBranch,omitempty"`
+
+	// Whether members can create repositories.
+	// +optional
+	MembersCanCreateRepositories *bool `json:"membersCanCreateRepositories,omitempty"`
+
+	// Whether members can create public repositories.
+	// +optional
+	MembersCanCreatePublicRepositories *bool `json:"membersCanCreatePublicRepositories,omitempty"`
+
+	// Whether members can create private repositories.
+	// +optional
+	MembersCanCreatePrivateRepositories *bool `json:"membersCanCreatePrivateRepositories,omitempty"`
+
+	// Whether members can create internal repositories. Only available to
+	// organizations that belong to an enterprise; enabling it for any other
+	// plan is rejected before it is sent.
+	// +optional
+	MembersCanCreateInternalRepositories *bool `json:"membersCanCreateInternalRepositories,omitempty"`
+
+	// Whether members can create GitHub Pages sites.
+	// +optional
+	MembersCanCreatePages *bool `json:"membersCanCreatePages,omitempty"`
+
+	// Whether members can fork private repositories.
+	// +optional
+	MembersCanForkPrivateRepositories *bool `json:"membersCanForkPrivateRepositories,omitempty"`
+
+	// Whether contributors must sign off on commits made through the web
+	// interface.
+	// +optional
+	WebCommitSignoffRequired *bool `json:"webCommitSignoffRequired,omitempty"`
+
+	// Whether the dependency graph is enabled for new repositories.
+	// +optional
+	DependencyGraphEnabledForNewRepositories *bool `json:"dependencyGraphEnabledForNewRepositories,omitempty"`
+
+	// Whether Dependabot alerts are enabled for new repositories.
+	// +optional
+	DependabotAlertsEnabledForNewRepositories *bool `json:"dependabotAlertsEnabledForNewRepositories,omitempty"`
+
+	// Whether Dependabot security updates are enabled for new repositories.
+	// +optional
+	DependabotSecurityUpdatesEnabledForNewRepositories *bool `json:"dependabotSecurityUpdatesEnabledForNewRepositories,omitempty"`
+
+	// Whether secret scanning is enabled for new repositories.
+	// +optional
+	SecretScanningEnabledForNewRepositories *bool `json:"secretScanningEnabledForNewRepositories,omitempty"`
+
+	// Whether secret scanning push protection is enabled for new
+	// repositories.
+	// +optional
+	SecretScanningPushProtectionEnabledForNewRepositories *bool `json:"secretScanningPushProtectionEnabledForNewRepositories,omitempty"`
+}
+
+// OrganizationSettingsObservation are the observable fields of an
+// OrganizationSettings.
+type OrganizationSettingsObservation struct {
+	ID     int64  `json:"id,omitempty"`
+	NodeID string `json:"nodeId,omitempty"`
+	Plan   string `json:"plan,omitempty"`
+}
+
+// An OrganizationSettingsSpec defines the desired state of an
+// OrganizationSettings.
+type OrganizationSettingsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationSettingsParameters `json:"forProvider"`
+}
+
+// An OrganizationSettingsStatus represents the observed state of an
+// OrganizationSettings.
+type OrganizationSettingsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationSettingsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationSettings manages the profile and policy settings of an
+// organization. Settings that are not set in the spec are late-initialized from
+// the organization, so a sparse spec never resets them.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="DEFAULT-PERMISSION",type="string",JSONPath=".spec.forProvider.defaultRepositoryPermission"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationSettingsSpec   `json:"spec"`
+	Status OrganizationSettingsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationSettingsList contains a list of OrganizationSettings
+type OrganizationSettingsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationSettings `json:"items"`
+}
+
+// OrganizationSettings type metadata.
+var (
+	OrganizationSettingsKind             = reflect.TypeOf(OrganizationSettings{}).Name()
+	OrganizationSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationSettingsKind}.String()
+	OrganizationSettingsKindAPIVersion   = OrganizationSettingsKind + "." + SchemeGroupVersion.String()
+	OrganizationSettingsGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationSettingsKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationSettings{}, &OrganizationSettingsList{})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// OrganizationWebhookParameters are the configurable fields of an
+// OrganizationWebhook.
+type OrganizationWebhookParameters struct {
+	// The organization to deliver the events of.
+	Org string `json:"org"`
+
+	// The URL to deliver events to.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// The media type events are serialized as.
+	// +kubebuilder:validation:Enum=json;form
+	// +kubebuilder:default=json
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// Deliver events without verifying the TLS certificate of the URL.
+	// +optional
+	InsecureSSL bool `json:"insecureSSL,omitempty"`
+
+	// The events to deliver. Defaults to push.
+	// +kubebuilder:default={push}
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// Whether events are delivered.
+	// +kubebuilder:default=true
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// A reference to the secret key that contains the secret deliveries are
+	// signed with. The webhook is updated whenever its value changes.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// Generate the secret deliveries are signed with, rather than reading it
+	// from secretRef. The generated secret is published as the secret
+	// connection detail, so writeConnectionSecretToRef must be set. A new
+	// secret is generated if the connection secret is deleted.
+	// +optional
+	GenerateSecret bool `json:"generateSecret,omitempty"`
+}
+
+// AnnotationKeyPingOnRotation is the key of the annotation that makes the
+// controller ping an OrganizationWebhook after rotating its secret. The
+// rotation is complete once the ping was delivered successfully.
+const AnnotationKeyPingOnRotation = "org.github.hasheddan.io/ping-on-rotation"
+
+// Rotation states of the secret of an OrganizationWebhook.
+const (
+	RotationPending  = "Pending"
+	RotationComplete = "Complete"
+	RotationFailed   = "Failed"
+)
+
+// A WebhookDelivery is a delivery of a webhook event.
+type WebhookDelivery struct {
+	ID          int64        `json:"id"`
+	StatusCode  int          `json:"statusCode,omitempty"`
+	Status      string       `json:"status,omitempty"`
+	DeliveredAt *metav1.Time `json:"deliveredAt,omitempty"`
+}
+
+// OrganizationWebhookObservation are the observable fields of an
+// OrganizationWebhook.
+type OrganizationWebhookObservation struct {
+	ID int64 `json:"id,omitempty"`
+
+	// The SHA-256 hash of the secret last applied to the webhook.
+	SecretHash string `json:"secretHash,omitempty"`
+
+	// When the secret last applied to the webhook was applied.
+	SecretRotatedAt *metav1.Time `json:"secretRotatedAt,omitempty"`
+
+	// The state of the last rotation of the secret. A rotation is Pending
+	// until the ping sent after it was delivered, if one is sent.
+	// +kubebuilder:validation:Enum=Pending;Complete;Failed
+	Rotation string `json:"rotation,omitempty"`
+
+	// The delivery of the ping sent after the last rotation of the secret.
+	PingDelivery *WebhookDelivery `json:"pingDelivery,omitempty"`
+}
+
+// An OrganizationWebhookSpec defines the desired state of an
+// OrganizationWebhook.
+type OrganizationWebhookSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationWebhookParameters `json:"forProvider"`
+}
+
+// An OrganizationWebhookStatus represents the observed state of an
+// OrganizationWebhook.
+type OrganizationWebhookStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          OrganizationWebhookObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationWebhook delivers the events of an organization to a URL. Its
+// external name is the ID of the webhook. The ID and URL of the webhook are
+// published as the id and url connection details.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="ROTATION",type="string",JSONPath=".status.atProvider.rotation"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationWebhookSpec   `json:"spec"`
+	Status OrganizationWebhookStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationWebhookList contains a list of OrganizationWebhook
+type OrganizationWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationWebhook `json:"items"`
+}
+
+// OrganizationWebhook type metadata.
+var (
+	OrganizationWebhookKind             = reflect.TypeOf(OrganizationWebhook{}).Name()
+	OrganizationWebhookGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationWebhookKind}.String()
+	OrganizationWebhookKindAPIVersion   = OrganizationWebhookKind + "." + SchemeGroupVersion.String()
+	OrganizationWebhookGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationWebhookKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&OrganizationWebhook{}, &OrganizationWebhookList{})
+}
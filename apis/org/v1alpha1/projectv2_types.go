@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// ProjectV2Parameters are the configurable fields of a ProjectV2.
+type ProjectV2Parameters struct {
+	// The login of the organization that owns the project.
+	Org string `json:"org"`
+
+	// The title of the project.
+	Title string `json:"title"`
+
+	// A short description of the project.
+	// +optional
+	ShortDescription *string `json:"shortDescription,omitempty"`
+
+	// The readme of the project, in Markdown.
+	// +optional
+	Readme *string `json:"readme,omitempty"`
+
+	// Whether the project is visible to everyone.
+	// +optional
+	Public *bool `json:"public,omitempty"`
+
+	// Whether the project is closed.
+	// +optional
+	Closed *bool `json:"closed,omitempty"`
+}
+
+// ProjectV2Observation are the observable fields of a ProjectV2.
+type ProjectV2Observation struct {
+	NodeID string `json:"nodeId,omitempty"`
+	Number int    `json:"number,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// A ProjectV2Spec defines the desired state of a ProjectV2.
+type ProjectV2Spec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectV2Parameters `json:"forProvider"`
+}
+
+// A ProjectV2Status represents the observed state of a ProjectV2.
+type ProjectV2Status struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          ProjectV2Observation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProjectV2 is an organization project. Its external name is the project's
+// node ID, which is assigned by GitHub on creation.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.atProvider.url"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type ProjectV2 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectV2Spec   `json:"spec"`
+	Status ProjectV2Status `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectV2List contains a list of ProjectV2
+type ProjectV2List struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectV2 `json:"items"`
+}
+
+// ProjectV2 type metadata.
+var (
+	ProjectV2Kind             = reflect.TypeOf(ProjectV2{}).Name()
+	ProjectV2GroupKind        = schema.GroupKind{Group: Group, Kind: ProjectV2Kind}.String()
+	ProjectV2KindAPIVersion   = ProjectV2Kind + "." + SchemeGroupVersion.String()
+	ProjectV2GroupVersionKind = SchemeGroupVersion.WithKind(ProjectV2Kind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ProjectV2{}, &ProjectV2List{})
+}
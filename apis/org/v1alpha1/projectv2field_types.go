@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// ProjectV2FieldParameters are the configurable fields of a ProjectV2Field.
+type ProjectV2FieldParameters struct {
+	// The node ID of the project the field belongs to. Set from the
+	// referenced project when projectRef or projectSelector is used.
+	// +crossplane:generate:reference:type=ProjectV2
+	// +crossplane:generate:reference:extractor=ProjectV2NodeID()
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+
+	// ProjectRef refers to a ProjectV2 resource.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects one ProjectV2 resource.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+
+	// The name of the field.
+	Name string `json:"name"`
+
+	// The data type of the field. It cannot be changed once the field has
+	// been created.
+	// +kubebuilder:validation:Enum=TEXT;NUMBER;DATE;SINGLE_SELECT;ITERATION
+	DataType string `json:"dataType"`
+
+	// The options of a SINGLE_SELECT field, in the order they are shown.
+	// Options are matched with the options of the field by name, so renaming
+	// an option replaces it. Ignored for fields of other data types.
+	// +optional
+	Options []ProjectV2FieldOption `json:"options,omitempty"`
+}
+
+// A ProjectV2FieldOption is an option of a single select field.
+type ProjectV2FieldOption struct {
+	// The name of the option.
+	Name string `json:"name"`
+
+	// The display color of the option.
+	// +kubebuilder:validation:Enum=GRAY;BLUE;GREEN;YELLOW;ORANGE;RED;PINK;PURPLE
+	// +kubebuilder:default=GRAY
+	// +optional
+	Color string `json:"color,omitempty"`
+
+	// A description of the option.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// A ProjectV2FieldOptionObservation is an observed option of a single select
+// field.
+type ProjectV2FieldOptionObservation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// ProjectV2FieldObservation are the observable fields of a ProjectV2Field.
+type ProjectV2FieldObservation struct {
+	// The GraphQL node ID of the field.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// The options of a SINGLE_SELECT field.
+	Options []ProjectV2FieldOptionObservation `json:"options,omitempty"`
+}
+
+// A ProjectV2FieldSpec defines the desired state of a ProjectV2Field.
+type ProjectV2FieldSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectV2FieldParameters `json:"forProvider"`
+}
+
+// A ProjectV2FieldStatus represents the observed state of a ProjectV2Field.
+type ProjectV2FieldStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          ProjectV2FieldObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProjectV2Field is a custom field of an organization project. Its external
+// name is the field's node ID, which is assigned by GitHub on creation.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="FIELD",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="DATA-TYPE",type="string",JSONPath=".spec.forProvider.dataType"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type ProjectV2Field struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectV2FieldSpec   `json:"spec"`
+	Status ProjectV2FieldStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectV2FieldList contains a list of ProjectV2Field
+type ProjectV2FieldList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectV2Field `json:"items"`
+}
+
+// ProjectV2Field type metadata.
+var (
+	ProjectV2FieldKind             = reflect.TypeOf(ProjectV2Field{}).Name()
+	ProjectV2FieldGroupKind        = schema.GroupKind{Group: Group, Kind: ProjectV2FieldKind}.String()
+	ProjectV2FieldKindAPIVersion   = ProjectV2FieldKind + "." + SchemeGroupVersion.String()
+	ProjectV2FieldGroupVersionKind = SchemeGroupVersion.WithKind(ProjectV2FieldKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ProjectV2Field{}, &ProjectV2FieldList{})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+)
+
+// TeamSlug returns an extractor that returns the slug of a Team. The slug is
+// derived from the name of the team by GitHub, so it is derived from the
+// external name until the Team has been observed.
+func TeamSlug() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		t, ok := mg.(*Team)
+		if !ok {
+			return ""
+		}
+		if t.Status.AtProvider.Slug != "" {
+			return t.Status.AtProvider.Slug
+		}
+		return externalname.Slug(meta.GetExternalName(t))
+	}
+}
+
+// TeamID returns an extractor that returns the numeric ID of a Team. It
+// returns nothing until the Team has been observed, which makes resolution
+// fail with an error that causes the referencing resource to be requeued.
+func TeamID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		t, ok := mg.(*Team)
+		if !ok || t.Status.AtProvider.ID == 0 {
+			return ""
+		}
+		return strconv.FormatInt(t.Status.AtProvider.ID, 10)
+	}
+}
+
+// ProjectV2NodeID returns an extractor that returns the node ID of a
+// ProjectV2. It returns nothing until the ProjectV2 has been observed, because
+// its external name is only a node ID once the project has been created.
+func ProjectV2NodeID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		p, ok := mg.(*ProjectV2)
+		if !ok {
+			return ""
+		}
+		return p.Status.AtProvider.NodeID
+	}
+}
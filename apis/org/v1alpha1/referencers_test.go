@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func team(name string, o ...func(*Team)) *Team {
+	t := &Team{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, fn := range o {
+		fn(t)
+	}
+	return t
+}
+
+func withExternalName(n string) func(*Team) {
+	return func(t *Team) { meta.SetExternalName(t, n) }
+}
+
+func withObserved(id int64, slug string) func(*Team) {
+	return func(t *Team) {
+		t.Status.AtProvider.ID = id
+		t.Status.AtProvider.Slug = slug
+	}
+}
+
+func TestTeamSlug(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   string
+	}{
+		"Observed": {
+			reason: "The slug GitHub reported should be used once the Team has been observed.",
+			mg:     team("platform", withExternalName("Platform Team"), withObserved(42, "platform-team-1")),
+			want:   "platform-team-1",
+		},
+		"NotObserved": {
+			reason: "The slug should be derived from the external name until the Team has been observed.",
+			mg:     team("platform", withExternalName("Platform Team!")),
+			want:   "platform-team",
+		},
+		"NotATeam": {
+			reason: "Nothing should be extracted from other kinds.",
+			mg:     &Membership{},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TeamSlug()(tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTeamSlug(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTeamID(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   string
+	}{
+		"Observed": {
+			reason: "The ID GitHub reported should be extracted once the Team has been observed.",
+			mg:     team("platform", withExternalName("platform"), withObserved(42, "platform")),
+			want:   "42",
+		},
+		"NotObserved": {
+			reason: "Nothing should be extracted until the Team has been observed.",
+			mg:     team("platform", withExternalName("platform")),
+			want:   "",
+		},
+		"NotATeam": {
+			reason: "Nothing should be extracted from other kinds.",
+			mg:     &Membership{},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TeamID()(tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTeamID(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// getTeam returns a client that gets the supplied Team.
+func getTeam(referenced *Team) client.Reader {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			referenced.DeepCopyInto(obj.(*Team))
+			return nil
+		}),
+	}
+}
+
+func TestResolveTeamReferences(t *testing.T) {
+	type want struct {
+		value string
+		err   bool
+	}
+	cases := map[string]struct {
+		reason     string
+		extract    reference.ExtractValueFn
+		referenced *Team
+		want       want
+	}{
+		"Slug": {
+			reason:     "A reference should resolve to the slug of the referenced Team.",
+			extract:    TeamSlug(),
+			referenced: team("platform", withExternalName("Platform"), withObserved(42, "platform")),
+			want:       want{value: "platform"},
+		},
+		"ID": {
+			reason:     "A reference should resolve to the ID of the referenced Team.",
+			extract:    TeamID(),
+			referenced: team("platform", withExternalName("Platform"), withObserved(42, "platform")),
+			want:       want{value: "42"},
+		},
+		"IDNotReady": {
+			reason:     "Resolving the ID of a Team that has not been observed should fail, so that the referencing resource is requeued.",
+			extract:    TeamID(),
+			referenced: team("platform", withExternalName("Platform")),
+			want:       want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			from := &Membership{}
+			rsp, err := reference.NewAPIResolver(getTeam(tc.referenced), from).Resolve(context.Background(), reference.ResolutionRequest{
+				Extract:   tc.extract,
+				Reference: &xpv1.Reference{Name: tc.referenced.GetName()},
+				To:        reference.To{Managed: &Team{}, List: &TeamList{}},
+			})
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nResolve(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.value, rsp.ResolvedValue); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want value, +got value:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMembershipResolveReferences(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		mg     *Membership
+		want   *string
+		err    error
+	}{
+		"TeamRef": {
+			reason: "A team reference should resolve to the slug of the referenced Team.",
+			kube:   getTeam(team("platform", withExternalName("Platform"), withObserved(42, "platform"))),
+			mg:     &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{TeamRef: &xpv1.Reference{Name: "platform"}}}},
+			want:   pointer.String("platform"),
+		},
+		"MatchControllerRef": {
+			reason: "A selector matching the controller should resolve to a Team with the same controller.",
+			kube: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					other := team("other", withObserved(1, "other"))
+					same := team("platform", withObserved(42, "platform"))
+					meta.AddControllerReference(same, meta.AsController(&xpv1.TypedReference{UID: "composite"}))
+					obj.(*TeamList).Items = []Team{*other, *same}
+					return nil
+				}),
+			},
+			mg: func() *Membership {
+				m := &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{TeamSelector: &xpv1.Selector{MatchControllerRef: pointer.Bool(true)}}}}
+				meta.AddControllerReference(m, meta.AsController(&xpv1.TypedReference{UID: "composite"}))
+				return m
+			}(),
+			want: pointer.String("platform"),
+		},
+		"GetError": {
+			reason: "Errors getting the referenced Team should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			mg:     &Membership{Spec: MembershipSpec{ForProvider: MembershipParameters{TeamRef: &xpv1.Reference{Name: "platform"}}}},
+			err:    errors.Wrap(errors.Wrap(errors.New("boom"), "cannot get referenced resource"), "mg.Spec.ForProvider.Team"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.mg.ResolveReferences(context.Background(), tc.kube)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nResolveReferences(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.mg.Spec.ForProvider.Team); diff != "" {
+				t.Errorf("\n%s\nResolveReferences(...): -want team, +got team:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
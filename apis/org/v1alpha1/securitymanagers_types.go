@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// SecurityManagersParameters are the configurable fields of a SecurityManagers.
+type SecurityManagersParameters struct {
+	// The login of the organization.
+	Org string `json:"org"`
+
+	// The slugs of the teams that should be security managers.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:extractor=TeamSlug()
+	// +crossplane:generate:reference:refFieldName=TeamRefs
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// TeamRefs refer to Team resources.
+	// +optional
+	TeamRefs []xpv1.Reference `json:"teamRefs,omitempty"`
+
+	// TeamSelector selects Team resources.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// Whether the teams are the authoritative list of security managers.
+	// When true, teams that are security managers but not listed are
+	// removed.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// SecurityManagersObservation are the observable fields of a SecurityManagers.
+type SecurityManagersObservation struct {
+	// The slugs of the teams that are security managers.
+	Teams []string `json:"teams,omitempty"`
+}
+
+// A SecurityManagersSpec defines the desired state of a SecurityManagers.
+type SecurityManagersSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecurityManagersParameters `json:"forProvider"`
+}
+
+// A SecurityManagersStatus represents the observed state of a SecurityManagers.
+type SecurityManagersStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          SecurityManagersObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityManagers grants the security manager role of an organization to
+// teams.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type SecurityManagers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityManagersSpec   `json:"spec"`
+	Status SecurityManagersStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityManagersList contains a list of SecurityManagers
+type SecurityManagersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityManagers `json:"items"`
+}
+
+// SecurityManagers type metadata.
+var (
+	SecurityManagersKind             = reflect.TypeOf(SecurityManagers{}).Name()
+	SecurityManagersGroupKind        = schema.GroupKind{Group: Group, Kind: SecurityManagersKind}.String()
+	SecurityManagersKindAPIVersion   = SecurityManagersKind + "." + SchemeGroupVersion.String()
+	SecurityManagersGroupVersionKind = SchemeGroupVersion.WithKind(SecurityManagersKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SecurityManagers{}, &SecurityManagersList{})
+}
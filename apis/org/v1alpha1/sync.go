@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "github.com/hasheddan/kc-provider-github/apis/common"
+
+// GetSyncStatus returns when this AnnouncementBanner was last compared with its external
+// resource.
+func (mg *AnnouncementBanner) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this CustomRepositoryRole was last compared with its external
+// resource.
+func (mg *CustomRepositoryRole) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Membership was last compared with its external
+// resource.
+func (mg *Membership) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationCustomProperty was last compared with its external
+// resource.
+func (mg *OrganizationCustomProperty) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationMemberPrivileges was last compared with its external
+// resource.
+func (mg *OrganizationMemberPrivileges) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationRoleAssignment was last compared with its external
+// resource.
+func (mg *OrganizationRoleAssignment) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationSettings was last compared with its external
+// resource.
+func (mg *OrganizationSettings) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this ProjectV2 was last compared with its external
+// resource.
+func (mg *ProjectV2) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this SecurityManagers was last compared with its external
+// resource.
+func (mg *SecurityManagers) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Team was last compared with its external
+// resource.
+func (mg *Team) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this TeamExternalGroup was last compared with its external
+// resource.
+func (mg *TeamExternalGroup) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationWebhook was last compared with its external
+// resource.
+func (mg *OrganizationWebhook) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this TeamRepositorySet was last compared with its external
+// resource.
+func (mg *TeamRepositorySet) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this OrganizationMembershipSet was last compared with its external
+// resource.
+func (mg *OrganizationMembershipSet) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this ProjectV2Field was last compared with its external
+// resource.
+func (mg *ProjectV2Field) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+// AnnotationKeyTeamV1Beta1Parameters is the annotation a v1alpha1 Team keeps
+// the parameters only v1beta1 supports in, so they survive being converted
+// to v1alpha1 and back.
+const AnnotationKeyTeamV1Beta1Parameters = "org.github.hasheddan.io/v1beta1-parameters"
+
+const (
+	errFmtUnsupportedHub = "unsupported conversion hub %T"
+	errUnmarshalParams   = "cannot unmarshal v1beta1 parameters annotation"
+	errMarshalParams     = "cannot marshal v1beta1 parameters annotation"
+)
+
+// teamV1Beta1Parameters are the parameters of a v1beta1 Team that v1alpha1
+// does not support.
+type teamV1Beta1Parameters struct {
+	DisplayName         *string         `json:"displayName,omitempty"`
+	NotificationSetting *string         `json:"notificationSetting,omitempty"`
+	ParentTeam          *string         `json:"parentTeam,omitempty"`
+	ParentTeamRef       *xpv1.Reference `json:"parentTeamRef,omitempty"`
+	ParentTeamSelector  *xpv1.Selector  `json:"parentTeamSelector,omitempty"`
+	ObserveMembers      bool            `json:"observeMembers,omitempty"`
+}
+
+// ConvertTo converts this Team to the v1beta1 hub version.
+func (t *Team) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.Team)
+	if !ok {
+		return errors.Errorf(errFmtUnsupportedHub, hub)
+	}
+
+	dst.ObjectMeta = *t.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = *t.Spec.ResourceSpec.DeepCopy()
+	dst.Spec.ForProvider = v1beta1.TeamParameters{
+		Org:         t.Spec.ForProvider.Org,
+		Description: t.Spec.ForProvider.Description,
+		Privacy:     t.Spec.ForProvider.Privacy,
+	}
+	if raw, ok := dst.GetAnnotations()[AnnotationKeyTeamV1Beta1Parameters]; ok {
+		p := teamV1Beta1Parameters{}
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			return errors.Wrap(err, errUnmarshalParams)
+		}
+		dst.Spec.ForProvider.DisplayName = p.DisplayName
+		dst.Spec.ForProvider.NotificationSetting = p.NotificationSetting
+		dst.Spec.ForProvider.ParentTeam = p.ParentTeam
+		dst.Spec.ForProvider.ParentTeamRef = p.ParentTeamRef
+		dst.Spec.ForProvider.ParentTeamSelector = p.ParentTeamSelector
+		dst.Spec.ForProvider.ObserveMembers = p.ObserveMembers
+		meta.RemoveAnnotations(dst, AnnotationKeyTeamV1Beta1Parameters)
+	}
+
+	dst.Status.ResourceStatus = *t.Status.ResourceStatus.DeepCopy()
+	dst.Status.SyncStatus = *t.Status.SyncStatus.DeepCopy()
+	dst.Status.AtProvider = v1beta1.TeamObservation{
+		ID:     t.Status.AtProvider.ID,
+		NodeID: t.Status.AtProvider.NodeID,
+		Slug:   t.Status.AtProvider.Slug,
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this Team.
+func (t *Team) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.Team)
+	if !ok {
+		return errors.Errorf(errFmtUnsupportedHub, hub)
+	}
+
+	t.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	t.Spec.ResourceSpec = *src.Spec.ResourceSpec.DeepCopy()
+	t.Spec.ForProvider = TeamParameters{
+		Org:         src.Spec.ForProvider.Org,
+		Description: src.Spec.ForProvider.Description,
+		Privacy:     src.Spec.ForProvider.Privacy,
+	}
+	p := teamV1Beta1Parameters{
+		DisplayName:         src.Spec.ForProvider.DisplayName,
+		NotificationSetting: src.Spec.ForProvider.NotificationSetting,
+		ParentTeam:          src.Spec.ForProvider.ParentTeam,
+		ParentTeamRef:       src.Spec.ForProvider.ParentTeamRef,
+		ParentTeamSelector:  src.Spec.ForProvider.ParentTeamSelector,
+		ObserveMembers:      src.Spec.ForProvider.ObserveMembers,
+	}
+	if p != (teamV1Beta1Parameters{}) {
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return errors.Wrap(err, errMarshalParams)
+		}
+		meta.AddAnnotations(t, map[string]string{AnnotationKeyTeamV1Beta1Parameters: string(raw)})
+	}
+
+	t.Status.ResourceStatus = *src.Status.ResourceStatus.DeepCopy()
+	t.Status.SyncStatus = *src.Status.SyncStatus.DeepCopy()
+	t.Status.AtProvider = TeamObservation{
+		ID:     src.Status.AtProvider.ID,
+		NodeID: src.Status.AtProvider.NodeID,
+		Slug:   src.Status.AtProvider.Slug,
+	}
+	return nil
+}
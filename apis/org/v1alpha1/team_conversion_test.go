@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	fuzz "github.com/google/gofuzz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+)
+
+// rounds is the number of randomly filled Teams each round trip is tested
+// with.
+const rounds = 1000
+
+func TestTeamHubRoundTrip(t *testing.T) {
+	f := fuzz.New().NilChance(0.2)
+	for i := 0; i < rounds; i++ {
+		want := &v1beta1.Team{}
+		f.Fuzz(want)
+		want.TypeMeta = metav1.TypeMeta{}
+
+		// v1alpha1 does not observe these fields. They are observed again
+		// the next time the Team is reconciled, so they need not survive.
+		want.Status.AtProvider = v1beta1.TeamObservation{
+			ID:     want.Status.AtProvider.ID,
+			NodeID: want.Status.AtProvider.NodeID,
+			Slug:   want.Status.AtProvider.Slug,
+		}
+
+		spoke := &Team{}
+		if err := spoke.ConvertFrom(want.DeepCopy()); err != nil {
+			t.Fatalf("ConvertFrom(...): %v", err)
+		}
+		got := &v1beta1.Team{}
+		if err := spoke.ConvertTo(got); err != nil {
+			t.Fatalf("ConvertTo(...): %v", err)
+		}
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("\nA v1beta1 Team converted to v1alpha1 and back should not change.\nConvertTo(ConvertFrom(...)): -want, +got:\n%s", diff)
+		}
+	}
+}
+
+func TestTeamSpokeRoundTrip(t *testing.T) {
+	f := fuzz.New().NilChance(0.2)
+	for i := 0; i < rounds; i++ {
+		want := &Team{}
+		f.Fuzz(want)
+		want.TypeMeta = metav1.TypeMeta{}
+
+		hub := &v1beta1.Team{}
+		if err := want.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo(...): %v", err)
+		}
+		got := &Team{}
+		if err := got.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom(...): %v", err)
+		}
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("\nA v1alpha1 Team converted to v1beta1 and back should not change.\nConvertFrom(ConvertTo(...)): -want, +got:\n%s", diff)
+		}
+	}
+}
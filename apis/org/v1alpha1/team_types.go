@@ -22,6 +22,8 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
 )
 
 // TeamParameters are the configurable fields of a Team.
@@ -39,7 +41,9 @@ type TeamParameters struct {
 
 // TeamObservation are the observable fields of a Team.
 type TeamObservation struct {
+	ID     int64  `json:"id,omitempty"`
 	NodeID string `json:"nodeId,omitempty"`
+	Slug   string `json:"slug,omitempty"`
 }
 
 // A TeamSpec defines the desired state of a Team.
@@ -51,6 +55,7 @@ type TeamSpec struct {
 // A TeamStatus represents the observed state of a Team.
 type TeamStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
 	AtProvider          TeamObservation `json:"atProvider,omitempty"`
 }
 
@@ -58,9 +63,14 @@ type TeamStatus struct {
 
 // A Team is an example API type
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="PRIVACY",type="string",JSONPath=".spec.forProvider.privacy"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
 type Team struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
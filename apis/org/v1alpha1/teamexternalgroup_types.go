@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// TeamExternalGroupParameters are the configurable fields of a
+// TeamExternalGroup.
+type TeamExternalGroupParameters struct {
+	// The login of the organization. It must use Enterprise Managed Users.
+	Org string `json:"org"`
+
+	// The slug of the team to connect. A team can be connected to at most
+	// one external group.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:extractor=TeamSlug()
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// The ID of the identity provider group to connect the team to.
+	ExternalGroupID int64 `json:"externalGroupID"`
+}
+
+// TeamExternalGroupObservation are the observable fields of a
+// TeamExternalGroup.
+type TeamExternalGroupObservation struct {
+	// The name of the connected identity provider group.
+	GroupName string `json:"groupName,omitempty"`
+
+	// The time the group was last updated from the identity provider.
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
+
+// A TeamExternalGroupSpec defines the desired state of a TeamExternalGroup.
+type TeamExternalGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamExternalGroupParameters `json:"forProvider"`
+}
+
+// A TeamExternalGroupStatus represents the observed state of a
+// TeamExternalGroup.
+type TeamExternalGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          TeamExternalGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamExternalGroup connects a team of an organization that uses Enterprise
+// Managed Users to a group of its identity provider.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="TEAM",type="string",JSONPath=".spec.forProvider.team"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type TeamExternalGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamExternalGroupSpec   `json:"spec"`
+	Status TeamExternalGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamExternalGroupList contains a list of TeamExternalGroup
+type TeamExternalGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamExternalGroup `json:"items"`
+}
+
+// TeamExternalGroup type metadata.
+var (
+	TeamExternalGroupKind             = reflect.TypeOf(TeamExternalGroup{}).Name()
+	TeamExternalGroupGroupKind        = schema.GroupKind{Group: Group, Kind: TeamExternalGroupKind}.String()
+	TeamExternalGroupKindAPIVersion   = TeamExternalGroupKind + "." + SchemeGroupVersion.String()
+	TeamExternalGroupGroupVersionKind = SchemeGroupVersion.WithKind(TeamExternalGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamExternalGroup{}, &TeamExternalGroupList{})
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// TeamRepositorySetParameters are the configurable fields of a
+// TeamRepositorySet.
+type TeamRepositorySetParameters struct {
+	// The organization the team and repositories belong to.
+	Org string `json:"org"`
+
+	// The slug of the team to grant access.
+	// +crossplane:generate:reference:type=github.com/hasheddan/kc-provider-github/apis/org/v1alpha1.Team
+	// +crossplane:generate:reference:extractor=TeamSlug()
+	// +crossplane:generate:reference:refFieldName=TeamRef
+	// +crossplane:generate:reference:selectorFieldName=TeamSelector
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// TeamRef refers to a Team resource.
+	// +optional
+	TeamRef *xpv1.Reference `json:"teamRef,omitempty"`
+
+	// TeamSelector selects one Team resource.
+	// +optional
+	TeamSelector *xpv1.Selector `json:"teamSelector,omitempty"`
+
+	// The repositories the team should have access to.
+	Repositories []TeamRepositoryGrant `json:"repositories"`
+
+	// Whether access of the team to repositories that are not part of the
+	// set should be revoked.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// A TeamRepositoryGrant grants a team access to a repository.
+type TeamRepositoryGrant struct {
+	// The name of the repository. Matched against the repositories of the
+	// team without regard to case, as GitHub does.
+	Repository string `json:"repository"`
+
+	// The permission to grant: pull, triage, push, maintain, admin, or the
+	// name of a custom repository role of the organization.
+	// +kubebuilder:default=pull
+	// +optional
+	Permission string `json:"permission,omitempty"`
+}
+
+// A TeamRepositoryFailure is a repository whose access could not be synced.
+type TeamRepositoryFailure struct {
+	Repository string `json:"repository"`
+	Message    string `json:"message"`
+}
+
+// TeamRepositorySetObservation are the observable fields of a
+// TeamRepositorySet.
+type TeamRepositorySetObservation struct {
+	// The number of repositories the team has access to.
+	Total int `json:"total,omitempty"`
+
+	// The number of repositories that are missing, are granted a different
+	// permission than the set, or would be pruned.
+	OutOfSync int `json:"outOfSync,omitempty"`
+
+	// The repositories whose access could not be synced during the last
+	// sync.
+	Failed []TeamRepositoryFailure `json:"failed,omitempty"`
+}
+
+// A TeamRepositorySetSpec defines the desired state of a TeamRepositorySet.
+type TeamRepositorySetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamRepositorySetParameters `json:"forProvider"`
+}
+
+// A TeamRepositorySetStatus represents the observed state of a
+// TeamRepositorySet.
+type TeamRepositorySetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          TeamRepositorySetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamRepositorySet syncs the repositories a team has access to, and the
+// permissions it has on them.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="OUT-OF-SYNC",type="integer",JSONPath=".status.atProvider.outOfSync"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type TeamRepositorySet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamRepositorySetSpec   `json:"spec"`
+	Status TeamRepositorySetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamRepositorySetList contains a list of TeamRepositorySet
+type TeamRepositorySetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamRepositorySet `json:"items"`
+}
+
+// TeamRepositorySet type metadata.
+var (
+	TeamRepositorySetKind             = reflect.TypeOf(TeamRepositorySet{}).Name()
+	TeamRepositorySetGroupKind        = schema.GroupKind{Group: Group, Kind: TeamRepositorySetKind}.String()
+	TeamRepositorySetKindAPIVersion   = TeamRepositorySetKind + "." + SchemeGroupVersion.String()
+	TeamRepositorySetGroupVersionKind = SchemeGroupVersion.WithKind(TeamRepositorySetKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamRepositorySet{}, &TeamRepositorySetList{})
+}
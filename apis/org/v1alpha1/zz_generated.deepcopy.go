@@ -26,6 +26,282 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBanner) DeepCopyInto(out *AnnouncementBanner) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBanner.
+func (in *AnnouncementBanner) DeepCopy() *AnnouncementBanner {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBanner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnnouncementBanner) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBannerList) DeepCopyInto(out *AnnouncementBannerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AnnouncementBanner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBannerList.
+func (in *AnnouncementBannerList) DeepCopy() *AnnouncementBannerList {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBannerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnnouncementBannerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBannerObservation) DeepCopyInto(out *AnnouncementBannerObservation) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBannerObservation.
+func (in *AnnouncementBannerObservation) DeepCopy() *AnnouncementBannerObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBannerObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBannerParameters) DeepCopyInto(out *AnnouncementBannerParameters) {
+	*out = *in
+	if in.Org != nil {
+		in, out := &in.Org, &out.Org
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.UserDismissible != nil {
+		in, out := &in.UserDismissible, &out.UserDismissible
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBannerParameters.
+func (in *AnnouncementBannerParameters) DeepCopy() *AnnouncementBannerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBannerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBannerSpec) DeepCopyInto(out *AnnouncementBannerSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBannerSpec.
+func (in *AnnouncementBannerSpec) DeepCopy() *AnnouncementBannerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBannerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnouncementBannerStatus) DeepCopyInto(out *AnnouncementBannerStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementBannerStatus.
+func (in *AnnouncementBannerStatus) DeepCopy() *AnnouncementBannerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnouncementBannerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRole) DeepCopyInto(out *CustomRepositoryRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRole.
+func (in *CustomRepositoryRole) DeepCopy() *CustomRepositoryRole {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomRepositoryRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRoleList) DeepCopyInto(out *CustomRepositoryRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomRepositoryRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRoleList.
+func (in *CustomRepositoryRoleList) DeepCopy() *CustomRepositoryRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomRepositoryRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRoleObservation) DeepCopyInto(out *CustomRepositoryRoleObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRoleObservation.
+func (in *CustomRepositoryRoleObservation) DeepCopy() *CustomRepositoryRoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRoleParameters) DeepCopyInto(out *CustomRepositoryRoleParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRoleParameters.
+func (in *CustomRepositoryRoleParameters) DeepCopy() *CustomRepositoryRoleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRoleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRoleSpec) DeepCopyInto(out *CustomRepositoryRoleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRoleSpec.
+func (in *CustomRepositoryRoleSpec) DeepCopy() *CustomRepositoryRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRepositoryRoleStatus) DeepCopyInto(out *CustomRepositoryRoleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRepositoryRoleStatus.
+func (in *CustomRepositoryRoleStatus) DeepCopy() *CustomRepositoryRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRepositoryRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Membership) DeepCopyInto(out *Membership) {
 	*out = *in
@@ -151,6 +427,7 @@ func (in *MembershipSpec) DeepCopy() *MembershipSpec {
 func (in *MembershipStatus) DeepCopyInto(out *MembershipStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 	out.AtProvider = in.AtProvider
 }
 
@@ -165,7 +442,7 @@ func (in *MembershipStatus) DeepCopy() *MembershipStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Team) DeepCopyInto(out *Team) {
+func (in *OrganizationCustomProperty) DeepCopyInto(out *OrganizationCustomProperty) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -173,18 +450,18 @@ func (in *Team) DeepCopyInto(out *Team) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Team.
-func (in *Team) DeepCopy() *Team {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomProperty.
+func (in *OrganizationCustomProperty) DeepCopy() *OrganizationCustomProperty {
 	if in == nil {
 		return nil
 	}
-	out := new(Team)
+	out := new(OrganizationCustomProperty)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Team) DeepCopyObject() runtime.Object {
+func (in *OrganizationCustomProperty) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -192,31 +469,31 @@ func (in *Team) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TeamList) DeepCopyInto(out *TeamList) {
+func (in *OrganizationCustomPropertyList) DeepCopyInto(out *OrganizationCustomPropertyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Team, len(*in))
+		*out = make([]OrganizationCustomProperty, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamList.
-func (in *TeamList) DeepCopy() *TeamList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomPropertyList.
+func (in *OrganizationCustomPropertyList) DeepCopy() *OrganizationCustomPropertyList {
 	if in == nil {
 		return nil
 	}
-	out := new(TeamList)
+	out := new(OrganizationCustomPropertyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TeamList) DeepCopyObject() runtime.Object {
+func (in *OrganizationCustomPropertyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -224,75 +501,1839 @@ func (in *TeamList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TeamObservation) DeepCopyInto(out *TeamObservation) {
+func (in *OrganizationCustomPropertyObservation) DeepCopyInto(out *OrganizationCustomPropertyObservation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamObservation.
-func (in *TeamObservation) DeepCopy() *TeamObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomPropertyObservation.
+func (in *OrganizationCustomPropertyObservation) DeepCopy() *OrganizationCustomPropertyObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(TeamObservation)
+	out := new(OrganizationCustomPropertyObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TeamParameters) DeepCopyInto(out *TeamParameters) {
+func (in *OrganizationCustomPropertyParameters) DeepCopyInto(out *OrganizationCustomPropertyParameters) {
 	*out = *in
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
 		*out = new(string)
 		**out = **in
 	}
-	if in.Privacy != nil {
-		in, out := &in.Privacy, &out.Privacy
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
 		*out = new(string)
 		**out = **in
 	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamParameters.
-func (in *TeamParameters) DeepCopy() *TeamParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomPropertyParameters.
+func (in *OrganizationCustomPropertyParameters) DeepCopy() *OrganizationCustomPropertyParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(TeamParameters)
+	out := new(OrganizationCustomPropertyParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
+func (in *OrganizationCustomPropertySpec) DeepCopyInto(out *OrganizationCustomPropertySpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
-func (in *TeamSpec) DeepCopy() *TeamSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomPropertySpec.
+func (in *OrganizationCustomPropertySpec) DeepCopy() *OrganizationCustomPropertySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TeamSpec)
+	out := new(OrganizationCustomPropertySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
+func (in *OrganizationCustomPropertyStatus) DeepCopyInto(out *OrganizationCustomPropertyStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 	out.AtProvider = in.AtProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStatus.
-func (in *TeamStatus) DeepCopy() *TeamStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationCustomPropertyStatus.
+func (in *OrganizationCustomPropertyStatus) DeepCopy() *OrganizationCustomPropertyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TeamStatus)
+	out := new(OrganizationCustomPropertyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMember) DeepCopyInto(out *OrganizationMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMember.
+func (in *OrganizationMember) DeepCopy() *OrganizationMember {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberFailure) DeepCopyInto(out *OrganizationMemberFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberFailure.
+func (in *OrganizationMemberFailure) DeepCopy() *OrganizationMemberFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivileges) DeepCopyInto(out *OrganizationMemberPrivileges) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivileges.
+func (in *OrganizationMemberPrivileges) DeepCopy() *OrganizationMemberPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivileges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMemberPrivileges) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivilegesList) DeepCopyInto(out *OrganizationMemberPrivilegesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationMemberPrivileges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivilegesList.
+func (in *OrganizationMemberPrivilegesList) DeepCopy() *OrganizationMemberPrivilegesList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivilegesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMemberPrivilegesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivilegesObservation) DeepCopyInto(out *OrganizationMemberPrivilegesObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivilegesObservation.
+func (in *OrganizationMemberPrivilegesObservation) DeepCopy() *OrganizationMemberPrivilegesObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivilegesObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivilegesParameters) DeepCopyInto(out *OrganizationMemberPrivilegesParameters) {
+	*out = *in
+	if in.DefaultRepositoryPermission != nil {
+		in, out := &in.DefaultRepositoryPermission, &out.DefaultRepositoryPermission
+		*out = new(string)
+		**out = **in
+	}
+	if in.MembersCanCreateRepositories != nil {
+		in, out := &in.MembersCanCreateRepositories, &out.MembersCanCreateRepositories
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivilegesParameters.
+func (in *OrganizationMemberPrivilegesParameters) DeepCopy() *OrganizationMemberPrivilegesParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivilegesParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivilegesSpec) DeepCopyInto(out *OrganizationMemberPrivilegesSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivilegesSpec.
+func (in *OrganizationMemberPrivilegesSpec) DeepCopy() *OrganizationMemberPrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMemberPrivilegesStatus) DeepCopyInto(out *OrganizationMemberPrivilegesStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMemberPrivilegesStatus.
+func (in *OrganizationMemberPrivilegesStatus) DeepCopy() *OrganizationMemberPrivilegesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMemberPrivilegesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSet) DeepCopyInto(out *OrganizationMembershipSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSet.
+func (in *OrganizationMembershipSet) DeepCopy() *OrganizationMembershipSet {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMembershipSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSetList) DeepCopyInto(out *OrganizationMembershipSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationMembershipSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSetList.
+func (in *OrganizationMembershipSetList) DeepCopy() *OrganizationMembershipSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMembershipSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSetObservation) DeepCopyInto(out *OrganizationMembershipSetObservation) {
+	*out = *in
+	if in.Protected != nil {
+		in, out := &in.Protected, &out.Protected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]OrganizationMemberFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSetObservation.
+func (in *OrganizationMembershipSetObservation) DeepCopy() *OrganizationMembershipSetObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSetObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSetParameters) DeepCopyInto(out *OrganizationMembershipSetParameters) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]OrganizationMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSetParameters.
+func (in *OrganizationMembershipSetParameters) DeepCopy() *OrganizationMembershipSetParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSetParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSetSpec) DeepCopyInto(out *OrganizationMembershipSetSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSetSpec.
+func (in *OrganizationMembershipSetSpec) DeepCopy() *OrganizationMembershipSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipSetStatus) DeepCopyInto(out *OrganizationMembershipSetStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipSetStatus.
+func (in *OrganizationMembershipSetStatus) DeepCopy() *OrganizationMembershipSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignment) DeepCopyInto(out *OrganizationRoleAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignment.
+func (in *OrganizationRoleAssignment) DeepCopy() *OrganizationRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationRoleAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignmentList) DeepCopyInto(out *OrganizationRoleAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationRoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignmentList.
+func (in *OrganizationRoleAssignmentList) DeepCopy() *OrganizationRoleAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationRoleAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignmentObservation) DeepCopyInto(out *OrganizationRoleAssignmentObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignmentObservation.
+func (in *OrganizationRoleAssignmentObservation) DeepCopy() *OrganizationRoleAssignmentObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignmentObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignmentParameters) DeepCopyInto(out *OrganizationRoleAssignmentParameters) {
+	*out = *in
+	if in.RoleID != nil {
+		in, out := &in.RoleID, &out.RoleID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RoleName != nil {
+		in, out := &in.RoleName, &out.RoleName
+		*out = new(string)
+		**out = **in
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(string)
+		**out = **in
+	}
+	if in.Team != nil {
+		in, out := &in.Team, &out.Team
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignmentParameters.
+func (in *OrganizationRoleAssignmentParameters) DeepCopy() *OrganizationRoleAssignmentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignmentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignmentSpec) DeepCopyInto(out *OrganizationRoleAssignmentSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignmentSpec.
+func (in *OrganizationRoleAssignmentSpec) DeepCopy() *OrganizationRoleAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleAssignmentStatus) DeepCopyInto(out *OrganizationRoleAssignmentStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleAssignmentStatus.
+func (in *OrganizationRoleAssignmentStatus) DeepCopy() *OrganizationRoleAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettings) DeepCopyInto(out *OrganizationSettings) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettings.
+func (in *OrganizationSettings) DeepCopy() *OrganizationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationSettings) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettingsList) DeepCopyInto(out *OrganizationSettingsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettingsList.
+func (in *OrganizationSettingsList) DeepCopy() *OrganizationSettingsList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettingsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationSettingsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettingsObservation) DeepCopyInto(out *OrganizationSettingsObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettingsObservation.
+func (in *OrganizationSettingsObservation) DeepCopy() *OrganizationSettingsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettingsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettingsParameters) DeepCopyInto(out *OrganizationSettingsParameters) {
+	*out = *in
+	if in.BillingEmail != nil {
+		in, out := &in.BillingEmail, &out.BillingEmail
+		*out = new(string)
+		**out = **in
+	}
+	if in.Company != nil {
+		in, out := &in.Company, &out.Company
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultRepositoryPermission != nil {
+		in, out := &in.DefaultRepositoryPermission, &out.DefaultRepositoryPermission
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultRepositoryBranch != nil {
+		in, out := &in.DefaultRepositoryBranch, &out.DefaultRepositoryBranch
+		*out = new(string)
+		**out = **in
+	}
+	if in.MembersCanCreateRepositories != nil {
+		in, out := &in.MembersCanCreateRepositories, &out.MembersCanCreateRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembersCanCreatePublicRepositories != nil {
+		in, out := &in.MembersCanCreatePublicRepositories, &out.MembersCanCreatePublicRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembersCanCreatePrivateRepositories != nil {
+		in, out := &in.MembersCanCreatePrivateRepositories, &out.MembersCanCreatePrivateRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembersCanCreateInternalRepositories != nil {
+		in, out := &in.MembersCanCreateInternalRepositories, &out.MembersCanCreateInternalRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembersCanCreatePages != nil {
+		in, out := &in.MembersCanCreatePages, &out.MembersCanCreatePages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembersCanForkPrivateRepositories != nil {
+		in, out := &in.MembersCanForkPrivateRepositories, &out.MembersCanForkPrivateRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WebCommitSignoffRequired != nil {
+		in, out := &in.WebCommitSignoffRequired, &out.WebCommitSignoffRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DependencyGraphEnabledForNewRepositories != nil {
+		in, out := &in.DependencyGraphEnabledForNewRepositories, &out.DependencyGraphEnabledForNewRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DependabotAlertsEnabledForNewRepositories != nil {
+		in, out := &in.DependabotAlertsEnabledForNewRepositories, &out.DependabotAlertsEnabledForNewRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DependabotSecurityUpdatesEnabledForNewRepositories != nil {
+		in, out := &in.DependabotSecurityUpdatesEnabledForNewRepositories, &out.DependabotSecurityUpdatesEnabledForNewRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretScanningEnabledForNewRepositories != nil {
+		in, out := &in.SecretScanningEnabledForNewRepositories, &out.SecretScanningEnabledForNewRepositories
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretScanningPushProtectionEnabledForNewRepositories != nil {
+		in, out := &in.SecretScanningPushProtectionEnabledForNewRepositories, &out.SecretScanningPushProtectionEnabledForNewRepositories
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettingsParameters.
+func (in *OrganizationSettingsParameters) DeepCopy() *OrganizationSettingsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettingsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettingsSpec) DeepCopyInto(out *OrganizationSettingsSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettingsSpec.
+func (in *OrganizationSettingsSpec) DeepCopy() *OrganizationSettingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSettingsStatus) DeepCopyInto(out *OrganizationSettingsStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSettingsStatus.
+func (in *OrganizationSettingsStatus) DeepCopy() *OrganizationSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhook) DeepCopyInto(out *OrganizationWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhook.
+func (in *OrganizationWebhook) DeepCopy() *OrganizationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookList) DeepCopyInto(out *OrganizationWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookList.
+func (in *OrganizationWebhookList) DeepCopy() *OrganizationWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookObservation) DeepCopyInto(out *OrganizationWebhookObservation) {
+	*out = *in
+	if in.SecretRotatedAt != nil {
+		in, out := &in.SecretRotatedAt, &out.SecretRotatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PingDelivery != nil {
+		in, out := &in.PingDelivery, &out.PingDelivery
+		*out = new(WebhookDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookObservation.
+func (in *OrganizationWebhookObservation) DeepCopy() *OrganizationWebhookObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookParameters) DeepCopyInto(out *OrganizationWebhookParameters) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookParameters.
+func (in *OrganizationWebhookParameters) DeepCopy() *OrganizationWebhookParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookSpec) DeepCopyInto(out *OrganizationWebhookSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookSpec.
+func (in *OrganizationWebhookSpec) DeepCopy() *OrganizationWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookStatus) DeepCopyInto(out *OrganizationWebhookStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookStatus.
+func (in *OrganizationWebhookStatus) DeepCopy() *OrganizationWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2) DeepCopyInto(out *ProjectV2) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2.
+func (in *ProjectV2) DeepCopy() *ProjectV2 {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectV2) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2Field) DeepCopyInto(out *ProjectV2Field) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2Field.
+func (in *ProjectV2Field) DeepCopy() *ProjectV2Field {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2Field)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectV2Field) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldList) DeepCopyInto(out *ProjectV2FieldList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectV2Field, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldList.
+func (in *ProjectV2FieldList) DeepCopy() *ProjectV2FieldList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectV2FieldList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldObservation) DeepCopyInto(out *ProjectV2FieldObservation) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]ProjectV2FieldOptionObservation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldObservation.
+func (in *ProjectV2FieldObservation) DeepCopy() *ProjectV2FieldObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldOption) DeepCopyInto(out *ProjectV2FieldOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldOption.
+func (in *ProjectV2FieldOption) DeepCopy() *ProjectV2FieldOption {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldOptionObservation) DeepCopyInto(out *ProjectV2FieldOptionObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldOptionObservation.
+func (in *ProjectV2FieldOptionObservation) DeepCopy() *ProjectV2FieldOptionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldOptionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldParameters) DeepCopyInto(out *ProjectV2FieldParameters) {
+	*out = *in
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]ProjectV2FieldOption, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldParameters.
+func (in *ProjectV2FieldParameters) DeepCopy() *ProjectV2FieldParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldSpec) DeepCopyInto(out *ProjectV2FieldSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldSpec.
+func (in *ProjectV2FieldSpec) DeepCopy() *ProjectV2FieldSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2FieldStatus) DeepCopyInto(out *ProjectV2FieldStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2FieldStatus.
+func (in *ProjectV2FieldStatus) DeepCopy() *ProjectV2FieldStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2FieldStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2List) DeepCopyInto(out *ProjectV2List) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectV2, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2List.
+func (in *ProjectV2List) DeepCopy() *ProjectV2List {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2List)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectV2List) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2Observation) DeepCopyInto(out *ProjectV2Observation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2Observation.
+func (in *ProjectV2Observation) DeepCopy() *ProjectV2Observation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2Observation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2Parameters) DeepCopyInto(out *ProjectV2Parameters) {
+	*out = *in
+	if in.ShortDescription != nil {
+		in, out := &in.ShortDescription, &out.ShortDescription
+		*out = new(string)
+		**out = **in
+	}
+	if in.Readme != nil {
+		in, out := &in.Readme, &out.Readme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Closed != nil {
+		in, out := &in.Closed, &out.Closed
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2Parameters.
+func (in *ProjectV2Parameters) DeepCopy() *ProjectV2Parameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2Parameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2Spec) DeepCopyInto(out *ProjectV2Spec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2Spec.
+func (in *ProjectV2Spec) DeepCopy() *ProjectV2Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectV2Status) DeepCopyInto(out *ProjectV2Status) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectV2Status.
+func (in *ProjectV2Status) DeepCopy() *ProjectV2Status {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectV2Status)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagers) DeepCopyInto(out *SecurityManagers) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagers.
+func (in *SecurityManagers) DeepCopy() *SecurityManagers {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityManagers) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagersList) DeepCopyInto(out *SecurityManagersList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityManagers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagersList.
+func (in *SecurityManagersList) DeepCopy() *SecurityManagersList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagersList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityManagersList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagersObservation) DeepCopyInto(out *SecurityManagersObservation) {
+	*out = *in
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagersObservation.
+func (in *SecurityManagersObservation) DeepCopy() *SecurityManagersObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagersObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagersParameters) DeepCopyInto(out *SecurityManagersParameters) {
+	*out = *in
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamRefs != nil {
+		in, out := &in.TeamRefs, &out.TeamRefs
+		*out = make([]v1.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagersParameters.
+func (in *SecurityManagersParameters) DeepCopy() *SecurityManagersParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagersParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagersSpec) DeepCopyInto(out *SecurityManagersSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagersSpec.
+func (in *SecurityManagersSpec) DeepCopy() *SecurityManagersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityManagersStatus) DeepCopyInto(out *SecurityManagersStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityManagersStatus.
+func (in *SecurityManagersStatus) DeepCopy() *SecurityManagersStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityManagersStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Team) DeepCopyInto(out *Team) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Team.
+func (in *Team) DeepCopy() *Team {
+	if in == nil {
+		return nil
+	}
+	out := new(Team)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Team) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroup) DeepCopyInto(out *TeamExternalGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroup.
+func (in *TeamExternalGroup) DeepCopy() *TeamExternalGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamExternalGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroupList) DeepCopyInto(out *TeamExternalGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TeamExternalGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroupList.
+func (in *TeamExternalGroupList) DeepCopy() *TeamExternalGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamExternalGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroupObservation) DeepCopyInto(out *TeamExternalGroupObservation) {
+	*out = *in
+	if in.UpdatedAt != nil {
+		in, out := &in.UpdatedAt, &out.UpdatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroupObservation.
+func (in *TeamExternalGroupObservation) DeepCopy() *TeamExternalGroupObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroupObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroupParameters) DeepCopyInto(out *TeamExternalGroupParameters) {
+	*out = *in
+	if in.Team != nil {
+		in, out := &in.Team, &out.Team
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroupParameters.
+func (in *TeamExternalGroupParameters) DeepCopy() *TeamExternalGroupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroupSpec) DeepCopyInto(out *TeamExternalGroupSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroupSpec.
+func (in *TeamExternalGroupSpec) DeepCopy() *TeamExternalGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamExternalGroupStatus) DeepCopyInto(out *TeamExternalGroupStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamExternalGroupStatus.
+func (in *TeamExternalGroupStatus) DeepCopy() *TeamExternalGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamExternalGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamList) DeepCopyInto(out *TeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Team, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamList.
+func (in *TeamList) DeepCopy() *TeamList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamObservation) DeepCopyInto(out *TeamObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamObservation.
+func (in *TeamObservation) DeepCopy() *TeamObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamParameters) DeepCopyInto(out *TeamParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Privacy != nil {
+		in, out := &in.Privacy, &out.Privacy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamParameters.
+func (in *TeamParameters) DeepCopy() *TeamParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositoryFailure) DeepCopyInto(out *TeamRepositoryFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositoryFailure.
+func (in *TeamRepositoryFailure) DeepCopy() *TeamRepositoryFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositoryFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositoryGrant) DeepCopyInto(out *TeamRepositoryGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositoryGrant.
+func (in *TeamRepositoryGrant) DeepCopy() *TeamRepositoryGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositoryGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySet) DeepCopyInto(out *TeamRepositorySet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySet.
+func (in *TeamRepositorySet) DeepCopy() *TeamRepositorySet {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamRepositorySet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySetList) DeepCopyInto(out *TeamRepositorySetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TeamRepositorySet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySetList.
+func (in *TeamRepositorySetList) DeepCopy() *TeamRepositorySetList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamRepositorySetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySetObservation) DeepCopyInto(out *TeamRepositorySetObservation) {
+	*out = *in
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]TeamRepositoryFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySetObservation.
+func (in *TeamRepositorySetObservation) DeepCopy() *TeamRepositorySetObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySetObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySetParameters) DeepCopyInto(out *TeamRepositorySetParameters) {
+	*out = *in
+	if in.Team != nil {
+		in, out := &in.Team, &out.Team
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]TeamRepositoryGrant, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySetParameters.
+func (in *TeamRepositorySetParameters) DeepCopy() *TeamRepositorySetParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySetParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySetSpec) DeepCopyInto(out *TeamRepositorySetSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySetSpec.
+func (in *TeamRepositorySetSpec) DeepCopy() *TeamRepositorySetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamRepositorySetStatus) DeepCopyInto(out *TeamRepositorySetStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamRepositorySetStatus.
+func (in *TeamRepositorySetStatus) DeepCopy() *TeamRepositorySetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamRepositorySetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
+func (in *TeamSpec) DeepCopy() *TeamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStatus.
+func (in *TeamStatus) DeepCopy() *TeamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDelivery) DeepCopyInto(out *WebhookDelivery) {
+	*out = *in
+	if in.DeliveredAt != nil {
+		in, out := &in.DeliveredAt, &out.DeliveredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDelivery.
+func (in *WebhookDelivery) DeepCopy() *WebhookDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+)
+
+// TeamSlug returns an extractor that returns the slug of a Team. The slug is
+// derived from the name of the team by GitHub, so it is derived from the
+// display name or external name until the Team has been observed.
+func TeamSlug() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		t, ok := mg.(*Team)
+		if !ok {
+			return ""
+		}
+		if t.Status.AtProvider.Slug != "" {
+			return t.Status.AtProvider.Slug
+		}
+		if t.Spec.ForProvider.DisplayName != nil {
+			return externalname.Slug(*t.Spec.ForProvider.DisplayName)
+		}
+		return externalname.Slug(meta.GetExternalName(t))
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// TeamParameters are the configurable fields of a Team.
+type TeamParameters struct {
+	// The name of the organization this team belongs to.
+	Org string `json:"org"`
+
+	// The name of the team shown on GitHub. Defaults to the external name.
+	// +optional
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// A description about the team.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The visibility of the team. Nested teams must be closed.
+	// +kubebuilder:validation:Enum=secret;closed
+	// +optional
+	Privacy *string `json:"privacy,omitempty"`
+
+	// Whether team members are notified when the team is mentioned.
+	// +kubebuilder:validation:Enum=notifications_enabled;notifications_disabled
+	// +optional
+	NotificationSetting *string `json:"notificationSetting,omitempty"`
+
+	// ParentTeam is the slug of the team this team is nested under. An
+	// empty slug removes the team from its parent.
+	// +crossplane:generate:reference:type=Team
+	// +crossplane:generate:reference:extractor=TeamSlug()
+	// +crossplane:generate:reference:refFieldName=ParentTeamRef
+	// +crossplane:generate:reference:selectorFieldName=ParentTeamSelector
+	// +optional
+	ParentTeam *string `json:"parentTeam,omitempty"`
+
+	// ParentTeamRef refers to the Team this team is nested under.
+	// +optional
+	ParentTeamRef *xpv1.Reference `json:"parentTeamRef,omitempty"`
+
+	// ParentTeamSelector selects the Team this team is nested under.
+	// +optional
+	ParentTeamSelector *xpv1.Selector `json:"parentTeamSelector,omitempty"`
+
+	// ObserveMembers records the members and maintainers of the team in its
+	// status. They are only observed, not managed. Observing them takes
+	// another request per hundred members every time the team is observed.
+	// +optional
+	ObserveMembers bool `json:"observeMembers,omitempty"`
+}
+
+// TeamObservation are the observable fields of a Team.
+type TeamObservation struct {
+	ID                  int64  `json:"id,omitempty"`
+	NodeID              string `json:"nodeId,omitempty"`
+	Slug                string `json:"slug,omitempty"`
+	HTMLURL             string `json:"htmlUrl,omitempty"`
+	ParentTeam          string `json:"parentTeam,omitempty"`
+	NotificationSetting string `json:"notificationSetting,omitempty"`
+	MembersCount        int    `json:"membersCount,omitempty"`
+	ReposCount          int    `json:"reposCount,omitempty"`
+
+	// The logins of the members of the team that are not maintainers, if
+	// observeMembers is set.
+	Members []string `json:"members,omitempty"`
+
+	// The logins of the maintainers of the team, if observeMembers is set.
+	Maintainers []string `json:"maintainers,omitempty"`
+
+	// The number of maintainers of the team, if observeMembers is set.
+	MaintainersCount int `json:"maintainersCount,omitempty"`
+
+	// MembersTruncated is true if the team has more members or maintainers
+	// than are recorded.
+	MembersTruncated bool `json:"membersTruncated,omitempty"`
+}
+
+// A TeamSpec defines the desired state of a Team.
+type TeamSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamParameters `json:"forProvider"`
+}
+
+// A TeamStatus represents the observed state of a Team.
+type TeamStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          TeamObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Team is a team of an organization.
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="SLUG",type="string",JSONPath=".status.atProvider.slug"
+// +kubebuilder:printcolumn:name="PRIVACY",type="string",JSONPath=".spec.forProvider.privacy"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamList contains a list of Team
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+// Team type metadata.
+var (
+	TeamKind             = reflect.TypeOf(Team{}).Name()
+	TeamGroupKind        = schema.GroupKind{Group: Group, Kind: TeamKind}.String()
+	TeamKindAPIVersion   = TeamKind + "." + SchemeGroupVersion.String()
+	TeamGroupVersionKind = SchemeGroupVersion.WithKind(TeamKind)
+)
+
+// Hub marks v1beta1 as the version other versions of Team are converted to
+// and from.
+func (*Team) Hub() {}
+
+func init() {
+	SchemeBuilder.Register(&Team{}, &TeamList{})
+}
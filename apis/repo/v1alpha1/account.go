@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// GetAccount returns the owner of the repository.
+func (mg *Repository) GetAccount() string {
+	return mg.GetRepositoryOwner()
+}
+
+// GetAccount returns the owner of the repository of this BranchProtection.
+func (mg *BranchProtection) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this CodeScanningDefaultSetup.
+func (mg *CodeScanningDefaultSetup) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this DiscussionCategory.
+func (mg *DiscussionCategory) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this Issue.
+func (mg *Issue) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this Label.
+func (mg *Label) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this LabelSet.
+func (mg *LabelSet) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this Milestone.
+func (mg *Milestone) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this ProjectV2Repository.
+func (mg *ProjectV2Repository) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this RepositoryCollaborator.
+func (mg *RepositoryCollaborator) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this RepositoryCustomPropertyValues.
+func (mg *RepositoryCustomPropertyValues) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the owner of the repository of this Ruleset.
+func (mg *Ruleset) GetAccount() string {
+	return mg.Spec.ForProvider.Owner
+}
+
+// GetAccount returns the organization of this RepositoryDefaults.
+func (mg *RepositoryDefaults) GetAccount() string {
+	return mg.Spec.ForProvider.Org
+}
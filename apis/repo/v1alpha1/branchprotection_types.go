@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// BranchProtectionParameters are the configurable fields of a BranchProtection.
+type BranchProtectionParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The name of the branch to protect.
+	Branch string `json:"branch"`
+
+	// RequiredStatusChecks requires status checks to pass before branches
+	// can be merged into the branch.
+	// +optional
+	RequiredStatusChecks *RequiredStatusChecks `json:"requiredStatusChecks,omitempty"`
+
+	// RequiredPullRequestReviews requires pull requests, and reviews of
+	// them, before changes can be merged into the branch.
+	// +optional
+	RequiredPullRequestReviews *RequiredPullRequestReviews `json:"requiredPullRequestReviews,omitempty"`
+
+	// Restrictions limits who can push to the branch. Anyone with write
+	// access can push if unset.
+	// +optional
+	Restrictions *BranchActors `json:"restrictions,omitempty"`
+
+	// Whether the protection applies to administrators too.
+	// +optional
+	EnforceAdmins bool `json:"enforceAdmins,omitempty"`
+
+	// Whether merge commits are prohibited.
+	// +optional
+	RequireLinearHistory bool `json:"requireLinearHistory,omitempty"`
+
+	// Whether all conversations on code must be resolved before a pull
+	// request can be merged.
+	// +optional
+	RequiredConversationResolution bool `json:"requiredConversationResolution,omitempty"`
+
+	// Whether users with push access can force push to the branch.
+	// +optional
+	AllowForcePushes bool `json:"allowForcePushes,omitempty"`
+
+	// Whether users with push access can delete the branch.
+	// +optional
+	AllowDeletions bool `json:"allowDeletions,omitempty"`
+}
+
+// RequiredStatusChecks are the status checks that must pass before branches
+// can be merged into a protected branch.
+type RequiredStatusChecks struct {
+	// Whether branches must be up to date with the protected branch before
+	// they can be merged.
+	// +optional
+	Strict bool `json:"strict,omitempty"`
+
+	// The names of the required checks. Any GitHub App may provide them.
+	// +optional
+	Contexts []string `json:"contexts,omitempty"`
+
+	// The required checks, each optionally with the GitHub App that must
+	// provide it. Different apps may report checks with the same name.
+	// +optional
+	Checks []RequiredStatusCheck `json:"checks,omitempty"`
+}
+
+// A RequiredStatusCheck is a status check that must pass, optionally provided
+// by a specific GitHub App. Any app may provide it if neither appID nor appSlug
+// is set.
+type RequiredStatusCheck struct {
+	// The name of the check.
+	// +kubebuilder:validation:MinLength=1
+	Context string `json:"context"`
+
+	// The ID of the GitHub App that must provide the check.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	AppID *int64 `json:"appID,omitempty"`
+
+	// The slug of the GitHub App that must provide the check. It is resolved
+	// to the ID of the app, and is mutually exclusive with appID.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	AppSlug *string `json:"appSlug,omitempty"`
+}
+
+// RequiredPullRequestReviews configures the reviews pull requests into a
+// protected branch require.
+type RequiredPullRequestReviews struct {
+	// The number of approving reviews required.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=6
+	// +optional
+	RequiredApprovingReviewCount int `json:"requiredApprovingReviewCount,omitempty"`
+
+	// Whether approving reviews are dismissed when new commits are pushed.
+	// +optional
+	DismissStaleReviews bool `json:"dismissStaleReviews,omitempty"`
+
+	// Whether code owners must review the changes they own.
+	// +optional
+	RequireCodeOwnerReviews bool `json:"requireCodeOwnerReviews,omitempty"`
+
+	// Whether the most recent push must be approved by someone other than
+	// the person who pushed it.
+	// +optional
+	RequireLastPushApproval bool `json:"requireLastPushApproval,omitempty"`
+
+	// DismissalRestrictions limits who can dismiss reviews. Anyone with
+	// write access can dismiss reviews if unset.
+	// +optional
+	DismissalRestrictions *BranchActors `json:"dismissalRestrictions,omitempty"`
+
+	// BypassPullRequestAllowances are allowed to push to the branch without
+	// a pull request.
+	// +optional
+	BypassPullRequestAllowances *BranchActors `json:"bypassPullRequestAllowances,omitempty"`
+}
+
+// BranchActors are the users, teams, and GitHub Apps a branch protection rule
+// makes an exception for.
+type BranchActors struct {
+	// The logins of the users.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// The slugs of the teams.
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// The slugs of the GitHub Apps, such as dependabot. An app must be
+	// installed on the repository.
+	// +optional
+	Apps []string `json:"apps,omitempty"`
+}
+
+// BranchProtectionObservation are the observable fields of a BranchProtection.
+type BranchProtectionObservation struct {
+	// The URL of the protection in the GitHub API.
+	URL string `json:"url,omitempty"`
+}
+
+// A BranchProtectionSpec defines the desired state of a BranchProtection.
+type BranchProtectionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BranchProtectionParameters `json:"forProvider"`
+}
+
+// A BranchProtectionStatus represents the observed state of a BranchProtection.
+type BranchProtectionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          BranchProtectionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BranchProtection protects a branch of a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".spec.forProvider.branch"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type BranchProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BranchProtectionSpec   `json:"spec"`
+	Status BranchProtectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BranchProtectionList contains a list of BranchProtection
+type BranchProtectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BranchProtection `json:"items"`
+}
+
+// BranchProtection type metadata.
+var (
+	BranchProtectionKind             = reflect.TypeOf(BranchProtection{}).Name()
+	BranchProtectionGroupKind        = schema.GroupKind{Group: Group, Kind: BranchProtectionKind}.String()
+	BranchProtectionKindAPIVersion   = BranchProtectionKind + "." + SchemeGroupVersion.String()
+	BranchProtectionGroupVersionKind = SchemeGroupVersion.WithKind(BranchProtectionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BranchProtection{}, &BranchProtectionList{})
+}
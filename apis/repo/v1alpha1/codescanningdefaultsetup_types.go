@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// CodeScanningDefaultSetupParameters are the configurable fields of a
+// CodeScanningDefaultSetup.
+type CodeScanningDefaultSetupParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// Whether default setup is configured.
+	// +kubebuilder:validation:Enum=configured;not-configured
+	State string `json:"state"`
+
+	// The query suite to run.
+	// +kubebuilder:validation:Enum=default;extended
+	// +optional
+	QuerySuite *string `json:"querySuite,omitempty"`
+
+	// The languages to analyze. Defaults to the languages GitHub detects in
+	// the repository.
+	// +optional
+	Languages []string `json:"languages,omitempty"`
+}
+
+// CodeScanningDefaultSetupObservation are the observable fields of a
+// CodeScanningDefaultSetup.
+type CodeScanningDefaultSetupObservation struct {
+	// Whether default setup is configured.
+	State string `json:"state,omitempty"`
+
+	// The query suite that is run.
+	QuerySuite string `json:"querySuite,omitempty"`
+
+	// The languages that are analyzed.
+	Languages []string `json:"languages,omitempty"`
+
+	// The time the configuration was last updated.
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// The ID of the workflow run that applies the last requested
+	// configuration. It is cleared once the run has completed.
+	RunID int64 `json:"runId,omitempty"`
+
+	// The URL of the workflow run that applies the last requested
+	// configuration.
+	RunURL string `json:"runURL,omitempty"`
+}
+
+// A CodeScanningDefaultSetupSpec defines the desired state of a
+// CodeScanningDefaultSetup.
+type CodeScanningDefaultSetupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CodeScanningDefaultSetupParameters `json:"forProvider"`
+}
+
+// A CodeScanningDefaultSetupStatus represents the observed state of a
+// CodeScanningDefaultSetup.
+type CodeScanningDefaultSetupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          CodeScanningDefaultSetupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CodeScanningDefaultSetup configures code scanning default setup of a
+// repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type CodeScanningDefaultSetup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CodeScanningDefaultSetupSpec   `json:"spec"`
+	Status CodeScanningDefaultSetupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CodeScanningDefaultSetupList contains a list of CodeScanningDefaultSetup
+type CodeScanningDefaultSetupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CodeScanningDefaultSetup `json:"items"`
+}
+
+// CodeScanningDefaultSetup type metadata.
+var (
+	CodeScanningDefaultSetupKind             = reflect.TypeOf(CodeScanningDefaultSetup{}).Name()
+	CodeScanningDefaultSetupGroupKind        = schema.GroupKind{Group: Group, Kind: CodeScanningDefaultSetupKind}.String()
+	CodeScanningDefaultSetupKindAPIVersion   = CodeScanningDefaultSetupKind + "." + SchemeGroupVersion.String()
+	CodeScanningDefaultSetupGroupVersionKind = SchemeGroupVersion.WithKind(CodeScanningDefaultSetupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CodeScanningDefaultSetup{}, &CodeScanningDefaultSetupList{})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// DiscussionCategoryParameters are the configurable fields of a
+// DiscussionCategory.
+type DiscussionCategoryParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The name of the category.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// The emoji of the category, in :shortcode: form.
+	// +kubebuilder:validation:Pattern=`^:[a-z0-9_+-]+:$`
+	// +optional
+	Emoji *string `json:"emoji,omitempty"`
+
+	// A description of the category.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Whether discussions in the category can have an answer.
+	// +optional
+	IsAnswerable *bool `json:"isAnswerable,omitempty"`
+}
+
+// DiscussionCategoryObservation are the observable fields of a
+// DiscussionCategory.
+type DiscussionCategoryObservation struct {
+	NodeID       string `json:"nodeId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Emoji        string `json:"emoji,omitempty"`
+	Description  string `json:"description,omitempty"`
+	IsAnswerable bool   `json:"isAnswerable,omitempty"`
+}
+
+// A DiscussionCategorySpec defines the desired state of a DiscussionCategory.
+type DiscussionCategorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DiscussionCategoryParameters `json:"forProvider"`
+}
+
+// A DiscussionCategoryStatus represents the observed state of a
+// DiscussionCategory.
+type DiscussionCategoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          DiscussionCategoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DiscussionCategory is a discussion category in a repository, identified by
+// its slug as the external name. GitHub does not expose mutations for
+// discussion categories, so existing categories are adopted and checked for
+// drift rather than created, updated, or deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type DiscussionCategory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DiscussionCategorySpec   `json:"spec"`
+	Status DiscussionCategoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DiscussionCategoryList contains a list of DiscussionCategory
+type DiscussionCategoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DiscussionCategory `json:"items"`
+}
+
+// DiscussionCategory type metadata.
+var (
+	DiscussionCategoryKind             = reflect.TypeOf(DiscussionCategory{}).Name()
+	DiscussionCategoryGroupKind        = schema.GroupKind{Group: Group, Kind: DiscussionCategoryKind}.String()
+	DiscussionCategoryKindAPIVersion   = DiscussionCategoryKind + "." + SchemeGroupVersion.String()
+	DiscussionCategoryGroupVersionKind = SchemeGroupVersion.WithKind(DiscussionCategoryKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&DiscussionCategory{}, &DiscussionCategoryList{})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// IssueParameters are the configurable fields of an Issue.
+type IssueParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The title of the issue.
+	Title string `json:"title"`
+
+	// The body of the issue. It is only set when the issue is created
+	// unless enforceBody is true, because issues are commonly edited by
+	// people.
+	// +optional
+	Body *string `json:"body,omitempty"`
+
+	// Whether changes to the body of the issue are reverted.
+	// +optional
+	EnforceBody bool `json:"enforceBody,omitempty"`
+
+	// The names of the labels of the issue.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// The logins of the users the issue is assigned to.
+	// +optional
+	Assignees []string `json:"assignees,omitempty"`
+
+	// The state of the issue.
+	// +kubebuilder:validation:Enum=open;closed
+	// +optional
+	State *string `json:"state,omitempty"`
+
+	// What happens to the issue when the resource is deleted. Issues
+	// cannot be deleted through the API, so they are either closed or left
+	// as they are.
+	// +kubebuilder:validation:Enum=Close;Orphan
+	// +kubebuilder:default=Close
+	// +optional
+	OnDelete string `json:"onDelete,omitempty"`
+}
+
+// IssueObservation are the observable fields of an Issue.
+type IssueObservation struct {
+	ID      int64  `json:"id,omitempty"`
+	NodeID  string `json:"nodeId,omitempty"`
+	HTMLURL string `json:"htmlURL,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+// An IssueSpec defines the desired state of an Issue.
+type IssueSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       IssueParameters `json:"forProvider"`
+}
+
+// An IssueStatus represents the observed state of an Issue.
+type IssueStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          IssueObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Issue is a long-lived issue of a repository, such as a tracking issue.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Issue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IssueSpec   `json:"spec"`
+	Status IssueStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IssueList contains a list of Issue
+type IssueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Issue `json:"items"`
+}
+
+// Issue type metadata.
+var (
+	IssueKind             = reflect.TypeOf(Issue{}).Name()
+	IssueGroupKind        = schema.GroupKind{Group: Group, Kind: IssueKind}.String()
+	IssueKindAPIVersion   = IssueKind + "." + SchemeGroupVersion.String()
+	IssueGroupVersionKind = SchemeGroupVersion.WithKind(IssueKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Issue{}, &IssueList{})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// LabelParameters are the configurable fields of a Label.
+type LabelParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The name of the label. Defaults to the external name. Changing it
+	// renames the label in place.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// The hexadecimal color code of the label, such as ff0000 or #f00.
+	// +kubebuilder:validation:Pattern=`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`
+	Color string `json:"color"`
+
+	// A short description of the label.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// LabelObservation are the observable fields of a Label.
+type LabelObservation struct {
+	ID     int64  `json:"id,omitempty"`
+	NodeID string `json:"nodeId,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// A LabelSpec defines the desired state of a Label.
+type LabelSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LabelParameters `json:"forProvider"`
+}
+
+// A LabelStatus represents the observed state of a Label.
+type LabelStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          LabelObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Label is an issue label in a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="COLOR",type="string",JSONPath=".spec.forProvider.color"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Label struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LabelSpec   `json:"spec"`
+	Status LabelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LabelList contains a list of Label
+type LabelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Label `json:"items"`
+}
+
+// Label type metadata.
+var (
+	LabelKind             = reflect.TypeOf(Label{}).Name()
+	LabelGroupKind        = schema.GroupKind{Group: Group, Kind: LabelKind}.String()
+	LabelKindAPIVersion   = LabelKind + "." + SchemeGroupVersion.String()
+	LabelGroupVersionKind = SchemeGroupVersion.WithKind(LabelKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Label{}, &LabelList{})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// LabelSetParameters are the configurable fields of a LabelSet.
+type LabelSetParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The labels that should exist in the repository.
+	Labels []LabelSetItem `json:"labels"`
+
+	// Whether labels that exist in the repository but are not part of the
+	// set should be deleted.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// A LabelSetItem is a single label of a LabelSet.
+type LabelSetItem struct {
+	// The name of the label. Matched against existing labels without regard
+	// to case, as GitHub does.
+	Name string `json:"name"`
+
+	// The hexadecimal color code of the label, such as ff0000 or #f00.
+	// +kubebuilder:validation:Pattern=`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`
+	Color string `json:"color"`
+
+	// A short description of the label.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// LabelSetObservation are the observable fields of a LabelSet.
+type LabelSetObservation struct {
+	// The number of labels in the repository.
+	Total int `json:"total,omitempty"`
+
+	// The number of labels that are missing, differ from the set, or would
+	// be pruned.
+	OutOfSync int `json:"outOfSync,omitempty"`
+}
+
+// A LabelSetSpec defines the desired state of a LabelSet.
+type LabelSetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LabelSetParameters `json:"forProvider"`
+}
+
+// A LabelSetStatus represents the observed state of a LabelSet.
+type LabelSetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          LabelSetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A LabelSet syncs a set of issue labels across a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="OUT-OF-SYNC",type="integer",JSONPath=".status.atProvider.outOfSync"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type LabelSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LabelSetSpec   `json:"spec"`
+	Status LabelSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LabelSetList contains a list of LabelSet
+type LabelSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LabelSet `json:"items"`
+}
+
+// LabelSet type metadata.
+var (
+	LabelSetKind             = reflect.TypeOf(LabelSet{}).Name()
+	LabelSetGroupKind        = schema.GroupKind{Group: Group, Kind: LabelSetKind}.String()
+	LabelSetKindAPIVersion   = LabelSetKind + "." + SchemeGroupVersion.String()
+	LabelSetGroupVersionKind = SchemeGroupVersion.WithKind(LabelSetKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&LabelSet{}, &LabelSetList{})
+}
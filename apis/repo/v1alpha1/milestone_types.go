@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// MilestoneParameters are the configurable fields of a Milestone.
+type MilestoneParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The title of the milestone.
+	Title string `json:"title"`
+
+	// The state of the milestone.
+	// +kubebuilder:validation:Enum=open;closed
+	// +optional
+	State *string `json:"state,omitempty"`
+
+	// A description of the milestone.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// The due date of the milestone, in RFC3339 format.
+	// +optional
+	DueOn *metav1.Time `json:"dueOn,omitempty"`
+}
+
+// MilestoneObservation are the observable fields of a Milestone.
+type MilestoneObservation struct {
+	ID           int64  `json:"id,omitempty"`
+	NodeID       string `json:"nodeId,omitempty"`
+	HTMLURL      string `json:"htmlURL,omitempty"`
+	OpenIssues   int    `json:"openIssues,omitempty"`
+	ClosedIssues int    `json:"closedIssues,omitempty"`
+}
+
+// A MilestoneSpec defines the desired state of a Milestone.
+type MilestoneSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MilestoneParameters `json:"forProvider"`
+}
+
+// A MilestoneStatus represents the observed state of a Milestone.
+type MilestoneStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          MilestoneObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Milestone is a milestone in a repository. Its external name is the
+// milestone number, which is assigned by GitHub on creation.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".spec.forProvider.state"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Milestone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MilestoneSpec   `json:"spec"`
+	Status MilestoneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MilestoneList contains a list of Milestone
+type MilestoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Milestone `json:"items"`
+}
+
+// Milestone type metadata.
+var (
+	MilestoneKind             = reflect.TypeOf(Milestone{}).Name()
+	MilestoneGroupKind        = schema.GroupKind{Group: Group, Kind: MilestoneKind}.String()
+	MilestoneKindAPIVersion   = MilestoneKind + "." + SchemeGroupVersion.String()
+	MilestoneGroupVersionKind = SchemeGroupVersion.WithKind(MilestoneKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Milestone{}, &MilestoneList{})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// ProjectV2RepositoryParameters are the configurable fields of a
+// ProjectV2Repository.
+type ProjectV2RepositoryParameters struct {
+	// The node ID of the project. Set from the referenced project when
+	// projectRef or projectSelector is used.
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+
+	// ProjectRef refers to a ProjectV2 resource.
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+
+	// ProjectSelector selects one ProjectV2 resource.
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
+
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+}
+
+// ProjectV2RepositoryObservation are the observable fields of a
+// ProjectV2Repository.
+type ProjectV2RepositoryObservation struct {
+	// The node ID of the linked repository.
+	RepositoryID string `json:"repositoryId,omitempty"`
+}
+
+// A ProjectV2RepositorySpec defines the desired state of a ProjectV2Repository.
+type ProjectV2RepositorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectV2RepositoryParameters `json:"forProvider"`
+}
+
+// A ProjectV2RepositoryStatus represents the observed state of a
+// ProjectV2Repository.
+type ProjectV2RepositoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          ProjectV2RepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProjectV2Repository links a repository to an organization project, so that
+// the project is listed in the repository. Its external name is not used.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type ProjectV2Repository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectV2RepositorySpec   `json:"spec"`
+	Status ProjectV2RepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectV2RepositoryList contains a list of ProjectV2Repository
+type ProjectV2RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectV2Repository `json:"items"`
+}
+
+// ProjectV2Repository type metadata.
+var (
+	ProjectV2RepositoryKind             = reflect.TypeOf(ProjectV2Repository{}).Name()
+	ProjectV2RepositoryGroupKind        = schema.GroupKind{Group: Group, Kind: ProjectV2RepositoryKind}.String()
+	ProjectV2RepositoryKindAPIVersion   = ProjectV2RepositoryKind + "." + SchemeGroupVersion.String()
+	ProjectV2RepositoryGroupVersionKind = SchemeGroupVersion.WithKind(ProjectV2RepositoryKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ProjectV2Repository{}, &ProjectV2RepositoryList{})
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	orgv1alpha1 "github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+)
+
+// The referencers of this file are written by hand because the owner and
+// name of a repository are resolved from a single reference, which angryjet
+// cannot generate.
+
+func repositoryTo() reference.To {
+	return reference.To{Managed: &Repository{}, List: &RepositoryList{}}
+}
+
+// ResolveReferences of this Label.
+func (mg *Label) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this LabelSet.
+func (mg *LabelSet) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this Milestone.
+func (mg *Milestone) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this DiscussionCategory.
+func (mg *DiscussionCategory) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this CodeScanningDefaultSetup.
+func (mg *CodeScanningDefaultSetup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this Issue.
+func (mg *Issue) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this RepositoryCustomPropertyValues.
+func (mg *RepositoryCustomPropertyValues) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	p := &mg.Spec.ForProvider
+
+	if err := common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	}); err != nil {
+		return err
+	}
+
+	for i := range p.Properties {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: p.Properties[i].Name,
+			Extract:      reference.ExternalName(),
+			Reference:    p.Properties[i].PropertyRef,
+			Selector:     p.Properties[i].PropertySelector,
+			To: reference.To{
+				List:    &orgv1alpha1.OrganizationCustomPropertyList{},
+				Managed: &orgv1alpha1.OrganizationCustomProperty{},
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.properties[%d].name", i)
+		}
+		p.Properties[i].Name = rsp.ResolvedValue
+		p.Properties[i].PropertyRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this RepositoryCollaborator.
+func (mg *RepositoryCollaborator) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this BranchProtection.
+func (mg *BranchProtection) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this Ruleset.
+func (mg *Ruleset) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+	return common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	})
+}
+
+// ResolveReferences of this ProjectV2Repository.
+func (mg *ProjectV2Repository) ResolveReferences(ctx context.Context, c client.Reader) error {
+	p := &mg.Spec.ForProvider
+
+	if err := common.ResolveRepository(ctx, c, mg, repositoryTo(), common.RepositoryReferencer{
+		Owner: &p.Owner, Repository: &p.Repository, Reference: &p.RepositoryRef, Selector: p.RepositorySelector,
+	}); err != nil {
+		return err
+	}
+
+	rsp, err := reference.NewAPIResolver(c, mg).Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: p.ProjectID,
+		Extract:      orgv1alpha1.ProjectV2NodeID(),
+		Reference:    p.ProjectRef,
+		Selector:     p.ProjectSelector,
+		To: reference.To{
+			List:    &orgv1alpha1.ProjectV2List{},
+			Managed: &orgv1alpha1.ProjectV2{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.projectId")
+	}
+	p.ProjectID = rsp.ResolvedValue
+	p.ProjectRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RepositoryParameters are the configurable fields of a Repository.
+type RepositoryParameters struct {
+	// The login of the organization that owns the repository. The
+	// repository is owned by the authenticated user when unset. The name
+	// of the repository is the external name of the resource.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// A short description of the repository.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// A URL with more information about the repository.
+	// +optional
+	Homepage *string `json:"homepage,omitempty"`
+
+	// The visibility of the repository. Internal repositories are only
+	// available to organizations of an enterprise.
+	// +kubebuilder:validation:Enum=public;private;internal
+	// +optional
+	Visibility *string `json:"visibility,omitempty"`
+
+	// Whether issues are enabled.
+	// +optional
+	HasIssues *bool `json:"hasIssues,omitempty"`
+
+	// Whether projects are enabled.
+	// +optional
+	HasProjects *bool `json:"hasProjects,omitempty"`
+
+	// Whether the wiki is enabled.
+	// +optional
+	HasWiki *bool `json:"hasWiki,omitempty"`
+
+	// Whether the repository is a template that new repositories can be
+	// generated from.
+	// +optional
+	IsTemplate *bool `json:"isTemplate,omitempty"`
+
+	// The title of squash merge commits. COMMIT_OR_PR_TITLE uses the
+	// message of the commit if the pull request has only one commit.
+	// +kubebuilder:validation:Enum=PR_TITLE;COMMIT_OR_PR_TITLE
+	// +optional
+	SquashMergeCommitTitle *string `json:"squashMergeCommitTitle,omitempty"`
+
+	// The message of squash merge commits. PR_BODY and BLANK require the
+	// PR_TITLE title, COMMIT_OR_PR_TITLE requires COMMIT_MESSAGES.
+	// +kubebuilder:validation:Enum=PR_BODY;COMMIT_MESSAGES;BLANK
+	// +optional
+	SquashMergeCommitMessage *string `json:"squashMergeCommitMessage,omitempty"`
+
+	// The title of merge commits.
+	// +kubebuilder:validation:Enum=PR_TITLE;MERGE_MESSAGE
+	// +optional
+	MergeCommitTitle *string `json:"mergeCommitTitle,omitempty"`
+
+	// The message of merge commits. PR_TITLE requires the MERGE_MESSAGE
+	// title, PR_BODY and BLANK require PR_TITLE.
+	// +kubebuilder:validation:Enum=PR_BODY;PR_TITLE;BLANK
+	// +optional
+	MergeCommitMessage *string `json:"mergeCommitMessage,omitempty"`
+
+	// Whether the repository is created with an empty commit containing a
+	// README. Only applies when the repository is created.
+	// +optional
+	AutoInit *bool `json:"autoInit,omitempty"`
+
+	// ObserveCollaborators records the direct collaborators of the
+	// repository and their permissions in its status. They are only
+	// observed, not managed. Observing them takes further requests every
+	// time the repository is observed.
+	// +optional
+	ObserveCollaborators bool `json:"observeCollaborators,omitempty"`
+
+	// ObserveEnvironments records the deployment environments of the
+	// repository and a summary of their protection rules in its status.
+	// They are only observed, not managed. Observing them takes further
+	// requests every time the repository is observed.
+	// +optional
+	ObserveEnvironments bool `json:"observeEnvironments,omitempty"`
+}
+
+// RepositoryObservation are the observable fields of a Repository.
+type RepositoryObservation struct {
+	ID      int64  `json:"id,omitempty"`
+	NodeID  string `json:"nodeId,omitempty"`
+	HTMLURL string `json:"htmlURL,omitempty"`
+
+	// The login of the account that owns the repository.
+	Owner string `json:"owner,omitempty"`
+
+	// The full name of the repository, in the form owner/name.
+	FullName string `json:"fullName,omitempty"`
+
+	// The name of the default branch of the repository.
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+
+	// Fork is true if the repository is a fork.
+	Fork bool `json:"fork,omitempty"`
+
+	// The full name of the repository this repository is a fork of, if it
+	// is a fork.
+	Parent string `json:"parent,omitempty"`
+
+	// The full name of the repository at the root of the network of forks
+	// this repository belongs to, if it is a fork.
+	Source string `json:"source,omitempty"`
+
+	// The full name of the template repository this repository was created
+	// from, if any.
+	TemplateRepository string `json:"templateRepository,omitempty"`
+
+	// The direct collaborators of the repository, if observeCollaborators
+	// is set.
+	Collaborators []CollaboratorObservation `json:"collaborators,omitempty"`
+
+	// The number of direct collaborators that are not members of the
+	// organization that owns the repository, if observeCollaborators is
+	// set.
+	OutsideCollaboratorsCount int `json:"outsideCollaboratorsCount,omitempty"`
+
+	// CollaboratorsTruncated is true if the repository has more direct
+	// collaborators than are recorded.
+	CollaboratorsTruncated bool `json:"collaboratorsTruncated,omitempty"`
+
+	// The deployment environments of the repository, if observeEnvironments
+	// is set.
+	Environments []EnvironmentObservation `json:"environments,omitempty"`
+
+	// EnvironmentsTruncated is true if the repository has more environments
+	// than are recorded.
+	EnvironmentsTruncated bool `json:"environmentsTruncated,omitempty"`
+}
+
+// An EnvironmentObservation is a deployment environment of a repository.
+type EnvironmentObservation struct {
+	Name string `json:"name"`
+
+	// The number of minutes deployments wait before they proceed.
+	WaitTimer int `json:"waitTimer,omitempty"`
+
+	// The number of users and teams that must approve deployments.
+	Reviewers int `json:"reviewers,omitempty"`
+
+	// Whether reviewers may not approve deployments they triggered.
+	PreventSelfReview bool `json:"preventSelfReview,omitempty"`
+
+	// Which branches may deploy: ProtectedBranches, CustomBranchPolicies,
+	// or All.
+	DeploymentBranchPolicy string `json:"deploymentBranchPolicy,omitempty"`
+
+	// Whether admins may bypass the protection rules.
+	CanAdminsBypass bool `json:"canAdminsBypass,omitempty"`
+}
+
+// A CollaboratorObservation is a collaborator of a repository.
+type CollaboratorObservation struct {
+	Login string `json:"login"`
+
+	// The role of the collaborator, such as admin, write, or the name of a
+	// custom repository role.
+	Permission string `json:"permission"`
+}
+
+// A RepositorySpec defines the desired state of a Repository.
+type RepositorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryParameters `json:"forProvider"`
+}
+
+// A RepositoryStatus represents the observed state of a Repository.
+type RepositoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Repository is a GitHub repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="OWNER",type="string",JSONPath=".spec.forProvider.owner"
+// +kubebuilder:printcolumn:name="VISIBILITY",type="string",JSONPath=".spec.forProvider.visibility"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Repository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositorySpec   `json:"spec"`
+	Status RepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryList contains a list of Repository
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items"`
+}
+
+// GetRepositoryOwner returns the login of the account that owns the
+// repository. It is only known once the repository has been observed if no
+// owner is set.
+func (mg *Repository) GetRepositoryOwner() string {
+	if mg.Status.AtProvider.Owner != "" {
+		return mg.Status.AtProvider.Owner
+	}
+	return mg.Spec.ForProvider.Owner
+}
+
+// Repository type metadata.
+var (
+	RepositoryKind             = reflect.TypeOf(Repository{}).Name()
+	RepositoryGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryKind}.String()
+	RepositoryKindAPIVersion   = RepositoryKind + "." + SchemeGroupVersion.String()
+	RepositoryGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Repository{}, &RepositoryList{})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RepositoryCollaboratorParameters are the configurable fields of a
+// RepositoryCollaborator.
+type RepositoryCollaboratorParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The login of the user to grant access to the repository. Users that
+	// are not members of the organization are invited.
+	Username string `json:"username"`
+
+	// The role of the collaborator: pull, triage, push, maintain, admin, or
+	// the name of a custom repository role. The read and write names of the
+	// pull and push roles are accepted too. Changing it changes the role of
+	// the collaborator, or of their pending invitation, in place.
+	Permission string `json:"permission"`
+}
+
+// RepositoryCollaboratorObservation are the observable fields of a
+// RepositoryCollaborator.
+type RepositoryCollaboratorObservation struct {
+	// The role granted to the collaborator directly as reported by GitHub,
+	// such as read, write, or the name of a custom repository role. Access
+	// through a team or the organization is not included.
+	Permission string `json:"permission,omitempty"`
+
+	// The ID of the pending invitation of the user, if they did not accept
+	// it yet.
+	InvitationID int64 `json:"invitationId,omitempty"`
+}
+
+// A RepositoryCollaboratorSpec defines the desired state of a
+// RepositoryCollaborator.
+type RepositoryCollaboratorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryCollaboratorParameters `json:"forProvider"`
+}
+
+// A RepositoryCollaboratorStatus represents the observed state of a
+// RepositoryCollaborator.
+type RepositoryCollaboratorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RepositoryCollaboratorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryCollaborator grants a user access to a repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="USERNAME",type="string",JSONPath=".spec.forProvider.username"
+// +kubebuilder:printcolumn:name="PERMISSION",type="string",JSONPath=".status.atProvider.permission"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RepositoryCollaborator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryCollaboratorSpec   `json:"spec"`
+	Status RepositoryCollaboratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryCollaboratorList contains a list of RepositoryCollaborator
+type RepositoryCollaboratorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryCollaborator `json:"items"`
+}
+
+// RepositoryCollaborator type metadata.
+var (
+	RepositoryCollaboratorKind             = reflect.TypeOf(RepositoryCollaborator{}).Name()
+	RepositoryCollaboratorGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryCollaboratorKind}.String()
+	RepositoryCollaboratorKindAPIVersion   = RepositoryCollaboratorKind + "." + SchemeGroupVersion.String()
+	RepositoryCollaboratorGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryCollaboratorKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryCollaborator{}, &RepositoryCollaboratorList{})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RepositoryCustomPropertyValuesParameters are the configurable fields of a
+// RepositoryCustomPropertyValues.
+type RepositoryCustomPropertyValuesParameters struct {
+	// The account owner of the repository. Custom properties are defined
+	// by organizations, so this must be an organization. Set from the
+	// referenced repository when repositoryRef or repositorySelector is
+	// used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The values of custom properties of the repository. Properties that
+	// are not listed are left untouched.
+	// +kubebuilder:validation:MinItems=1
+	Properties []CustomPropertyValue `json:"properties"`
+}
+
+// A CustomPropertyValue is the value of a custom property of a repository.
+// Exactly one of value, boolValue, and values should be set, matching the
+// value type of the property. When none is set the value is unset.
+type CustomPropertyValue struct {
+	// The name of the property.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// PropertyRef refers to an OrganizationCustomProperty.
+	// +optional
+	PropertyRef *xpv1.Reference `json:"propertyRef,omitempty"`
+
+	// PropertySelector selects an OrganizationCustomProperty.
+	// +optional
+	PropertySelector *xpv1.Selector `json:"propertySelector,omitempty"`
+
+	// The value of a string or single_select property.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// The value of a true_false property.
+	// +optional
+	BoolValue *bool `json:"boolValue,omitempty"`
+
+	// The values of a multi_select property.
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// An ObservedCustomPropertyValue is the value of a custom property of a
+// repository as reported by GitHub. True_false values are reported as
+// strings.
+type ObservedCustomPropertyValue struct {
+	// The name of the property.
+	Name string `json:"name"`
+
+	// The value of a single value property.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// The values of a multi_select property.
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// RepositoryCustomPropertyValuesObservation are the observable fields of a
+// RepositoryCustomPropertyValues.
+type RepositoryCustomPropertyValuesObservation struct {
+	// The values of all custom properties of the repository, as reported by
+	// GitHub.
+	Properties []ObservedCustomPropertyValue `json:"properties,omitempty"`
+}
+
+// A RepositoryCustomPropertyValuesSpec defines the desired state of a
+// RepositoryCustomPropertyValues.
+type RepositoryCustomPropertyValuesSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryCustomPropertyValuesParameters `json:"forProvider"`
+}
+
+// A RepositoryCustomPropertyValuesStatus represents the observed state of a
+// RepositoryCustomPropertyValues.
+type RepositoryCustomPropertyValuesStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RepositoryCustomPropertyValuesObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryCustomPropertyValues sets the values of custom properties of a
+// repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RepositoryCustomPropertyValues struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryCustomPropertyValuesSpec   `json:"spec"`
+	Status RepositoryCustomPropertyValuesStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryCustomPropertyValuesList contains a list of RepositoryCustomPropertyValues
+type RepositoryCustomPropertyValuesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryCustomPropertyValues `json:"items"`
+}
+
+// RepositoryCustomPropertyValues type metadata.
+var (
+	RepositoryCustomPropertyValuesKind             = reflect.TypeOf(RepositoryCustomPropertyValues{}).Name()
+	RepositoryCustomPropertyValuesGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryCustomPropertyValuesKind}.String()
+	RepositoryCustomPropertyValuesKindAPIVersion   = RepositoryCustomPropertyValuesKind + "." + SchemeGroupVersion.String()
+	RepositoryCustomPropertyValuesGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryCustomPropertyValuesKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryCustomPropertyValues{}, &RepositoryCustomPropertyValuesList{})
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RepositoryDefaultsParameters are the configurable fields of a
+// RepositoryDefaults.
+type RepositoryDefaultsParameters struct {
+	// The organization whose repositories the defaults apply to.
+	Org string `json:"org"`
+
+	// Selector selects the repositories of the organization the defaults
+	// apply to. All repositories that are not archived are selected if it
+	// is empty.
+	// +optional
+	Selector RepositoryDefaultsSelector `json:"selector,omitempty"`
+
+	// Template holds the settings applied to every selected repository.
+	// Settings that are unset are left as they are.
+	Template RepositoryDefaultsTemplate `json:"template"`
+}
+
+// A RepositoryDefaultsSelector selects repositories of an organization.
+// Repositories must match all of its criteria that are set.
+type RepositoryDefaultsSelector struct {
+	// NamePattern selects repositories whose name matches the supplied
+	// shell pattern, such as service-*.
+	// +optional
+	NamePattern *string `json:"namePattern,omitempty"`
+
+	// CustomProperty selects repositories whose custom property has the
+	// supplied value.
+	// +optional
+	CustomProperty *CustomPropertyMatch `json:"customProperty,omitempty"`
+}
+
+// A CustomPropertyMatch matches repositories by the value of a custom
+// property.
+type CustomPropertyMatch struct {
+	// The name of the custom property.
+	Name string `json:"name"`
+
+	// The value the custom property must have. A property with multiple
+	// values matches if any of them is the supplied value.
+	Value string `json:"value"`
+}
+
+// A RepositoryDefaultsTemplate holds the settings applied to repositories.
+type RepositoryDefaultsTemplate struct {
+	// Whether issues are enabled.
+	// +optional
+	HasIssues *bool `json:"hasIssues,omitempty"`
+
+	// Whether projects are enabled.
+	// +optional
+	HasProjects *bool `json:"hasProjects,omitempty"`
+
+	// Whether the wiki is enabled.
+	// +optional
+	HasWiki *bool `json:"hasWiki,omitempty"`
+
+	// Whether pull requests can be merged with a merge commit.
+	// +optional
+	AllowMergeCommit *bool `json:"allowMergeCommit,omitempty"`
+
+	// Whether pull requests can be squash-merged.
+	// +optional
+	AllowSquashMerge *bool `json:"allowSquashMerge,omitempty"`
+
+	// Whether pull requests can be rebase-merged.
+	// +optional
+	AllowRebaseMerge *bool `json:"allowRebaseMerge,omitempty"`
+
+	// Whether pull requests can be merged automatically once their
+	// requirements are met.
+	// +optional
+	AllowAutoMerge *bool `json:"allowAutoMerge,omitempty"`
+
+	// Whether head branches are deleted once their pull request is merged.
+	// +optional
+	DeleteBranchOnMerge *bool `json:"deleteBranchOnMerge,omitempty"`
+
+	// Topics added to every repository. Topics a repository already has are
+	// kept.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
+	// Whether Dependabot alerts for vulnerable dependencies are enabled.
+	// +optional
+	VulnerabilityAlerts *bool `json:"vulnerabilityAlerts,omitempty"`
+}
+
+// Results of applying RepositoryDefaults to a repository.
+const (
+	RepositoryDefaultsCompliant = "Compliant"
+	RepositoryDefaultsDrifted   = "Drifted"
+	RepositoryDefaultsUpdated   = "Updated"
+	RepositoryDefaultsFailed    = "Failed"
+)
+
+// A RepositoryDefaultsResult records whether a selected repository matches
+// the template of a RepositoryDefaults.
+type RepositoryDefaultsResult struct {
+	// The name of the repository.
+	Name string `json:"name"`
+
+	// Result is Compliant if the repository matches the template, Drifted
+	// if it does not, Updated if it was changed to match it, and Failed if
+	// changing it failed.
+	Result string `json:"result"`
+
+	// Message describes how the repository differs from the template, or
+	// why changing it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RepositoryDefaultsObservation are the observable fields of a
+// RepositoryDefaults.
+type RepositoryDefaultsObservation struct {
+	// The selected repositories and whether their settings match the
+	// template, ordered by name.
+	Repositories []RepositoryDefaultsResult `json:"repositories,omitempty"`
+}
+
+// A RepositoryDefaultsSpec defines the desired state of a RepositoryDefaults.
+type RepositoryDefaultsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryDefaultsParameters `json:"forProvider"`
+}
+
+// A RepositoryDefaultsStatus represents the observed state of a
+// RepositoryDefaults.
+type RepositoryDefaultsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RepositoryDefaultsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryDefaults applies a template of settings to the repositories of an
+// organization that match its selector, including repositories created outside
+// of Crossplane. It is only reconciled if the EnableAlphaRepositoryDefaults
+// feature flag is enabled.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="ORG",type="string",JSONPath=".spec.forProvider.org"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RepositoryDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryDefaultsSpec   `json:"spec"`
+	Status RepositoryDefaultsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryDefaultsList contains a list of RepositoryDefaults
+type RepositoryDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryDefaults `json:"items"`
+}
+
+// RepositoryDefaults type metadata.
+var (
+	RepositoryDefaultsKind             = reflect.TypeOf(RepositoryDefaults{}).Name()
+	RepositoryDefaultsGroupKind        = schema.GroupKind{Group: Group, Kind: RepositoryDefaultsKind}.String()
+	RepositoryDefaultsKindAPIVersion   = RepositoryDefaultsKind + "." + SchemeGroupVersion.String()
+	RepositoryDefaultsGroupVersionKind = SchemeGroupVersion.WithKind(RepositoryDefaultsKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RepositoryDefaults{}, &RepositoryDefaultsList{})
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// RulesetParameters are the configurable fields of a Ruleset.
+type RulesetParameters struct {
+	// The account owner of the repository. Set from the referenced
+	// repository when repositoryRef or repositorySelector is used.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The name of the repository. Set from the referenced repository when
+	// repositoryRef or repositorySelector is used.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// RepositoryRef refers to a Repository resource.
+	// +optional
+	RepositoryRef *xpv1.Reference `json:"repositoryRef,omitempty"`
+
+	// RepositorySelector selects one Repository resource.
+	// +optional
+	RepositorySelector *xpv1.Selector `json:"repositorySelector,omitempty"`
+
+	// The name of the ruleset.
+	Name string `json:"name"`
+
+	// Whether the ruleset is enforced. Rulesets that are evaluated report
+	// what they would have blocked without blocking it.
+	// +kubebuilder:validation:Enum=active;evaluate;disabled
+	// +kubebuilder:default=active
+	// +optional
+	Enforcement string `json:"enforcement,omitempty"`
+
+	// The branches the ruleset applies to, as fnmatch patterns such as main
+	// or release/**. ~DEFAULT_BRANCH matches the default branch and ~ALL
+	// matches all branches.
+	// +kubebuilder:validation:MinItems=1
+	IncludeBranches []string `json:"includeBranches"`
+
+	// The branches the ruleset does not apply to, even though they are
+	// included.
+	// +optional
+	ExcludeBranches []string `json:"excludeBranches,omitempty"`
+
+	// The rules the branches must follow.
+	Rules RulesetRules `json:"rules"`
+
+	// Evaluation summarizes the results of the ruleset in its status while
+	// its enforcement is evaluate, and optionally promotes it to active.
+	// +optional
+	Evaluation *RulesetEvaluation `json:"evaluation,omitempty"`
+}
+
+// RulesetEvaluation configures how an evaluated Ruleset is summarized and
+// promoted.
+type RulesetEvaluation struct {
+	// The number of most recent pushes to the repository whose results are
+	// summarized. Each takes a request every time the ruleset is observed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	// +optional
+	Samples int `json:"samples,omitempty"`
+
+	// PromoteWhenCleanFor opts in to promoting the ruleset. Once it has been
+	// evaluated for this long, at least one push was evaluated, and no push
+	// failed it for this long, its enforcement is set to active in this
+	// spec. Failures are only noticed among the sampled pushes, so the
+	// samples should cover the pushes of a poll interval.
+	// +optional
+	PromoteWhenCleanFor *metav1.Duration `json:"promoteWhenCleanFor,omitempty"`
+}
+
+// RulesetRules are the rules of a Ruleset.
+type RulesetRules struct {
+	// Whether only users who can bypass the ruleset can delete the
+	// branches.
+	// +optional
+	Deletion bool `json:"deletion,omitempty"`
+
+	// Whether only users who can bypass the ruleset can force push to the
+	// branches.
+	// +optional
+	NonFastForward bool `json:"nonFastForward,omitempty"`
+
+	// Whether merge commits are prohibited.
+	// +optional
+	RequiredLinearHistory bool `json:"requiredLinearHistory,omitempty"`
+
+	// Whether commits must have verified signatures.
+	// +optional
+	RequiredSignatures bool `json:"requiredSignatures,omitempty"`
+
+	// PullRequest requires changes to be made through pull requests.
+	// +optional
+	PullRequest *RulesetPullRequest `json:"pullRequest,omitempty"`
+
+	// RequiredStatusChecks requires status checks to pass before changes
+	// are made.
+	// +optional
+	RequiredStatusChecks *RequiredStatusChecks `json:"requiredStatusChecks,omitempty"`
+}
+
+// RulesetPullRequest configures the pull requests a Ruleset requires.
+type RulesetPullRequest struct {
+	// The number of approving reviews required.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	RequiredApprovingReviewCount int `json:"requiredApprovingReviewCount,omitempty"`
+
+	// Whether approving reviews are dismissed when new commits are pushed.
+	// +optional
+	DismissStaleReviewsOnPush bool `json:"dismissStaleReviewsOnPush,omitempty"`
+
+	// Whether code owners must review the changes they own.
+	// +optional
+	RequireCodeOwnerReview bool `json:"requireCodeOwnerReview,omitempty"`
+
+	// Whether the most recent push must be approved by someone other than
+	// the person who pushed it.
+	// +optional
+	RequireLastPushApproval bool `json:"requireLastPushApproval,omitempty"`
+
+	// Whether all conversations on code must be resolved.
+	// +optional
+	RequiredReviewThreadResolution bool `json:"requiredReviewThreadResolution,omitempty"`
+}
+
+// RulesetObservation are the observable fields of a Ruleset.
+type RulesetObservation struct {
+	ID     int64  `json:"id,omitempty"`
+	NodeID string `json:"nodeId,omitempty"`
+
+	// Evaluation summarizes the results of the ruleset while its
+	// enforcement is evaluate, if evaluation is set.
+	Evaluation *RulesetEvaluationObservation `json:"evaluation,omitempty"`
+}
+
+// RulesetEvaluationObservation summarizes the results of an evaluated Ruleset.
+type RulesetEvaluationObservation struct {
+	// The number of sampled pushes that passed the ruleset.
+	Passed int `json:"passed"`
+
+	// The number of sampled pushes that failed the ruleset, and would have
+	// been blocked had it been active.
+	Failed int `json:"failed"`
+
+	// When the ruleset was first observed being evaluated.
+	EvaluatingSince *metav1.Time `json:"evaluatingSince,omitempty"`
+
+	// When a push last failed the ruleset, if one did while it was
+	// evaluated.
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// A RulesetSpec defines the desired state of a Ruleset.
+type RulesetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RulesetParameters `json:"forProvider"`
+}
+
+// A RulesetStatus represents the observed state of a Ruleset.
+type RulesetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	common.SyncStatus   `json:",inline"`
+	AtProvider          RulesetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Ruleset is a ruleset of the branches of a repository. Its external name is
+// the ID GitHub assigns it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="ENFORCEMENT",type="string",JSONPath=".spec.forProvider.enforcement"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Ruleset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RulesetSpec   `json:"spec"`
+	Status RulesetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RulesetList contains a list of Ruleset
+type RulesetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ruleset `json:"items"`
+}
+
+// Ruleset type metadata.
+var (
+	RulesetKind             = reflect.TypeOf(Ruleset{}).Name()
+	RulesetGroupKind        = schema.GroupKind{Group: Group, Kind: RulesetKind}.String()
+	RulesetKindAPIVersion   = RulesetKind + "." + SchemeGroupVersion.String()
+	RulesetGroupVersionKind = SchemeGroupVersion.WithKind(RulesetKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Ruleset{}, &RulesetList{})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "github.com/hasheddan/kc-provider-github/apis/common"
+
+// GetSyncStatus returns when this CodeScanningDefaultSetup was last compared with its external
+// resource.
+func (mg *CodeScanningDefaultSetup) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this DiscussionCategory was last compared with its external
+// resource.
+func (mg *DiscussionCategory) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Issue was last compared with its external
+// resource.
+func (mg *Issue) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Label was last compared with its external
+// resource.
+func (mg *Label) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this LabelSet was last compared with its external
+// resource.
+func (mg *LabelSet) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Milestone was last compared with its external
+// resource.
+func (mg *Milestone) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Repository was last compared with its external
+// resource.
+func (mg *Repository) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this RepositoryCustomPropertyValues was last compared with its external
+// resource.
+func (mg *RepositoryCustomPropertyValues) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this RepositoryCollaborator was last compared with its external
+// resource.
+func (mg *RepositoryCollaborator) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this BranchProtection was last compared with its external
+// resource.
+func (mg *BranchProtection) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this Ruleset was last compared with its external
+// resource.
+func (mg *Ruleset) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this ProjectV2Repository was last compared with its external
+// resource.
+func (mg *ProjectV2Repository) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
+
+// GetSyncStatus returns when this RepositoryDefaults was last compared with its external
+// resource.
+func (mg *RepositoryDefaults) GetSyncStatus() *common.SyncStatus {
+	return &mg.Status.SyncStatus
+}
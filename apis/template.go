@@ -20,7 +20,10 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	actionsv1alpha1 "github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
 	orgv1alpha1 "github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	repov1alpha1 "github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
 	templatev1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
 )
 
@@ -29,6 +32,9 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		templatev1alpha1.SchemeBuilder.AddToScheme,
 		orgv1alpha1.SchemeBuilder.AddToScheme,
+		orgv1beta1.SchemeBuilder.AddToScheme,
+		actionsv1alpha1.SchemeBuilder.AddToScheme,
+		repov1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
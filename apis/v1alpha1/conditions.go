@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeHealthy indicates whether the credentials of a ProviderConfig can be
+// used to manage resources.
+const TypeHealthy xpv1.ConditionType = "Healthy"
+
+// Reasons a ProviderConfig is or is not healthy.
+const (
+	ReasonHealthy       xpv1.ConditionReason = "Healthy"
+	ReasonCannotConnect xpv1.ConditionReason = "CannotConnect"
+)
+
+// TypeScopesMissing indicates whether the token of a ProviderConfig lacks
+// OAuth scopes that the kinds of managed resources using it require. It is a
+// warning; the token may still manage resources that need fewer scopes.
+const TypeScopesMissing xpv1.ConditionType = "ScopesMissing"
+
+// Reasons the token of a ProviderConfig does or does not lack scopes.
+const (
+	ReasonMissingScopes xpv1.ConditionReason = "MissingScopes"
+	ReasonScopesGranted xpv1.ConditionReason = "ScopesGranted"
+)
+
+// TypeDegraded indicates whether the credentials of a ProviderConfig will
+// soon stop working.
+const TypeDegraded xpv1.ConditionType = "Degraded"
+
+// Reasons a ProviderConfig is or is not degraded.
+const (
+	ReasonTokenExpiring    xpv1.ConditionReason = "TokenExpiring"
+	ReasonTokenNotExpiring xpv1.ConditionReason = "TokenNotExpiring"
+)
+
+// Healthy returns a condition that indicates the credentials of a
+// ProviderConfig can be used to manage resources.
+func Healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+}
+
+// MissingScopes returns a condition that indicates the token of a
+// ProviderConfig lacks OAuth scopes managed resources using it require.
+func MissingScopes(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeScopesMissing,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMissingScopes,
+		Message:            msg,
+	}
+}
+
+// ScopesGranted returns a condition that indicates the token of a
+// ProviderConfig has the OAuth scopes managed resources using it require, or
+// that its scopes are unknown.
+func ScopesGranted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeScopesMissing,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonScopesGranted,
+	}
+}
+
+// CannotConnect returns a condition that indicates the provider cannot connect
+// to GitHub using a ProviderConfig, for example because its credentials are
+// invalid.
+func CannotConnect(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCannotConnect,
+		Message:            msg,
+	}
+}
+
+// TokenExpiring returns a condition that indicates the token of a
+// ProviderConfig expires soon.
+func TokenExpiring(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokenExpiring,
+		Message:            msg,
+	}
+}
+
+// TokenNotExpiring returns a condition that indicates the token of a
+// ProviderConfig does not expire soon, or does not expire at all.
+func TokenNotExpiring() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokenNotExpiring,
+	}
+}
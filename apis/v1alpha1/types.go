@@ -22,15 +22,35 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// CredentialsSourceGitHubApp indicates that the provider authenticates as an
+// installation of a GitHub App. The referenced secret contains the private
+// key of the app.
+const CredentialsSourceGitHubApp xpv1.CredentialsSource = "GitHubApp"
+
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem;GitHubApp
 	Source xpv1.CredentialsSource `json:"source"`
 
+	// GitHubApp identifies the app installation to authenticate as. It is
+	// required when the source is GitHubApp.
+	// +optional
+	GitHubApp *GitHubAppCredentials `json:"githubApp,omitempty"`
+
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// GitHubAppCredentials identify an installation of a GitHub App.
+type GitHubAppCredentials struct {
+	// The ID of the app.
+	AppID int64 `json:"appID"`
+
+	// The ID of the installation of the app in the account whose resources
+	// are managed.
+	InstallationID int64 `json:"installationID"`
+}
+
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
 	// Add any other fields here for information that is specific to configuring
@@ -38,11 +58,196 @@ type ProviderConfigSpec struct {
 
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// The URL of the REST API of a GitHub Enterprise Server, for example
+	// https://github.example.com/api/v3/. A missing /api/v3/ is added.
+	// Defaults to github.com.
+	// +optional
+	BaseURL *string `json:"baseURL,omitempty"`
+
+	// The URL of the upload API of a GitHub Enterprise Server. A missing
+	// /api/uploads/ is added. Defaults to the base URL.
+	// +optional
+	UploadURL *string `json:"uploadURL,omitempty"`
+
+	// TLS configures how the GitHub instance is verified.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// The URL of an HTTP proxy to connect through. Defaults to the proxy the
+	// HTTPS_PROXY and NO_PROXY environment variables of the provider select.
+	// +optional
+	ProxyURL *string `json:"proxyURL,omitempty"`
+
+	// How long a single request to GitHub may take. Defaults to 30s.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// Retry configures how requests that failed because of a server error or
+	// a broken connection are retried. Only requests that do not modify
+	// anything, such as GETs, are retried.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// DryRun makes the provider observe the resources that use this
+	// ProviderConfig without changing anything. Changes it would have made
+	// are reported as events instead.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// PauseMutationsUntil makes the provider observe the resources that use
+	// this ProviderConfig without changing anything until the supplied
+	// time, such as during an audit. Drift is still reported. The external
+	// resources of managed resources that are deleted meanwhile are deleted
+	// once changes resume. The time is an RFC 3339 timestamp, such as
+	// 2024-07-01T09:00:00+02:00, and includes its time zone.
+	// +optional
+	PauseMutationsUntil *metav1.Time `json:"pauseMutationsUntil,omitempty"`
+
+	// RateBudget limits the requests made using this ProviderConfig, so that
+	// it cannot use up a rate limit that is shared with others, for example
+	// the rate limit of a GitHub App installed once for several tenants.
+	// +optional
+	RateBudget *RateBudget `json:"rateBudget,omitempty"`
+
+	// Discovery configures which existing GitHub resources are discovered
+	// and imported as observe-only managed resources that use this
+	// ProviderConfig.
+	// +optional
+	Discovery *Discovery `json:"discovery,omitempty"`
+
+	// AuditLog configures which organization audit logs are polled for
+	// changes made to managed resources outside of the provider, so that
+	// they are corrected without waiting for the next poll. Reading the
+	// audit log requires GitHub Enterprise Cloud.
+	// +optional
+	AuditLog *AuditLogPolling `json:"auditLog,omitempty"`
+
+	// How long before its token expires the ProviderConfig is marked as
+	// Degraded, so that the token can be renewed before the provider stops
+	// working. Only applies to tokens that expire, such as fine-grained
+	// personal access tokens. Defaults to 168h.
+	// +optional
+	TokenExpiryWarning *metav1.Duration `json:"tokenExpiryWarning,omitempty"`
+}
+
+// AuditLogPolling configures which audit logs are polled.
+type AuditLogPolling struct {
+	// The organizations to poll the audit logs of.
+	// +kubebuilder:validation:MinItems=1
+	Organizations []string `json:"organizations"`
+
+	// How often the audit logs are polled. Defaults to 1m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// Discovery configures which existing GitHub resources are discovered.
+type Discovery struct {
+	// The organizations to discover resources of.
+	// +kubebuilder:validation:MinItems=1
+	Organizations []string `json:"organizations"`
+
+	// Teams enables the discovery of teams. A Team managed resource is
+	// created for every team that is not managed yet. Discovered teams are
+	// only observed, and deleting their managed resources leaves them in
+	// place.
+	// +optional
+	Teams bool `json:"teams,omitempty"`
+}
+
+// A RateBudget limits the requests made using a ProviderConfig.
+type RateBudget struct {
+	// The maximum number of requests per hour. Requests beyond it fail until
+	// the hour has passed. Unlimited if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerHour *int `json:"requestsPerHour,omitempty"`
+
+	// The maximum number of concurrent requests. Further requests wait for
+	// their turn. Unlimited if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int `json:"maxConcurrent,omitempty"`
+}
+
+// A RetryPolicy configures how failed requests are retried.
+type RetryPolicy struct {
+	// The maximum number of attempts of a request, including the first.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+
+	// How long to wait before the first retry. The wait doubles with every
+	// further retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// TLSConfig configures how the GitHub instance is verified.
+type TLSConfig struct {
+	// PEM encoded certificates of authorities to trust in addition to the
+	// system's, for example the internal CA of a GitHub Enterprise Server.
+	// +optional
+	CABundle *string `json:"caBundle,omitempty"`
+
+	// A reference to a secret key that contains PEM encoded certificates of
+	// authorities to trust in addition to the system's.
+	// +optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// Do not verify the certificate of the GitHub instance, and allow a base
+	// URL that does not use TLS at all. This is insecure and only meant for
+	// test instances.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// The normalized URL of the REST API the ProviderConfig connects to.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// The version of the provider that last connected using the
+	// ProviderConfig.
+	ProviderVersion string `json:"providerVersion,omitempty"`
+
+	// The login of the user the credentials authenticate as.
+	Login string `json:"login,omitempty"`
+
+	// The OAuth scopes of the token, or the permissions of the GitHub App
+	// installation, such as members:write. Fine-grained tokens do not report
+	// their permissions.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// The version of the GitHub Enterprise Server the ProviderConfig
+	// connects to. Empty for github.com.
+	EnterpriseVersion string `json:"enterpriseVersion,omitempty"`
+
+	// When the token expires, for tokens that expire, such as fine-grained
+	// personal access tokens.
+	TokenExpiresAt *metav1.Time `json:"tokenExpiresAt,omitempty"`
+
+	// The primary rate limit of the credentials when they were last checked.
+	RateLimit *RateLimitStatus `json:"rateLimit,omitempty"`
+
+	// The rate budget of the ProviderConfig when it was last checked.
+	RateBudget *RateLimitStatus `json:"rateBudget,omitempty"`
+}
+
+// RateLimitStatus is a snapshot of a rate limit.
+type RateLimitStatus struct {
+	// The number of requests allowed per hour.
+	Limit int `json:"limit"`
+
+	// The number of requests remaining until the rate limit resets.
+	Remaining int `json:"remaining"`
+
+	// The time the rate limit resets.
+	Reset metav1.Time `json:"reset"`
 }
 
 // +kubebuilder:object:root=true
@@ -51,7 +256,11 @@ type ProviderConfigStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="LOGIN",type="string",JSONPath=".status.login",priority=1
+// +kubebuilder:printcolumn:name="BASE-URL",type="string",JSONPath=".status.baseURL",priority=1
+// +kubebuilder:printcolumn:name="TOKEN-EXPIRES-AT",type="date",JSONPath=".status.tokenExpiresAt",priority=1
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,github}
 type ProviderConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -76,7 +285,7 @@ type ProviderConfigList struct {
 // +kubebuilder:printcolumn:name="CONFIG-NAME",type="string",JSONPath=".providerConfigRef.name"
 // +kubebuilder:printcolumn:name="RESOURCE-KIND",type="string",JSONPath=".resourceRef.kind"
 // +kubebuilder:printcolumn:name="RESOURCE-NAME",type="string",JSONPath=".resourceRef.name"
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,github}
 type ProviderConfigUsage struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
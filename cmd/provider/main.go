@@ -17,24 +17,61 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/hasheddan/kc-provider-github/apis"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
 	"github.com/hasheddan/kc-provider-github/pkg/controller"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/config"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/team"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+	"github.com/hasheddan/kc-provider-github/pkg/tracing"
+	"github.com/hasheddan/kc-provider-github/pkg/version"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
 )
 
 func main() {
 	var (
-		app        = kingpin.New(filepath.Base(os.Args[0]), "Template support for Crossplane.").DefaultEnvars()
-		debug      = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncPeriod = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		app              = kingpin.New(filepath.Base(os.Args[0]), "Template support for Crossplane.").DefaultEnvars()
+		debug            = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		syncPeriod       = app.Flag("sync-period", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		pollInterval     = app.Flag("poll-interval", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
+		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may be checked for drift from the desired state.").Default("10").Int()
+		webhookListen    = app.Flag("webhook-listen", "Address to receive GitHub webhook events on, such as :8443. Events trigger reconciles of the resources they concern.").String()
+		webhookSecret    = app.Flag("webhook-secret", "Secret GitHub webhook events are signed with.").String()
+		healthCheck      = app.Flag("provider-config-check-interval", "Interval at which the credentials of ProviderConfigs are checked.").Default(config.DefaultHealthCheckInterval.String()).Duration()
+		dryRun           = app.Flag("dry-run", "Observe all resources without changing anything. Changes that would have been made are reported as events.").Bool()
+		usageGCDryRun    = app.Flag("usage-gc-dry-run", "Log stale ProviderConfigUsages instead of deleting them.").Bool()
+		apiVersion       = app.Flag("github-api-version", "Version of the GitHub REST API to request. Only change this in emergencies.").Default(kcgitclient.DefaultAPIVersion).String()
+		callTimeout      = app.Flag("github-call-timeout", "How long a single observation, creation, update, or deletion of an external resource may take.").Default(kcgitclient.DefaultCallTimeout.String()).Duration()
+		etagCache        = app.Flag("etag-cache-size", "Number of GitHub API responses to cache for conditional requests. Zero disables the cache.").Default(strconv.Itoa(kcgitclient.DefaultETagCacheSize)).Int()
+
+		enableTracing = app.Flag("enable-tracing", "Export traces of reconciles and GitHub API calls using OTLP/HTTP.").Default("false").Bool()
+		otlpEndpoint  = app.Flag("otlp-endpoint", "OTLP/HTTP endpoint to export traces to, such as localhost:4318. Defaults to the standard OTEL_EXPORTER_OTLP_* environment variables.").String()
+		otlpInsecure  = app.Flag("otlp-insecure", "Export traces without TLS.").Default("false").Bool()
+
+		enableWebhookSource = app.Flag("enable-webhook-source", "Enable alpha support for reconciles triggered by GitHub webhook events.").Default("false").Bool()
+		enableETagCache     = app.Flag("enable-etag-cache", "Enable alpha support for caching GitHub API responses for conditional requests.").Default("false").Bool()
+		enableTeamCache     = app.Flag("enable-team-observation-cache", "Enable alpha support for observing teams using a periodically listed cache of all teams of their organization.").Default("false").Bool()
+		enableDiscovery     = app.Flag("enable-discovery", "Enable alpha support for discovering existing GitHub resources and importing them as observe-only managed resources.").Default("false").Bool()
+		enableAuditLog      = app.Flag("enable-audit-log-polling", "Enable alpha support for reconciles triggered by changes found in the audit logs of organizations.").Default("false").Bool()
+		enableRepoDefaults  = app.Flag("enable-repository-defaults", "Enable alpha support for RepositoryDefaults, which apply a template of settings to selected repositories of an organization.").Default("false").Bool()
+		teamCacheTTL        = app.Flag("team-observation-cache-ttl", "Age after which the teams of an organization are listed again.").Default(team.DefaultCacheTTL.String()).Duration()
+
+		enableConversionWebhook = app.Flag("enable-conversion-webhook", "Serve the webhook that converts resources between API versions. It is always served if a TLS certificate directory is supplied, as Crossplane does for providers with such CRDs.").Default("false").Bool()
+		webhookTLSCertDir       = app.Flag("webhook-tls-cert-dir", "Directory of the TLS certificate and key the conversion webhook is served with.").Envar("WEBHOOK_TLS_CERT_DIR").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -47,15 +84,77 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "poll-interval", pollInterval.String(), "max-reconcile-rate", *maxReconcileRate, "version", version.Version)
+
+	o := xpcontroller.Options{
+		Logger:                  log,
+		GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
+		PollInterval:            *pollInterval,
+		MaxConcurrentReconciles: *maxReconcileRate,
+		Features:                &feature.Flags{},
+	}
+	var enabled []feature.Flag
+	for _, f := range []struct {
+		flag feature.Flag
+		on   bool
+	}{
+		{features.EnableAlphaWebhookSource, *enableWebhookSource},
+		{features.EnableAlphaETagCache, *enableETagCache},
+		{features.EnableAlphaTeamObservationCache, *enableTeamCache},
+		{features.EnableAlphaDiscovery, *enableDiscovery},
+		{features.EnableAlphaAuditLogPolling, *enableAuditLog},
+		{features.EnableAlphaRepositoryDefaults, *enableRepoDefaults},
+	} {
+		if f.on {
+			o.Features.Enable(f.flag)
+			enabled = append(enabled, f.flag)
+		}
+	}
+	log.Info("Enabled features", "features", enabled)
+
+	if !o.Features.Enabled(features.EnableAlphaETagCache) {
+		*etagCache = 0
+	}
+	kcgitclient.SetETagCacheSize(*etagCache)
+	kcgitclient.SetAPIVersion(*apiVersion)
+	kcgitclient.SetDryRun(*dryRun)
+	kcgitclient.SetCallTimeout(*callTimeout)
+	config.SetHealthCheckInterval(*healthCheck)
+	config.SetUsageGCDryRun(*usageGCDryRun)
+	team.SetCacheTTL(*teamCacheTTL)
+
+	shutdownTracing := func(context.Context) error { return nil }
+	if *enableTracing {
+		var err error
+		shutdownTracing, err = tracing.Setup(context.Background(), *otlpEndpoint, *otlpInsecure)
+		kingpin.FatalIfError(err, "Cannot setup tracing")
+	}
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{SyncPeriod: syncPeriod})
+	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{SyncPeriod: syncPeriod, CertDir: *webhookTLSCertDir})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Template APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup Template controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup Template controllers")
+	// The API server calls the conversion webhook for every request of a kind
+	// with more than one version. Crossplane mounts the certificate of the
+	// webhook Service it creates, so the webhook must be served whenever one
+	// is supplied.
+	if *enableConversionWebhook || *webhookTLSCertDir != "" {
+		kingpin.FatalIfError(controller.SetupWebhooks(mgr), "Cannot setup conversion webhooks")
+	}
+	if o.Features.Enabled(features.EnableAlphaWebhookSource) {
+		if *webhookListen == "" || *webhookSecret == "" {
+			kingpin.Fatalf("A webhook listen address and secret are required to receive webhook events")
+		}
+		srv := webhook.NewServer(*webhookListen, *webhookSecret, mgr.GetClient(), mgr.GetScheme(), log.WithValues("component", "webhook"))
+		kingpin.FatalIfError(mgr.Add(srv), "Cannot add webhook server")
+	}
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Info("Cannot flush traces", "error", err)
+	}
+	kingpin.FatalIfError(err, "Cannot start controller manager")
 }
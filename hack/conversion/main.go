@@ -0,0 +1,73 @@
+//go:build generate
+// +build generate
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Conversion declares webhook conversion in the supplied CRD manifests, which
+// controller-gen does not. The Crossplane package manager points the webhook
+// at the Service and CA bundle of the provider revision that is installed.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// conversion is the conversion of a CRD whose versions are converted by the
+// conversion webhook of the provider. The client config is a placeholder that
+// Crossplane replaces, which the API server requires to be set.
+const conversion = `  conversion:
+    strategy: Webhook
+    webhook:
+      clientConfig:
+        service:
+          name: kc-provider-github
+          namespace: crossplane-system
+          path: /convert
+      conversionReviewVersions:
+      - v1
+`
+
+func main() {
+	for _, path := range os.Args[1:] {
+		if err := patch(path); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot declare conversion in %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// patch inserts the conversion as the first field of the spec of the CRD
+// manifest at the supplied path, where it sorts as controller-gen would.
+func patch(path string) error {
+	b, err := os.ReadFile(path) //nolint:gosec // The paths are those of go:generate.
+	if err != nil {
+		return err
+	}
+	if bytes.Contains(b, []byte("\n  conversion:\n")) {
+		return nil
+	}
+	spec := []byte("\nspec:\n")
+	i := bytes.Index(b, spec)
+	if i < 0 {
+		return fmt.Errorf("no spec")
+	}
+	i += len(spec)
+	out := append(append(append([]byte{}, b[:i]...), conversion...), b[i:]...)
+	return os.WriteFile(path, out, 0o644) //nolint:gosec // CRD manifests are not secret.
+}
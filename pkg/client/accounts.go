@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+const (
+	errGetInstallationAccount = "cannot determine the account the GitHub App is installed on"
+
+	errFmtInaccessibleAccount = "the GitHub App of the ProviderConfig is installed on %s and cannot access resources of %s; use a ProviderConfig whose app is installed on %s"
+)
+
+// An InaccessibleAccountError is returned when the credentials of a
+// ProviderConfig cannot access the account of a managed resource.
+type InaccessibleAccountError struct {
+	msg string
+}
+
+func (e *InaccessibleAccountError) Error() string { return e.msg }
+
+// IsInaccessibleAccount returns true if the supplied error was returned
+// because the credentials of a ProviderConfig cannot access the account of a
+// managed resource.
+func IsInaccessibleAccount(err error) bool {
+	e := &InaccessibleAccountError{}
+	return errors.As(err, &e)
+}
+
+// An installation caches the account a GitHub App installation belongs to.
+// It never changes for an installation, so it is looked up once per
+// connection.
+type installation struct {
+	mu      sync.Mutex
+	known   bool
+	account string
+}
+
+// checkAccount returns an InaccessibleAccountError if the supplied connection
+// authenticates as a GitHub App installation on another account than the one
+// of the supplied managed resource. GitHub answers requests for any other
+// account with a 404, which looks as if the external resource did not exist
+// and would be created over and over again. Tokens are not checked, because
+// a token may be granted access to repositories of any account.
+func checkAccount(ctx context.Context, conn *connection, mg resource.Managed) error {
+	a, ok := mg.(common.AccountScoped)
+	if !ok || conn.app == nil || a.GetAccount() == "" {
+		return nil
+	}
+	installed, err := conn.installation.get(ctx, conn.rest)
+	if err != nil {
+		return errors.Wrap(err, errGetInstallationAccount)
+	}
+	if installed == "" || strings.EqualFold(installed, a.GetAccount()) {
+		return nil
+	}
+	return &InaccessibleAccountError{msg: fmt.Sprintf(errFmtInaccessibleAccount, installed, a.GetAccount(), a.GetAccount())}
+}
+
+// get returns the login of the account the installation the supplied client
+// authenticates as belongs to. All repositories of an installation belong to
+// its account, so it is the owner of any of them. It is empty if the
+// installation cannot access any repository.
+func (i *installation) get(ctx context.Context, c *github.Client) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.known {
+		return i.account, nil
+	}
+	repos, _, err := c.Apps.ListRepos(ctx, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", err
+	}
+	if len(repos.Repositories) > 0 {
+		i.account = repos.Repositories[0].GetOwner().GetLogin()
+	}
+	i.known = true
+	return i.account, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const reasonMutated event.Reason = "GitHubMutation"
+
+// An Auditor records the changes made to GitHub on behalf of managed
+// resources, as log lines and events.
+type Auditor struct {
+	record event.Recorder
+	log    logging.Logger
+}
+
+// NewAuditor returns an Auditor that records changes using the supplied
+// recorder and logger.
+func NewAuditor(r event.Recorder, l logging.Logger) *Auditor {
+	return &Auditor{record: r, log: l}
+}
+
+type auditKey struct{}
+
+type auditTarget struct {
+	auditor *Auditor
+	mg      resource.Managed
+}
+
+// Context returns a context whose successful mutating requests are recorded
+// as made on behalf of the supplied managed resource.
+func (a *Auditor) Context(ctx context.Context, mg resource.Managed) context.Context {
+	return context.WithValue(ctx, auditKey{}, auditTarget{auditor: a, mg: mg})
+}
+
+// audit records the supplied successful mutating request.
+func (a *Auditor) audit(mg resource.Managed, login string, req *http.Request, res *http.Response) {
+	id := res.Header.Get("X-GitHub-Request-Id")
+	if login == "" {
+		// GitHub App installations do not have a login.
+		login = "unknown"
+	}
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	a.log.Info("Changed GitHub resource",
+		"kind", gvk.Kind,
+		"name", mg.GetName(),
+		"method", req.Method,
+		"endpoint", req.URL.Path,
+		"status", res.StatusCode,
+		"request-id", id,
+		"login", login)
+	a.record.Event(mg, event.Normal(reasonMutated, fmt.Sprintf("%s %s by %s (request %s)", req.Method, req.URL.Path, login, id)))
+}
+
+// An auditTransport records successful mutating requests sent with a context
+// returned by an Auditor.
+type auditTransport struct {
+	base http.RoundTripper
+	conn *connection
+}
+
+// RoundTrip sends the supplied request and records it if it changed anything.
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, ok := req.Context().Value(auditKey{}).(auditTarget)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	mutates, err := isMutation(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.base.RoundTrip(req)
+	if err != nil || !mutates || res.StatusCode >= http.StatusBadRequest {
+		return res, err
+	}
+	target.auditor.audit(target.mg, t.conn.login, req, res)
+	return res, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// budgetWindow is the window a RateBudget limits requests for.
+const budgetWindow = time.Hour
+
+var (
+	budgetConsumed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_rate_budget_consumed",
+		Help: "Number of requests made in the current rate budget window, by ProviderConfig.",
+	}, []string{"provider_config"})
+
+	budgetRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_rate_budget_remaining",
+		Help: "Number of requests remaining in the current rate budget window, by ProviderConfig.",
+	}, []string{"provider_config"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(budgetConsumed, budgetRemaining)
+}
+
+// A BudgetExceededError is returned for requests that cannot be sent because
+// the rate budget of their ProviderConfig is exhausted.
+type BudgetExceededError struct {
+	// Reset is the time the budget resets.
+	Reset time.Time
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("rate budget of the ProviderConfig is exhausted until %s", e.Reset.Format(time.RFC3339))
+}
+
+// IsBudgetExceeded reports whether the supplied error was returned because
+// the rate budget of a ProviderConfig is exhausted.
+func IsBudgetExceeded(err error) bool {
+	be := &BudgetExceededError{}
+	return errors.As(err, &be)
+}
+
+var (
+	budgetsMu sync.Mutex
+	budgets   = map[string]*budget{}
+)
+
+// budgetFor returns the budget shared by all connections of the supplied
+// ProviderConfig, updated to the supplied RateBudget. Budgets outlive
+// connections, so that replacing the connection of a ProviderConfig, for
+// example because it was edited, does not reset its budget.
+func budgetFor(pc string, rb *apisv1alpha1.RateBudget) *budget {
+	budgetsMu.Lock()
+	defer budgetsMu.Unlock()
+	b, ok := budgets[pc]
+	if !ok {
+		b = &budget{pc: pc}
+		budgets[pc] = b
+	}
+	b.configure(rb)
+	return b
+}
+
+// forgetBudget drops the budget of the supplied ProviderConfig.
+func forgetBudget(pc string) {
+	budgetsMu.Lock()
+	defer budgetsMu.Unlock()
+	delete(budgets, pc)
+}
+
+// A budget tracks the requests made using a ProviderConfig in the current
+// window, and the requests in flight.
+type budget struct {
+	pc string
+
+	mu      sync.Mutex
+	perHour int
+	slots   chan struct{}
+	start   time.Time
+	used    int
+}
+
+// configure applies the supplied RateBudget. Requests in flight keep the
+// concurrency slot they hold if the maximum concurrency changes.
+func (b *budget) configure(rb *apisv1alpha1.RateBudget) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.perHour = pointer.IntDeref(rb.RequestsPerHour, 0)
+	switch {
+	case rb.MaxConcurrent == nil:
+		b.slots = nil
+	case b.slots == nil || cap(b.slots) != *rb.MaxConcurrent:
+		b.slots = make(chan struct{}, *rb.MaxConcurrent)
+	}
+}
+
+// consume takes a request from the budget of the current window.
+func (b *budget) consume() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.perHour == 0 {
+		return nil
+	}
+	if time.Since(b.start) >= budgetWindow {
+		b.start, b.used = time.Now(), 0
+	}
+	if b.used >= b.perHour {
+		return &BudgetExceededError{Reset: b.start.Add(budgetWindow)}
+	}
+	b.used++
+	b.record()
+	return nil
+}
+
+// refund returns a request to the budget of the current window.
+func (b *budget) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.perHour == 0 || b.used == 0 {
+		return
+	}
+	b.used--
+	b.record()
+}
+
+// record exposes the budget as metrics. It must be called while holding mu.
+func (b *budget) record() {
+	budgetConsumed.WithLabelValues(b.pc).Set(float64(b.used))
+	budgetRemaining.WithLabelValues(b.pc).Set(float64(b.perHour - b.used))
+}
+
+// concurrency returns the slots of requests in flight, or nil if their
+// number is not limited.
+func (b *budget) concurrency() chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.slots
+}
+
+// A budgetTransport enforces the RateBudget of a ProviderConfig.
+type budgetTransport struct {
+	base   http.RoundTripper
+	budget *budget
+}
+
+func newBudgetTransport(base http.RoundTripper, pc string, b *apisv1alpha1.RateBudget) *budgetTransport {
+	return &budgetTransport{base: base, budget: budgetFor(pc, b)}
+}
+
+// RoundTrip sends the supplied request if the budget allows it. Conditional
+// requests that return 304 Not Modified do not count against the budget,
+// just like they do not count against the rate limit of GitHub.
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := t.budget.consume(); err != nil {
+		return nil, recordRateLimit(ctx, err)
+	}
+	if slots := t.budget.concurrency(); slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-slots }()
+	}
+	res, err := t.base.RoundTrip(req)
+	if err == nil && res.StatusCode == http.StatusNotModified {
+		t.budget.refund()
+	}
+	return res, err
+}
+
+// status returns a snapshot of the budget of the current window, or nil if
+// the number of requests is not limited.
+func (t *budgetTransport) status() *apisv1alpha1.RateLimitStatus {
+	if t == nil {
+		return nil
+	}
+	b := t.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.perHour == 0 {
+		return nil
+	}
+	if time.Since(b.start) >= budgetWindow {
+		return &apisv1alpha1.RateLimitStatus{Limit: b.perHour, Remaining: b.perHour}
+	}
+	return &apisv1alpha1.RateLimitStatus{Limit: b.perHour, Remaining: b.perHour - b.used, Reset: metav1.NewTime(b.start.Add(budgetWindow))}
+}
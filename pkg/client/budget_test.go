@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// responding returns a transport that answers every request with the
+// supplied status.
+func responding(status int) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+}
+
+// send sends a GET request with the supplied transport.
+func send(t *testing.T, rt http.RoundTripper) error {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	res, err := rt.RoundTrip(req)
+	if err == nil {
+		_ = res.Body.Close()
+	}
+	return err
+}
+
+func TestBudgetTransport(t *testing.T) {
+	rb := &apisv1alpha1.RateBudget{RequestsPerHour: pointer.Int(3)}
+
+	cases := map[string]struct {
+		reason string
+		status int
+		sent   int
+		err    bool
+	}{
+		"WithinBudget": {
+			reason: "Requests within the budget should be sent.",
+			status: http.StatusOK,
+			sent:   3,
+		},
+		"Exhausted": {
+			reason: "Requests beyond the budget should fail with a BudgetExceededError.",
+			status: http.StatusOK,
+			sent:   4,
+			err:    true,
+		},
+		"NotModified": {
+			reason: "Conditional requests that return 304 Not Modified should not count against the budget.",
+			status: http.StatusNotModified,
+			sent:   10,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := "budget-" + name
+			t.Cleanup(func() { forgetBudget(pc) })
+			rt := newBudgetTransport(responding(tc.status), pc, rb)
+			var err error
+			for i := 0; i < tc.sent; i++ {
+				err = send(t, rt)
+			}
+			if IsBudgetExceeded(err) != tc.err {
+				t.Errorf("\n%s\nRoundTrip(...): want budget exceeded %t, got %v", tc.reason, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestBudgetOutlivesConnection(t *testing.T) {
+	const pc = "budget-rebuilt"
+	t.Cleanup(func() { forgetBudget(pc) })
+	rb := &apisv1alpha1.RateBudget{RequestsPerHour: pointer.Int(2)}
+
+	if err := send(t, newBudgetTransport(responding(http.StatusOK), pc, rb)); err != nil {
+		t.Fatal(err)
+	}
+	// Rebuilding the connection, for example because the ProviderConfig was
+	// edited, must not reset its budget.
+	rebuilt := newBudgetTransport(responding(http.StatusOK), pc, rb)
+	if err := send(t, rebuilt); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(t, rebuilt); !IsBudgetExceeded(err) {
+		t.Errorf("RoundTrip(...): want the budget to be shared by the connections of a ProviderConfig, got %v", err)
+	}
+	if got := rebuilt.status(); got == nil || got.Remaining != 0 {
+		t.Errorf("status(): want no remaining requests, got %+v", got)
+	}
+
+	// A raised budget applies to the requests already made.
+	raised := newBudgetTransport(responding(http.StatusOK), pc, &apisv1alpha1.RateBudget{RequestsPerHour: pointer.Int(3)})
+	if err := send(t, raised); err != nil {
+		t.Errorf("RoundTrip(...): want a raised budget to allow another request, got %v", err)
+	}
+
+	forgetBudget(pc)
+	if err := send(t, newBudgetTransport(responding(http.StatusOK), pc, rb)); err != nil {
+		t.Errorf("RoundTrip(...): want a fresh budget for a ProviderConfig that was deleted, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+var connections = &connectionCache{entries: map[string]*connection{}, failures: map[string]*failure{}}
+
+const (
+	minFailureBackoff = 30 * time.Second
+	maxFailureBackoff = 15 * time.Minute
+)
+
+// A failure records that connecting using a ProviderConfig failed, and when
+// to try again.
+type failure struct {
+	key     string
+	err     error
+	retry   time.Time
+	backoff time.Duration
+}
+
+// A connectionCache holds the latest connection of each ProviderConfig, so
+// that connection pools, rate limit state, and cached responses are shared by
+// all managed resources using it.
+type connectionCache struct {
+	mu       sync.RWMutex
+	entries  map[string]*connection
+	failures map[string]*failure
+}
+
+// get returns the connection of the named ProviderConfig, unless it was
+// created for a different key.
+func (c *connectionCache) get(pc, key string) (*connection, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	conn, ok := c.entries[pc]
+	if !ok || conn.key != key {
+		return nil, false
+	}
+	return conn, true
+}
+
+// set replaces the connection of the named ProviderConfig. The replaced
+// connection is closed.
+func (c *connectionCache) set(pc string, conn *connection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.entries[pc]; ok && old != conn {
+		old.close()
+	}
+	c.entries[pc] = conn
+	delete(c.failures, pc)
+}
+
+// remove closes and drops the connection of the named ProviderConfig.
+func (c *connectionCache) remove(pc string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.entries[pc]; ok {
+		old.close()
+	}
+	delete(c.entries, pc)
+	delete(c.failures, pc)
+}
+
+// fail records that connecting using the named ProviderConfig and the
+// supplied key failed with the supplied error. Its connection is dropped.
+// Connecting is not tried again with the same key until a backoff, which
+// grows with every consecutive failure, expires.
+func (c *connectionCache) fail(pc, key string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.entries[pc]; ok {
+		old.close()
+		delete(c.entries, pc)
+	}
+	backoff := minFailureBackoff
+	if f, ok := c.failures[pc]; ok && f.key == key {
+		backoff = f.backoff * 2
+		if backoff > maxFailureBackoff {
+			backoff = maxFailureBackoff
+		}
+	}
+	c.failures[pc] = &failure{key: key, err: err, retry: time.Now().Add(backoff), backoff: backoff}
+}
+
+// failure returns the error connecting using the named ProviderConfig and the
+// supplied key failed with, unless it is time to try again.
+func (c *connectionCache) failure(pc, key string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	f, ok := c.failures[pc]
+	if !ok || f.key != key || time.Now().After(f.retry) {
+		return nil
+	}
+	return f.err
+}
+
+// connectionKey identifies the generation of the supplied ProviderConfig and
+// the supplied credentials and CA bundle, which may be read from secrets. They
+// are hashed so that the cache does not hold on to secrets that were rotated.
+func connectionKey(pc *apisv1alpha1.ProviderConfig, creds, ca []byte) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(pc.GetGeneration(), 10)))
+	h.Write([]byte{0})
+	h.Write(creds)
+	h.Write([]byte{0})
+	h.Write(ca)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// close releases the idle network connections of the connection. Requests
+// still in flight complete normally.
+func (c *connection) close() {
+	if c.network != nil {
+		c.network.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func TestConnectionCache(t *testing.T) {
+	c := &connectionCache{entries: map[string]*connection{}, failures: map[string]*failure{}}
+	conn := &connection{key: "a"}
+	c.set("pc", conn)
+
+	if got, ok := c.get("pc", "a"); !ok || got != conn {
+		t.Errorf("get(...): want the connection that was set for its key")
+	}
+	if _, ok := c.get("pc", "b"); ok {
+		t.Errorf("get(...): want no connection for a different key")
+	}
+
+	errBoom := errors.New("boom")
+	c.fail("pc", "a", errBoom)
+	if _, ok := c.get("pc", "a"); ok {
+		t.Errorf("get(...): want no connection after connecting failed")
+	}
+	if err := c.failure("pc", "a"); err != errBoom {
+		t.Errorf("failure(...): want %v, got %v", errBoom, err)
+	}
+	if err := c.failure("pc", "b"); err != nil {
+		t.Errorf("failure(...): want no failure for a different key, got %v", err)
+	}
+
+	c.fail("pc", "a", errBoom)
+	if got := c.failures["pc"].backoff; got != 2*minFailureBackoff {
+		t.Errorf("fail(...): want the backoff to double with consecutive failures, got %s", got)
+	}
+	c.fail("pc", "b", errBoom)
+	if got := c.failures["pc"].backoff; got != minFailureBackoff {
+		t.Errorf("fail(...): want the backoff to reset for a different key, got %s", got)
+	}
+	c.failures["pc"].retry = time.Now().Add(-time.Second)
+	if err := c.failure("pc", "b"); err != nil {
+		t.Errorf("failure(...): want no failure once the backoff expired, got %v", err)
+	}
+
+	c.set("pc", conn)
+	c.remove("pc")
+	if _, ok := c.get("pc", "a"); ok {
+		t.Errorf("get(...): want no connection after it was removed")
+	}
+}
+
+// TestConnectLeaks connects many times with rotated credentials, which
+// replaces the connection each time, and checks that replaced connections do
+// not leave goroutines behind.
+func TestConnectLeaks(t *testing.T) {
+	srv, ca := tlsServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/user":
+			_, _ = w.Write([]byte(`{"login":"fake"}`))
+		case "/api/v3/rate_limit":
+			_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":5000,"reset":0}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("leak")
+	pc.Spec.BaseURL = pointer.String(srv.URL)
+	pc.Spec.TLS = &apisv1alpha1.TLSConfig{CABundle: pointer.String(string(ca))}
+	pc.Spec.Credentials = apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+			Key:             "token",
+		}},
+	}
+	t.Cleanup(func() { ForgetProviderConfig(pc.GetName()) })
+
+	cycle := func(i int) {
+		kube := &test.MockClient{
+			MockGet:          secretGet(map[string][]byte{"token": []byte(fmt.Sprintf("token-%d", i))}),
+			MockList:         test.NewMockListFn(nil),
+			MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+		}
+		if _, err := connect(context.Background(), kube, pc.DeepCopy()); err != nil {
+			t.Fatalf("connect(...): %v", err)
+		}
+	}
+
+	// The first cycles warm up pools that are only created once.
+	for i := 0; i < 5; i++ {
+		cycle(i)
+	}
+	before := runtime.NumGoroutine()
+	for i := 5; i < 105; i++ {
+		cycle(i)
+	}
+	ForgetProviderConfig(pc.GetName())
+
+	// Closed network connections take a moment to stop their goroutines.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		after := runtime.NumGoroutine()
+		if after <= before+2 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("connect(...): %d goroutines before 100 connections were replaced, %d after", before, after)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -1,45 +1,181 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	"github.com/google/go-github/v45/github"
+	"github.com/golang/groupcache/singleflight"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
 	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
-	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/version"
 )
 
 const (
-	errEmptyToken   = "no token provided"
-	errNotMyType    = "managed resource is not a MyType custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret"
-
-	errNewClient = "cannot create new Service"
+	errEmptyToken     = "no token provided"
+	errNotMyType      = "managed resource is not a MyType custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCredentials = "cannot get credentials"
+	errNoCredentials  = "credentials are empty"
+
+	errNewClient       = "cannot create new Service"
+	errNoGitHubApp     = "ProviderConfig does not identify a GitHub App installation"
+	errNewAppTransport = "cannot create GitHub App installation transport"
+	errParseURL        = "cannot parse GitHub URLs of ProviderConfig"
+	errUpdatePCStatus  = "cannot update ProviderConfig status"
+	errIdentify        = "cannot determine who the credentials of the ProviderConfig authenticate as"
+	errGetRateLimit    = "cannot get rate limit of the credentials of the ProviderConfig"
+	errUnhealthy       = "ProviderConfig is unhealthy; connecting is retried later"
+
+	errFmtMissingScopes = "token lacks scopes required by managed resources using it: %s"
+	errFmtTokenExpiring = "token expires at %s; renew it and update the credentials of the ProviderConfig"
 )
 
+// defaultTokenExpiryWarning is how long before its token expires a
+// ProviderConfig is marked as degraded by default.
+const defaultTokenExpiryWarning = 7 * 24 * time.Hour
+
 // NewClient creates a new client.
 func NewClient(token string) (*github.Client, error) {
+	hc, err := newTokenClient(token, nil)
+	if err != nil {
+		return nil, err
+	}
+	return github.NewClient(hc), nil
+}
+
+// NewGraphQLClient creates a new GraphQL client.
+func NewGraphQLClient(token string) (*githubv4.Client, error) {
+	hc, err := newTokenClient(token, nil)
+	if err != nil {
+		return nil, err
+	}
+	return githubv4.NewClient(hc), nil
+}
+
+// newTokenClient returns an HTTP client that authenticates with the supplied
+// token and sends its requests using the supplied transport. The default
+// transport is used if it is nil.
+func newTokenClient(token string, base http.RoundTripper) (*http.Client, error) {
 	if token == "" {
 		return nil, errors.New(errEmptyToken)
 	}
-	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	return &http.Client{Transport: &oauth2.Transport{Source: ts, Base: base}}, nil
+}
+
+// newAppClient returns an HTTP client that authenticates as the supplied
+// installation of a GitHub App of the instance with the supplied base URL,
+// and sends its requests using the supplied transport.
+// Installation tokens expire after an hour and are refreshed by the transport
+// as required.
+func newAppClient(app *apisv1alpha1.GitHubAppCredentials, privateKey []byte, baseURL *url.URL, base http.RoundTripper) (*http.Client, error) {
+	if app == nil {
+		return nil, errors.New(errNoGitHubApp)
+	}
+	tr, err := ghinstallation.New(base, app.AppID, app.InstallationID, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewAppTransport)
+	}
+	tr.BaseURL = strings.TrimSuffix(baseURL.String(), "/")
+	return &http.Client{Transport: tr}, nil
+}
 
-	return github.NewClient(tc), nil
+// IsGitHubDotCom reports whether the supplied client talks to github.com
+// rather than to a GitHub Enterprise Server.
+func IsGitHubDotCom(c *github.Client) bool {
+	return c.BaseURL.Host == "api.github.com"
 }
 
+// UseProviderConfig returns a REST client using the credentials of the
+// supplied managed resource's ProviderConfig.
 func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*github.Client, error) {
+	conn, err := useProviderConfig(ctx, c, mg)
+	if err != nil {
+		return nil, err
+	}
+	return conn.rest, nil
+}
+
+// ConnectProviderConfig returns a REST client using the credentials of the
+// supplied ProviderConfig, for controllers that do not reconcile managed
+// resources.
+func ConnectProviderConfig(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig) (*github.Client, error) {
+	conn, err := connect(ctx, c, pc)
+	if err != nil {
+		return nil, err
+	}
+	return conn.rest, nil
+}
+
+// UseProviderConfigGraphQL returns a GraphQL client using the credentials of
+// the supplied managed resource's ProviderConfig.
+func UseProviderConfigGraphQL(ctx context.Context, c client.Client, mg resource.Managed) (*githubv4.Client, error) {
+	conn, err := useProviderConfig(ctx, c, mg)
+	if err != nil {
+		return nil, err
+	}
+	return conn.graphql, nil
+}
+
+// A connection is an authenticated HTTP client and the endpoints of the
+// GitHub instance it connects to.
+type connection struct {
+	http      *http.Client
+	baseURL   *url.URL
+	uploadURL *url.URL
+
+	// app is the transport of a connection that authenticates as a GitHub
+	// App installation.
+	app *ghinstallation.Transport
+
+	// installation is the account the GitHub App installation belongs to.
+	installation installation
+
+	rest    *github.Client
+	graphql *githubv4.Client
+
+	// login is the user the credentials authenticate as, if known.
+	login string
+
+	// network holds the network connections of the connection.
+	network *http.Transport
+
+	// budget enforces the rate budget of the ProviderConfig, if any.
+	budget *budgetTransport
+
+	// key identifies the ProviderConfig generation and the credentials the
+	// connection was created for.
+	key string
+}
+
+// useProviderConfig tracks the supplied managed resource's usage of its
+// ProviderConfig and returns a connection that authenticates using the
+// credentials the ProviderConfig references. Connections are reused until the
+// ProviderConfig or its credentials change.
+func useProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*connection, error) {
+	traceManaged(ctx, mg)
 	usage := resource.NewProviderConfigUsageTracker(c, &apisv1alpha1.ProviderConfigUsage{})
 
 	if err := usage.Track(ctx, mg); err != nil {
@@ -50,23 +186,377 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
+	conn, err := connect(ctx, c, pc)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccount(ctx, conn, mg); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
 
-	// A secret is the most common way to authenticate to a provider, but some
-	// providers additionally support alternative authentication methods such as
-	// IAM, so a reference is not required.
-	ref := pc.Spec.Credentials.SecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
+// CheckProviderConfig connects using the supplied ProviderConfig, unless a
+// connection using its current credentials exists, verifies that the
+// credentials work, and records in its status whether it is healthy. It is
+// called periodically, and when the credentials of a ProviderConfig may have
+// changed, to surface invalid credentials before they fail any reconcile.
+func CheckProviderConfig(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig) error {
+	conn, err := connect(ctx, c, pc)
+	status := pc.Status.DeepCopy()
+	if err == nil {
+		err = verify(ctx, c, pc, conn)
+	}
+	if err == nil {
+		return updateStatus(ctx, c, pc, status)
+	}
+	if IsRateLimit(err) {
+		return err
 	}
+	if conn != nil && failed(err) {
+		connections.fail(pc.GetName(), conn.key, err)
+	}
+	pc.Status.SetConditions(apisv1alpha1.CannotConnect(err.Error()))
+	if uerr := updateStatus(ctx, c, pc, status); uerr != nil {
+		return uerr
+	}
+	return err
+}
 
-	s := &v1.Secret{}
-	if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+// ForgetProviderConfig closes the connection of the named ProviderConfig and
+// drops any state kept for it. It is called once the ProviderConfig was
+// deleted.
+func ForgetProviderConfig(name string) {
+	connections.remove(name)
+	forgetMutationLimiter(name)
+	forgetBudget(name)
+	forgetMetrics(name)
+}
+
+// connect returns a connection that authenticates using the credentials the
+// supplied ProviderConfig references.
+func connect(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig) (*connection, error) {
+	creds, err := credentials(ctx, c, pc.Spec.Credentials)
+	if err != nil {
+		return nil, err
 	}
 
-	svc, err := NewClient(string(s.Data[ref.Key]))
+	ca, err := caBundle(ctx, c, pc.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	key := connectionKey(pc, creds, ca)
+	if conn, ok := connections.get(pc.GetName(), key); ok {
+		return conn, nil
+	}
+	// Credentials that are known to be bad are not tried again until their
+	// backoff expires, so that they do not consume the rate limit.
+	if err := connections.failure(pc.GetName(), key); err != nil {
+		return nil, errors.Wrap(err, errUnhealthy)
+	}
+
+	// Managed resources that use the same ProviderConfig are reconciled
+	// concurrently, but a new connection is only verified once.
+	v, err := verifications.Do(pc.GetName()+"/"+key, func() (interface{}, error) {
+		if conn, ok := connections.get(pc.GetName(), key); ok {
+			return conn, nil
+		}
+		conn, err := newConnection(pc, creds, ca, key)
+		if err != nil {
+			return nil, err
+		}
+		status := pc.Status.DeepCopy()
+		if err := verify(ctx, c, pc, conn); err != nil {
+			if failed(err) {
+				connections.fail(pc.GetName(), key, err)
+			}
+			return nil, err
+		}
+		connections.set(pc.GetName(), conn)
+
+		// The connection works even if its status cannot be recorded, which
+		// the health check of the ProviderConfig tries again.
+		_ = updateStatus(ctx, c, pc, status)
+		return conn, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*connection), nil
+}
+
+// verifications deduplicates concurrent verifications of new connections.
+var verifications singleflight.Group
+
+// An identityError is returned when GitHub refuses to tell who the
+// credentials of a connection authenticate as, for example because the
+// installation of a GitHub App does not exist.
+type identityError struct {
+	error
+}
+
+func (e *identityError) Unwrap() error {
+	return e.error
+}
+
+// failed reports whether the supplied error verifying a connection shows that
+// its credentials do not work, in which case they are not tried again until a
+// backoff expires. Other errors, for example because GitHub is unavailable or
+// rate limited, may not happen again on the next try.
+func failed(err error) bool {
+	ie := &identityError{}
+	return statusCode(err) == http.StatusUnauthorized || errors.As(err, &ie)
+}
+
+// rejected reports whether the supplied error was returned because GitHub
+// refused a request with a client error, other than because of a rate limit.
+func rejected(err error) bool {
+	code := statusCode(err)
+	he := &ghinstallation.HTTPError{}
+	if errors.As(err, &he) && he.Response != nil {
+		code = he.Response.StatusCode
+	}
+	return code >= http.StatusBadRequest && code < http.StatusInternalServerError && !IsRateLimit(err)
+}
+
+// credentials returns the credentials the supplied credentials selectors
+// reference. Credentials are read on every connect, so that rotated
+// credentials, for example a token a Vault agent writes to the filesystem, are
+// picked up without restarting the provider.
+func credentials(ctx context.Context, c client.Client, cd apisv1alpha1.ProviderCredentials) ([]byte, error) {
+	var creds []byte
+	var err error
+	switch cd.Source {
+	case apisv1alpha1.CredentialsSourceGitHubApp:
+		// The private key of a GitHub App is always read from a secret.
+		creds, err = resource.ExtractSecret(ctx, c, cd.CommonCredentialSelectors)
+	default:
+		creds, err = resource.CommonCredentialExtractor(ctx, cd.Source, c, cd.CommonCredentialSelectors)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCredentials)
+	}
+	// A missing secret key, environment variable, or empty file yields no
+	// credentials rather than an error.
+	if len(bytes.TrimSpace(creds)) == 0 {
+		return nil, errors.Wrap(errors.New(errNoCredentials), errGetCredentials)
+	}
+	return creds, nil
+}
+
+// verify records the base URL of the supplied connection and who its
+// credentials authenticate as in the status of the supplied ProviderConfig,
+// which makes it easy to confirm what the ProviderConfig points at. It
+// returns an error if the credentials do not work.
+func verify(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig, conn *connection) error {
+	pc.Status.BaseURL = conn.baseURL.String()
+	pc.Status.ProviderVersion = version.Version
+	if err := updateIdentity(ctx, conn, &pc.Status, usingKinds(ctx, c, pc), tokenExpiryWarning(pc.Spec)); err != nil {
+		err = errors.Wrap(err, errIdentify)
+		if rejected(err) {
+			return &identityError{error: err}
+		}
+		return err
+	}
+
+	// Checking the rate limit does not count against it.
+	rl, res, err := conn.rest.RateLimit.Get(ctx)
+	if err != nil {
+		return errors.Wrap(err, errGetRateLimit)
+	}
+	if core := rl.GetCore(); core != nil {
+		pc.Status.RateLimit = &apisv1alpha1.RateLimitStatus{Limit: core.Limit, Remaining: core.Remaining, Reset: metav1.NewTime(core.Reset.Time)}
+	}
+	pc.Status.EnterpriseVersion = res.Header.Get("X-GitHub-Enterprise-Version")
+	pc.Status.RateBudget = conn.budget.status()
+	return nil
+}
+
+// updateStatus updates the status of the supplied ProviderConfig unless it
+// does not differ from the supplied status. The status is applied to the
+// latest version of the ProviderConfig if it changed since it was read.
+func updateStatus(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig, status *apisv1alpha1.ProviderConfigStatus) error {
+	// Timestamps change on every check and are ignored, except for the expiry
+	// of the token, which changes when the token is renewed.
+	if cmp.Equal(*status, pc.Status, cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(metav1.Time{})) && status.TokenExpiresAt.Equal(pc.Status.TokenExpiresAt) {
+		return nil
+	}
+	want := pc.Status.DeepCopy()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := c.Status().Update(ctx, pc)
+		if !kerrors.IsConflict(err) {
+			return err
+		}
+		if gerr := c.Get(ctx, types.NamespacedName{Name: pc.GetName()}, pc); gerr != nil {
+			return gerr
+		}
+		pc.Status = *want.DeepCopy()
+		return err
+	})
+	return errors.Wrap(err, errUpdatePCStatus)
+}
+
+// newConnection returns a connection to the GitHub instance of the supplied
+// ProviderConfig that authenticates with the supplied credentials and trusts
+// the supplied CA bundle.
+func newConnection(pc *apisv1alpha1.ProviderConfig, creds, ca []byte, key string) (*connection, error) {
+	conn := &connection{key: key}
+	var err error
+	conn.baseURL, conn.uploadURL, err = endpoints(pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseURL)
+	}
+	base, network, err := newTransport(pc.Spec, conn.baseURL, ca)
+	if err != nil {
+		return nil, err
+	}
+	conn.network = network
+
+	switch pc.Spec.Credentials.Source {
+	case apisv1alpha1.CredentialsSourceGitHubApp:
+		conn.http, err = newAppClient(pc.Spec.Credentials.GitHubApp, creds, conn.baseURL, base)
+	default:
+		// Credentials may contain several tokens, one per line, to spread
+		// requests across their rate limits.
+		if tokens := parseTokens(creds); len(tokens) > 1 {
+			conn.http = newTokenPoolClient(tokens, base)
+		} else {
+			conn.http, err = newTokenClient(strings.TrimSpace(string(creds)), base)
+		}
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return svc, nil
+	conn.app, _ = conn.http.Transport.(*ghinstallation.Transport)
+	var tr http.RoundTripper = &auditTransport{
+		base: &tracingTransport{base: &metricsTransport{base: newMutationTransport(conn.http.Transport, mutationLimiterFor(pc.GetName())), pc: pc.GetName()}},
+		conn: conn,
+	}
+	// Each token of a pool has a rate limit of its own, which the pool
+	// tracks, so that one exhausted token does not block the others.
+	if _, ok := conn.http.Transport.(*tokenPoolTransport); !ok {
+		tr = newRateLimitTransport(tr)
+	}
+	if b := pc.Spec.RateBudget; b != nil {
+		conn.budget = newBudgetTransport(tr, pc.GetName(), b)
+		tr = conn.budget
+	}
+	// Cached responses are scoped to the credentials, which may not see the
+	// same data.
+	conn.http.Transport = newETagTransport(tr, etags, key)
+	if isDryRun(pc) {
+		conn.http.Transport = &dryRunTransport{base: conn.http.Transport}
+	}
+
+	conn.rest = github.NewClient(conn.http)
+	conn.rest.BaseURL = conn.baseURL
+	conn.rest.UploadURL = conn.uploadURL
+	conn.graphql = githubv4.NewEnterpriseClient(graphQLURL(conn.baseURL), conn.http)
+	return conn, nil
+}
+
+// updateIdentity records the login and the scopes of the credentials of the
+// supplied connection in the supplied status, and whether they lack any scope
+// the supplied kinds of managed resources require. It also records when a
+// token expires, and whether it expires within the supplied warning period.
+func updateIdentity(ctx context.Context, conn *connection, s *apisv1alpha1.ProviderConfigStatus, kinds []string, warning time.Duration) error {
+	login, scopes, expiry, err := identify(ctx, conn)
+	if err != nil {
+		return err
+	}
+	s.Login, s.Scopes = login, scopes
+	conn.login = login
+	updateTokenExpiry(s, expiry, warning)
+	s.SetConditions(apisv1alpha1.Healthy())
+
+	// The permissions of apps and fine-grained tokens cannot be compared to
+	// OAuth scopes.
+	if conn.app != nil || scopes == nil {
+		s.SetConditions(apisv1alpha1.ScopesGranted())
+		return nil
+	}
+	if missing := missingScopes(scopes, kinds); len(missing) > 0 {
+		s.SetConditions(apisv1alpha1.MissingScopes(fmt.Sprintf(errFmtMissingScopes, formatMissingScopes(missing))))
+		return nil
+	}
+	s.SetConditions(apisv1alpha1.ScopesGranted())
+	return nil
+}
+
+// usingKinds returns the kinds of managed resources that use the supplied
+// ProviderConfig. It returns all kinds that declared required scopes if the
+// usages of the ProviderConfig cannot be listed.
+func usingKinds(ctx context.Context, c client.Client, pc *apisv1alpha1.ProviderConfig) []string {
+	l := &apisv1alpha1.ProviderConfigUsageList{}
+	if err := c.List(ctx, l, client.MatchingLabels{xpv1.LabelKeyProviderName: pc.GetName()}); err != nil {
+		return requiringKinds()
+	}
+	kinds := []string{}
+	for _, u := range l.Items {
+		if k := u.ResourceReference.Kind; !contains(kinds, k) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// updateTokenExpiry records when the token of a ProviderConfig expires in the
+// supplied status, and marks it as degraded if that is within the supplied
+// warning period. The tokens of GitHub App installations expire hourly but are
+// renewed by the provider, so they are never degraded.
+func updateTokenExpiry(s *apisv1alpha1.ProviderConfigStatus, expiry *time.Time, warning time.Duration) {
+	s.TokenExpiresAt = nil
+	if expiry == nil {
+		s.SetConditions(apisv1alpha1.TokenNotExpiring())
+		return
+	}
+	at := metav1.NewTime(*expiry)
+	s.TokenExpiresAt = &at
+	if time.Until(*expiry) < warning {
+		s.SetConditions(apisv1alpha1.TokenExpiring(fmt.Sprintf(errFmtTokenExpiring, expiry.UTC().Format(time.RFC3339))))
+		return
+	}
+	s.SetConditions(apisv1alpha1.TokenNotExpiring())
+}
+
+// tokenExpiryWarning returns how long before its token expires the supplied
+// ProviderConfig is marked as degraded.
+func tokenExpiryWarning(spec apisv1alpha1.ProviderConfigSpec) time.Duration {
+	if spec.TokenExpiryWarning == nil {
+		return defaultTokenExpiryWarning
+	}
+	return spec.TokenExpiryWarning.Duration
+}
+
+// endpoints returns the normalized REST and upload endpoints of the GitHub
+// instance the supplied ProviderConfig points at.
+func endpoints(spec apisv1alpha1.ProviderConfigSpec) (*url.URL, *url.URL, error) {
+	gc := github.NewClient(nil)
+	if spec.BaseURL == nil {
+		return gc.BaseURL, gc.UploadURL, nil
+	}
+	upload := *spec.BaseURL
+	if spec.UploadURL != nil {
+		upload = *spec.UploadURL
+	}
+	// The client normalizes the URLs of an Enterprise Server, for example by
+	// adding a missing /api/v3/.
+	gc, err := gc.WithEnterpriseURLs(*spec.BaseURL, upload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gc.BaseURL, gc.UploadURL, nil
+}
+
+// graphQLURL returns the GraphQL endpoint of the GitHub instance with the
+// supplied REST endpoint.
+func graphQLURL(base *url.URL) string {
+	u := *base
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path = "/graphql"
+	}
+	return u.String()
 }
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// privateKey returns a PEM encoded RSA private key to sign GitHub App JWTs
+// with.
+func privateKey(t *testing.T) []byte {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+}
+
+// An appServer issues installation tokens that expire after a configurable
+// lifetime, and records the tokens API requests were authenticated with.
+type appServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	lifetime time.Duration
+	issued   int
+	used     []string
+}
+
+func newAppServer(lifetime time.Duration) *appServer {
+	s := &appServer{lifetime: lifetime}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.Method == http.MethodPost && r.URL.Path == "/app/installations/2/access_tokens" {
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			s.issued++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"token":      fmt.Sprintf("token-%d", s.issued),
+				"expires_at": time.Now().Add(s.lifetime).UTC().Format(time.RFC3339),
+			})
+			return
+		}
+		s.used = append(s.used, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("{}"))
+	}))
+	return s
+}
+
+func TestNewAppClientRefreshesTokens(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		lifetime time.Duration
+		want     []string
+	}{
+		"Valid": {
+			reason:   "A token that is far from expiring should be reused.",
+			lifetime: time.Hour,
+			want:     []string{"token token-1", "token token-1", "token token-1"},
+		},
+		"Expiring": {
+			reason:   "A token that is about to expire should be refreshed before it is used again.",
+			lifetime: 30 * time.Second,
+			want:     []string{"token token-1", "token token-2", "token token-3"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newAppServer(tc.lifetime)
+			defer s.Close()
+			u, _ := url.Parse(s.URL + "/")
+
+			hc, err := newAppClient(&apisv1alpha1.GitHubAppCredentials{AppID: 1, InstallationID: 2}, privateKey(t), u, http.DefaultTransport)
+			if err != nil {
+				t.Fatalf("newAppClient(...): %v", err)
+			}
+			for i := 0; i < len(tc.want); i++ {
+				res, err := hc.Get(s.URL + "/user")
+				if err != nil {
+					t.Fatalf("Get(...): %v", err)
+				}
+				res.Body.Close()
+			}
+			if diff := cmp.Diff(tc.want, s.used); diff != "" {
+				t.Errorf("\n%s\nAuthorization: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewAppClient(t *testing.T) {
+	u, _ := url.Parse("https://api.github.com/")
+	cases := map[string]struct {
+		reason string
+		app    *apisv1alpha1.GitHubAppCredentials
+		key    []byte
+	}{
+		"NoApp": {
+			reason: "A ProviderConfig that does not identify an installation should be rejected.",
+			key:    privateKey(t),
+		},
+		"InvalidKey": {
+			reason: "A private key that cannot be parsed should be rejected.",
+			app:    &apisv1alpha1.GitHubAppCredentials{AppID: 1, InstallationID: 2},
+			key:    []byte("not a key"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newAppClient(tc.app, tc.key, u, http.DefaultTransport); err == nil {
+				t.Errorf("\n%s\nnewAppClient(...): want error, got nil", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func apiError(status int) error {
+	return &github.ErrorResponse{Response: &http.Response{StatusCode: status, Request: &http.Request{}}}
+}
+
+func TestFailed(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Unauthorized":   {err: errors.Wrap(apiError(http.StatusUnauthorized), errGetRateLimit), want: true},
+		"Identity":       {err: &identityError{error: errors.Wrap(apiError(http.StatusNotFound), errIdentify)}, want: true},
+		"ServerError":    {err: errors.Wrap(apiError(http.StatusBadGateway), errIdentify)},
+		"RateLimit":      {err: &RateLimitError{Reset: time.Now()}},
+		"NetworkError":   {err: errors.Wrap(errors.New("connection refused"), errIdentify)},
+		"StatusConflict": {err: errors.Wrap(kerrors.NewConflict(schema.GroupResource{}, "default", errors.New("boom")), errUpdatePCStatus)},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := failed(tc.err); got != tc.want {
+				t.Errorf("failed(%v): want %t, got %t", tc.err, tc.want, got)
+			}
+		})
+	}
+}
+
+// A verifyServer is a fake GitHub server that answers the requests made to
+// verify a connection, and counts them.
+type verifyServer struct {
+	userStatus int
+	users      int32
+	// block holds identity requests until it is closed, if it is not nil.
+	block chan struct{}
+}
+
+func (s *verifyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/api/v3/user":
+		atomic.AddInt32(&s.users, 1)
+		if s.block != nil {
+			<-s.block
+		}
+		if s.userStatus != 0 {
+			w.WriteHeader(s.userStatus)
+			_, _ = w.Write([]byte(`{"message":"nope"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"login":"fake"}`))
+	case "/api/v3/rate_limit":
+		_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":5000,"reset":0}}}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// verifiedProviderConfig returns a ProviderConfig with the supplied name for
+// the supplied server.
+func verifiedProviderConfig(t *testing.T, name string, s *verifyServer) *apisv1alpha1.ProviderConfig {
+	t.Helper()
+	srv, ca := tlsServer(t, s)
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName(name)
+	pc.Spec.BaseURL = pointer.String(srv.URL)
+	pc.Spec.TLS = &apisv1alpha1.TLSConfig{CABundle: pointer.String(string(ca))}
+	pc.Spec.Retry = &apisv1alpha1.RetryPolicy{MaxAttempts: pointer.Int(1)}
+	pc.Spec.Credentials = apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+			Key:             "token",
+		}},
+	}
+	t.Cleanup(func() { ForgetProviderConfig(name) })
+	return pc
+}
+
+func TestConnectFailures(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		userStatus int
+		update     test.MockStatusUpdateFn
+		wantErr    bool
+		wantFailed bool
+	}{
+		"Unauthorized": {
+			reason:     "Credentials GitHub does not accept should not be tried again until their backoff expires.",
+			userStatus: http.StatusUnauthorized,
+			wantErr:    true,
+			wantFailed: true,
+		},
+		"ServerError": {
+			reason:     "A GitHub outage should not make credentials be considered bad.",
+			userStatus: http.StatusBadGateway,
+			wantErr:    true,
+		},
+		"StatusUpdateError": {
+			reason: "A connection that works should be used even if its status cannot be recorded.",
+			update: test.NewMockStatusUpdateFn(errors.New("boom")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := verifiedProviderConfig(t, "failures-"+name, &verifyServer{userStatus: tc.userStatus})
+			update := tc.update
+			if update == nil {
+				update = test.NewMockStatusUpdateFn(nil)
+			}
+			kube := &test.MockClient{
+				MockGet:          secretGet(map[string][]byte{"token": []byte("token")}),
+				MockList:         test.NewMockListFn(nil),
+				MockStatusUpdate: update,
+			}
+			_, err := connect(context.Background(), kube, pc)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nconnect(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			creds, _ := credentials(context.Background(), kube, pc.Spec.Credentials)
+			ca, _ := caBundle(context.Background(), kube, pc.Spec.TLS)
+			ferr := connections.failure(pc.GetName(), connectionKey(pc, creds, ca))
+			if (ferr != nil) != tc.wantFailed {
+				t.Errorf("\n%s\nconnect(...): want failure recorded %t, got %v", tc.reason, tc.wantFailed, ferr)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusConflict(t *testing.T) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("conflict")
+	status := pc.Status.DeepCopy()
+	pc.Status.Login = "fake"
+
+	updates := 0
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			latest := obj.(*apisv1alpha1.ProviderConfig)
+			latest.SetResourceVersion("2")
+			latest.Status = apisv1alpha1.ProviderConfigStatus{}
+			return nil
+		},
+		MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			updates++
+			if obj.GetResourceVersion() != "2" {
+				return kerrors.NewConflict(schema.GroupResource{}, "conflict", errors.New("stale"))
+			}
+			if got := obj.(*apisv1alpha1.ProviderConfig).Status.Login; got != "fake" {
+				t.Errorf("updateStatus(...): want the status applied to the latest version, got login %q", got)
+			}
+			return nil
+		},
+	}
+	if err := updateStatus(context.Background(), kube, pc, status); err != nil {
+		t.Fatalf("updateStatus(...): %v", err)
+	}
+	if updates != 2 {
+		t.Errorf("updateStatus(...): want 2 updates, got %d", updates)
+	}
+}
+
+func TestConnectVerifiesOnce(t *testing.T) {
+	s := &verifyServer{block: make(chan struct{})}
+	pc := verifiedProviderConfig(t, "once", s)
+	kube := &test.MockClient{
+		MockGet:          secretGet(map[string][]byte{"token": []byte("token")}),
+		MockList:         test.NewMockListFn(nil),
+		MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+	}
+
+	const callers = 10
+	wg := sync.WaitGroup{}
+	conns := make([]*connection, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn, err := connect(context.Background(), kube, pc.DeepCopy())
+			if err != nil {
+				t.Errorf("connect(...): %v", err)
+			}
+			conns[i] = conn
+		}(i)
+	}
+	// Give all callers a chance to wait for the verification in flight.
+	time.Sleep(100 * time.Millisecond)
+	close(s.block)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&s.users); got != 1 {
+		t.Errorf("connect(...): want the connection verified once, got %d identity requests", got)
+	}
+	for i := range conns {
+		if conns[i] != conns[0] {
+			t.Errorf("connect(...): want all callers to share one connection")
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func secretGet(data map[string][]byte) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		s, ok := obj.(*corev1.Secret)
+		if !ok {
+			return errors.New("not a secret")
+		}
+		s.Data = data
+		return nil
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+	dir := t.TempDir()
+	token := filepath.Join(dir, "token")
+	if err := os.WriteFile(token, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN_TEST", "env-token")
+
+	secretRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+		Key:             "token",
+	}
+
+	type want struct {
+		creds []byte
+		err   error
+	}
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		cd     apisv1alpha1.ProviderCredentials
+		want   want
+	}{
+		"Secret": {
+			reason: "Credentials should be read from the referenced secret key.",
+			get:    secretGet(map[string][]byte{"token": []byte("secret-token")}),
+			cd: apisv1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{creds: []byte("secret-token")},
+		},
+		"SecretMissingKey": {
+			reason: "A secret without the referenced key should return an error rather than empty credentials.",
+			get:    secretGet(map[string][]byte{"other": []byte("secret-token")}),
+			cd: apisv1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{err: errors.Wrap(errors.New(errNoCredentials), errGetCredentials)},
+		},
+		"SecretGetError": {
+			reason: "Errors getting the referenced secret should be returned.",
+			get:    test.NewMockGetFn(errBoom),
+			cd: apisv1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{err: errors.Wrap(errors.Wrap(errBoom, "cannot get credentials secret"), errGetCredentials)},
+		},
+		"GitHubApp": {
+			reason: "The private key of a GitHub App should be read from the referenced secret key.",
+			get:    secretGet(map[string][]byte{"token": []byte("private-key")}),
+			cd: apisv1alpha1.ProviderCredentials{
+				Source:                    apisv1alpha1.CredentialsSourceGitHubApp,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{creds: []byte("private-key")},
+		},
+		"Environment": {
+			reason: "Credentials should be read from the referenced environment variable.",
+			cd: apisv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Env: &xpv1.EnvSelector{Name: "GITHUB_TOKEN_TEST"},
+				},
+			},
+			want: want{creds: []byte("env-token")},
+		},
+		"EnvironmentUnset": {
+			reason: "An unset environment variable should return an error rather than empty credentials.",
+			cd: apisv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Env: &xpv1.EnvSelector{Name: "GITHUB_TOKEN_TEST_UNSET"},
+				},
+			},
+			want: want{err: errors.Wrap(errors.New(errNoCredentials), errGetCredentials)},
+		},
+		"Filesystem": {
+			reason: "Credentials should be read from the referenced file.",
+			cd: apisv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Fs: &xpv1.FsSelector{Path: token},
+				},
+			},
+			want: want{creds: []byte("file-token\n")},
+		},
+		"FilesystemEmpty": {
+			reason: "An empty file should return an error rather than empty credentials.",
+			cd: apisv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Fs: &xpv1.FsSelector{Path: empty},
+				},
+			},
+			want: want{err: errors.Wrap(errors.New(errNoCredentials), errGetCredentials)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &test.MockClient{MockGet: tc.get}
+			creds, err := credentials(context.Background(), c, tc.cd)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creds, creds); diff != "" {
+				t.Errorf("\n%s\ncredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCredentialsRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	cd := apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceFilesystem,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			Fs: &xpv1.FsSelector{Path: path},
+		},
+	}
+
+	for _, want := range []string{"first", "rotated"} {
+		if err := os.WriteFile(path, []byte(want), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		creds, err := credentials(context.Background(), &test.MockClient{}, cd)
+		if err != nil {
+			t.Fatalf("credentials(...): %v", err)
+		}
+		if diff := cmp.Diff(want, string(creds)); diff != "" {
+			t.Errorf("credentials(...): a rotated file should be read again: -want, +got:\n%s", diff)
+		}
+	}
+}
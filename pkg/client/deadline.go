@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// DefaultCallTimeout is the default time a single call of an external client,
+// such as Observe, may take.
+const DefaultCallTimeout = 30 * time.Second
+
+const errAbandoned = "abandoned GitHub API call"
+
+var callTimeout = DefaultCallTimeout
+
+// SetCallTimeout sets the time a single call of an external client may take.
+// A call may consist of several requests, each of which is also bounded by
+// the request timeout of its ProviderConfig.
+func SetCallTimeout(d time.Duration) {
+	callTimeout = d
+}
+
+// WithCallTimeout wraps the supplied ExternalConnecter so that the calls of
+// the external clients it connects are abandoned once they take longer than
+// the call timeout, or once the reconcile is canceled, for example because
+// the provider shuts down.
+func WithCallTimeout(ec managed.ExternalConnecter) managed.ExternalConnecter {
+	return &timeoutConnecter{ExternalConnecter: ec}
+}
+
+type timeoutConnecter struct {
+	managed.ExternalConnecter
+}
+
+// Connect connects an external client whose calls time out.
+func (c *timeoutConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	ext, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, abandoned(ctx, err)
+	}
+	return &timeoutExternal{ExternalClient: ext}, nil
+}
+
+type timeoutExternal struct {
+	managed.ExternalClient
+}
+
+// Observe observes the supplied managed resource within the call timeout.
+func (e *timeoutExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, abandoned(ctx, err)
+}
+
+// Create creates the supplied managed resource within the call timeout.
+func (e *timeoutExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	c, err := e.ExternalClient.Create(ctx, mg)
+	return c, abandoned(ctx, err)
+}
+
+// Update updates the supplied managed resource within the call timeout.
+func (e *timeoutExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, abandoned(ctx, err)
+}
+
+// Delete deletes the supplied managed resource within the call timeout.
+func (e *timeoutExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	return abandoned(ctx, e.ExternalClient.Delete(ctx, mg))
+}
+
+// abandoned returns the error of the supplied context if the supplied error
+// occurred because the context is done, so that timeouts and cancellations
+// are reported as such rather than as whatever error the abandoned request
+// happened to return. The managed reconciler retries either.
+func abandoned(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	return errors.Wrap(ctx.Err(), errAbandoned)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// hungServer returns a server that never responds. Requests it receives are
+// sent to the returned channel once they are abandoned by their client.
+func hungServer(t *testing.T) (*github.Client, <-chan string) {
+	t.Helper()
+	abandoned := make(chan string, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		abandoned <- r.Method
+	}))
+	t.Cleanup(s.Close)
+	c := github.NewClient(s.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c, abandoned
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx func() (context.Context, context.CancelFunc)
+		// call calls the supplied external client.
+		call func(ctx context.Context, e managed.ExternalClient) error
+	}
+	type want struct {
+		err    error
+		method string
+	}
+	cases := map[string]struct {
+		reason string
+		// hung is whether the external client calls a hung server.
+		hung bool
+		// err is returned by the external client if it is not hung.
+		err  error
+		args args
+		want want
+	}{
+		"ObserveTimedOut": {
+			reason: "An Observe that hangs should be abandoned at the call timeout.",
+			hung:   true,
+			args: args{call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Observe(ctx, &fake.Managed{})
+				return err
+			}},
+			want: want{err: errors.Wrap(context.DeadlineExceeded, errAbandoned), method: http.MethodGet},
+		},
+		"CreateTimedOut": {
+			reason: "A Create that hangs should be abandoned at the call timeout.",
+			hung:   true,
+			args: args{call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Create(ctx, &fake.Managed{})
+				return err
+			}},
+			want: want{err: errors.Wrap(context.DeadlineExceeded, errAbandoned), method: http.MethodPost},
+		},
+		"UpdateTimedOut": {
+			reason: "An Update that hangs should be abandoned at the call timeout.",
+			hung:   true,
+			args: args{call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Update(ctx, &fake.Managed{})
+				return err
+			}},
+			want: want{err: errors.Wrap(context.DeadlineExceeded, errAbandoned), method: http.MethodPatch},
+		},
+		"DeleteTimedOut": {
+			reason: "A Delete that hangs should be abandoned at the call timeout.",
+			hung:   true,
+			args: args{call: func(ctx context.Context, e managed.ExternalClient) error {
+				return e.Delete(ctx, &fake.Managed{})
+			}},
+			want: want{err: errors.Wrap(context.DeadlineExceeded, errAbandoned), method: http.MethodDelete},
+		},
+		"Canceled": {
+			reason: "A call that hangs should be abandoned once the reconcile is canceled.",
+			hung:   true,
+			args: args{
+				ctx: func() (context.Context, context.CancelFunc) {
+					ctx, cancel := context.WithCancel(context.Background())
+					time.AfterFunc(10*time.Millisecond, cancel)
+					return ctx, cancel
+				},
+				call: func(ctx context.Context, e managed.ExternalClient) error {
+					_, err := e.Observe(ctx, &fake.Managed{})
+					return err
+				},
+			},
+			want: want{err: errors.Wrap(context.Canceled, errAbandoned), method: http.MethodGet},
+		},
+		"Error": {
+			reason: "Errors of calls that were not abandoned should be returned as they are.",
+			err:    errBoom,
+			args: args{call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Observe(ctx, &fake.Managed{})
+				return err
+			}},
+			want: want{err: errBoom},
+		},
+	}
+
+	defer SetCallTimeout(callTimeout)
+	SetCallTimeout(50 * time.Millisecond)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, abandoned := hungServer(t)
+			request := func(ctx context.Context, method string) error {
+				if !tc.hung {
+					return tc.err
+				}
+				req, err := c.NewRequest(method, "orgs/acme/teams/platform", nil)
+				if err != nil {
+					return err
+				}
+				_, err = c.Do(ctx, req, nil)
+				return err
+			}
+			ec := WithCallTimeout(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, request(ctx, http.MethodGet)
+					},
+					CreateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, request(ctx, http.MethodPost)
+					},
+					UpdateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+						return managed.ExternalUpdate{}, request(ctx, http.MethodPatch)
+					},
+					DeleteFn: func(ctx context.Context, _ resource.Managed) error {
+						return request(ctx, http.MethodDelete)
+					},
+				}, nil
+			}))
+
+			ctx, cancel := context.Background(), context.CancelFunc(func() {})
+			if tc.args.ctx != nil {
+				ctx, cancel = tc.args.ctx()
+			}
+			defer cancel()
+
+			e, err := ec.Connect(ctx, &fake.Managed{})
+			if err != nil {
+				t.Fatalf("Connect(...): %v", err)
+			}
+			done := make(chan error, 1)
+			go func() { done <- tc.args.call(ctx, e) }()
+
+			select {
+			case err = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("\n%s\nThe call was not abandoned.", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncall(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			// The request must be abandoned by the transport too, rather
+			// than keep waiting for the server.
+			if tc.want.method == "" {
+				return
+			}
+			select {
+			case m := <-abandoned:
+				if m != tc.want.method {
+					t.Errorf("\n%s\nwant abandoned %s request, got %s", tc.reason, tc.want.method, m)
+				}
+			case <-time.After(5 * time.Second):
+				t.Errorf("\n%s\nThe %s request was not abandoned.", tc.reason, tc.want.method)
+			}
+		})
+	}
+}
+
+func TestWithCallTimeoutConnect(t *testing.T) {
+	defer SetCallTimeout(callTimeout)
+	SetCallTimeout(50 * time.Millisecond)
+
+	c, abandoned := hungServer(t)
+	ec := WithCallTimeout(managed.ExternalConnectorFn(func(ctx context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		// Connecting verifies the credentials the first time.
+		_, _, err := c.Users.Get(ctx, "")
+		return nil, err
+	}))
+
+	_, err := ec.Connect(context.Background(), &fake.Managed{})
+	if diff := cmp.Diff(errors.Wrap(context.DeadlineExceeded, errAbandoned), err, test.EquateErrors()); diff != "" {
+		t.Errorf("\nA Connect that hangs should be abandoned at the call timeout.\nConnect(...): -want error, +got error:\n%s", diff)
+	}
+	select {
+	case <-abandoned:
+	case <-time.After(5 * time.Second):
+		t.Error("\nA Connect that hangs should be abandoned at the call timeout.\nThe request was not abandoned.")
+	}
+}
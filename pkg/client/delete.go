@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// forbiddenRequeue is how long a managed resource whose deletion was
+// forbidden waits before its deletion is tried again. Retrying sooner cannot
+// help until its credentials are changed.
+const forbiddenRequeue = time.Hour
+
+const errForbiddenDelete = "the credentials of the ProviderConfig lack permission to delete this resource, which for many organization resources requires an organization owner; fix the credentials or set deletionPolicy Orphan"
+
+// DeleteError returns the error to return from Delete for the supplied error
+// of a request that deletes an external resource. An external resource that
+// does not exist is already deleted, so a 404 is not an error. A 403 sets the
+// DeletionForbidden condition of the managed resource and postpones its next
+// reconcile rather than retrying with exponential backoff.
+func DeleteError(ctx context.Context, mg resource.Managed, err error, msg string) error {
+	switch {
+	case err == nil, IsNotFound(err):
+		return nil
+	case IsForbidden(err):
+		mg.SetConditions(common.DeletionForbidden(errForbiddenDelete))
+		if r, ok := ctx.Value(forbiddenKey{}).(*forbiddenRecord); ok {
+			r.mu.Lock()
+			r.forbidden = true
+			r.mu.Unlock()
+		}
+		return errors.Wrap(WrapAPIError(err, msg), errForbiddenDelete)
+	default:
+		return WrapAPIError(err, msg)
+	}
+}
+
+type forbiddenKey struct{}
+
+// A forbiddenRecord records whether a deletion was forbidden during a
+// reconcile.
+type forbiddenRecord struct {
+	mu        sync.Mutex
+	forbidden bool
+}
+
+// RequeueOnForbiddenDelete wraps the supplied reconciler so that a reconcile
+// whose deletion was forbidden, as reported by DeleteError, is requeued after
+// an hour rather than being retried with exponential backoff.
+func RequeueOnForbiddenDelete(r reconcile.Reconciler) reconcile.Reconciler {
+	return &forbiddenDeleteRequeuer{inner: r}
+}
+
+type forbiddenDeleteRequeuer struct {
+	inner reconcile.Reconciler
+}
+
+func (r *forbiddenDeleteRequeuer) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	rec := &forbiddenRecord{}
+	result, err := r.inner.Reconcile(context.WithValue(ctx, forbiddenKey{}, rec), req)
+
+	rec.mu.Lock()
+	forbidden := rec.forbidden
+	rec.mu.Unlock()
+	if !forbidden {
+		return result, err
+	}
+	return reconcile.Result{RequeueAfter: forbiddenRequeue}, nil
+}
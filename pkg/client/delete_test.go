@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+func TestDeleteError(t *testing.T) {
+	errBoom := errors.New("boom")
+	forbidden := errorResponse(http.StatusForbidden, "", "Must be an organization owner")
+
+	type want struct {
+		err       error
+		condition *xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"Deleted": {
+			reason: "A successful deletion should not be an error.",
+		},
+		"NotFound": {
+			reason: "An external resource that does not exist is already deleted.",
+			err:    errorResponse(http.StatusNotFound, "", "Not Found"),
+		},
+		"Forbidden": {
+			reason: "A forbidden deletion should be an error that says how to fix it, and set the DeletionForbidden condition.",
+			err:    forbidden,
+			want: want{
+				err:       errors.Wrap(WrapAPIError(forbidden, "cannot delete team"), errForbiddenDelete),
+				condition: func() *xpv1.Condition { c := common.DeletionForbidden(errForbiddenDelete); return &c }(),
+			},
+		},
+		"RateLimit": {
+			reason: "A rate limited deletion should be retried rather than be reported as forbidden.",
+			err:    &RateLimitError{},
+			want:   want{err: errors.Wrap(&RateLimitError{}, "cannot delete team")},
+		},
+		"Error": {
+			reason: "Other errors should be wrapped.",
+			err:    errBoom,
+			want:   want{err: errors.Wrap(errBoom, "cannot delete team")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			err := DeleteError(context.Background(), mg, tc.err, "cannot delete team")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeleteError(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			var got *xpv1.Condition
+			if c := mg.GetCondition(xpv1.TypeReady); c.Reason != "" {
+				got = &c
+			}
+			if diff := cmp.Diff(tc.want.condition, got, test.EquateConditions(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nDeleteError(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequeueOnForbiddenDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"Forbidden": {
+			reason: "A reconcile whose deletion was forbidden should be requeued after an hour, without backing off.",
+			err:    errorResponse(http.StatusForbidden, "", "Must be an organization owner"),
+			want:   want{result: reconcile.Result{RequeueAfter: forbiddenRequeue}},
+		},
+		"Error": {
+			reason: "A reconcile whose deletion failed otherwise should return its result.",
+			err:    errBoom,
+			want:   want{result: reconcile.Result{Requeue: true}, err: errors.Wrap(errBoom, "cannot delete team")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := RequeueOnForbiddenDelete(reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{Requeue: true}, DeleteError(ctx, &fake.Managed{}, tc.err, "cannot delete team")
+			}))
+			result, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want, want{result: result, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
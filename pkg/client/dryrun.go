@@ -0,0 +1,221 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// AnnotationKeyObserveOnly is the key of the annotation that makes the
+// provider observe a managed resource without changing anything, as if its
+// ProviderConfig was in dry-run mode.
+const AnnotationKeyObserveOnly = "github.hasheddan.io/observe-only"
+
+const (
+	errDryRun = "refusing to send mutating request in dry-run mode"
+
+	reasonDryRun          event.Reason = "DryRun"
+	reasonMutationsPaused event.Reason = "MutationsPaused"
+
+	errFmtPaused = "changes are paused by ProviderConfig %s until %s"
+)
+
+var dryRun bool
+
+// SetDryRun sets whether the provider observes all resources without changing
+// anything, regardless of their ProviderConfig.
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// isDryRun reports whether changes must not be made using the supplied
+// ProviderConfig.
+func isDryRun(pc *apisv1alpha1.ProviderConfig) bool {
+	return dryRun || pc.Spec.DryRun
+}
+
+// pausedUntil returns until when changes must not be made using the supplied
+// ProviderConfig, or nil if they may be made now.
+func pausedUntil(pc *apisv1alpha1.ProviderConfig, now time.Time) *metav1.Time {
+	if t := pc.Spec.PauseMutationsUntil; t != nil && now.Before(t.Time) {
+		return t
+	}
+	return nil
+}
+
+// isObserveOnly reports whether changes must not be made to the supplied
+// managed resource.
+func isObserveOnly(mg resource.Managed) bool {
+	return mg.GetAnnotations()[AnnotationKeyObserveOnly] == "true"
+}
+
+// A dryRunTransport refuses to send mutating requests. It guards against
+// changes that slip past the dry-run external client.
+type dryRunTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the supplied request unless it mutates.
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mutates, err := isMutation(req)
+	if err != nil {
+		return nil, err
+	}
+	if mutates {
+		return nil, errors.Errorf("%s: %s %s", errDryRun, req.Method, req.URL.Path)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// WithDryRun wraps the supplied connecter of the named controller so that, in
+// dry-run mode, its external clients only observe. The creations, updates, and
+// deletions they would have made are reported as events and log lines.
+func WithDryRun(mgr ctrl.Manager, name string, l logging.Logger, ec managed.ExternalConnecter) managed.ExternalConnecter {
+	return &dryRunConnecter{
+		ExternalConnecter: ec,
+		kube:              mgr.GetClient(),
+		record:            event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		log:               l.WithValues("controller", name),
+	}
+}
+
+type dryRunConnecter struct {
+	managed.ExternalConnecter
+	kube   client.Client
+	record event.Recorder
+	log    logging.Logger
+}
+
+// Connect returns an external client that only observes if the ProviderConfig
+// of the supplied managed resource is in dry-run mode or pauses changes, or if
+// the managed resource is annotated as observe-only. Paused managed resources
+// have the MutationsPaused condition, so that it is obvious why their drift is
+// not corrected.
+func (c *dryRunConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ext, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+	if until := pausedUntil(pc, time.Now()); until != nil {
+		msg := fmt.Sprintf(errFmtPaused, pc.GetName(), until.UTC().Format(time.RFC3339))
+		mg.SetConditions(common.MutationsPaused(msg))
+		return &dryRunExternal{ExternalClient: ext, record: c.record, log: c.log, reason: reasonMutationsPaused, prefix: msg, paused: true}, nil
+	}
+	if mg.GetCondition(common.TypeMutationsPaused).Status == corev1.ConditionTrue {
+		mg.SetConditions(common.MutationsResumed())
+	}
+	if !isDryRun(pc) && !isObserveOnly(mg) {
+		return ext, nil
+	}
+	return &dryRunExternal{ExternalClient: ext, record: c.record, log: c.log, reason: reasonDryRun, prefix: "dry run"}, nil
+}
+
+// A dryRunExternal observes using the wrapped external client but reports the
+// changes it would have made instead of making them.
+type dryRunExternal struct {
+	managed.ExternalClient
+	record event.Recorder
+	log    logging.Logger
+
+	// reason and prefix of the events and log lines reporting changes.
+	reason event.Reason
+	prefix string
+
+	// paused is true if changes are only paused. The external resources of
+	// managed resources that are deleted are then deleted once changes
+	// resume, rather than being left behind.
+	paused bool
+}
+
+// Observe reports the external resource as being in its desired state, after
+// reporting what would have been done to get it there. Making the reconciler
+// believe there is nothing to do keeps it from calling Create, Update, or
+// Delete, which would otherwise be retried over and over.
+func (e *dryRunExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+	switch {
+	case meta.WasDeleted(mg) && e.paused && o.ResourceExists:
+		e.report(mg, "delete", "")
+		return o, errors.New(e.prefix)
+	case meta.WasDeleted(mg):
+		if o.ResourceExists {
+			e.report(mg, "delete", "")
+		}
+		// The managed resource is deleted without touching the external
+		// resource.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	case !o.ResourceExists:
+		e.report(mg, "create", "")
+		mg.SetConditions(xpv1.Unavailable().WithMessage(e.prefix + ": the external resource would have been created"))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	case !o.ResourceUpToDate:
+		e.report(mg, "update", o.Diff)
+		o.ResourceUpToDate = true
+	}
+	return o, nil
+}
+
+// Create reports the creation it would have made.
+func (e *dryRunExternal) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	e.report(mg, "create", "")
+	return managed.ExternalCreation{}, nil
+}
+
+// Update reports the update it would have made.
+func (e *dryRunExternal) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	e.report(mg, "update", "")
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete reports the deletion it would have made.
+func (e *dryRunExternal) Delete(_ context.Context, mg resource.Managed) error {
+	e.report(mg, "delete", "")
+	return nil
+}
+
+func (e *dryRunExternal) report(mg resource.Managed, action, diff string) {
+	msg := fmt.Sprintf("%s: would %s the external resource", e.prefix, action)
+	e.log.Info(msg, "name", mg.GetName(), "external-name", meta.GetExternalName(mg), "diff", diff)
+	e.record.Event(mg, event.Normal(e.reason, msg))
+}
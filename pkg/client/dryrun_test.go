@@ -0,0 +1,377 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// A methodRecorder is a fake GitHub server that answers every request with an
+// empty JSON object and records the method and path of each.
+type methodRecorder struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *methodRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"data":{}}`))
+}
+
+func TestDryRunConnection(t *testing.T) {
+	rec := &methodRecorder{}
+	srv, ca := tlsServer(t, rec)
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("dry-run")
+	pc.Spec.BaseURL = pointer.String(srv.URL)
+	pc.Spec.DryRun = true
+	conn, err := newConnection(pc, []byte("token"), ca, "dry-run")
+	if err != nil {
+		t.Fatalf("newConnection(...): %v", err)
+	}
+
+	ctx := context.Background()
+	mutations := map[string]func() error{
+		"CreateTeam": func() error {
+			_, _, err := conn.rest.Teams.CreateTeam(ctx, "org", github.NewTeam{Name: "team"})
+			return err
+		},
+		"EditTeam": func() error {
+			_, _, err := conn.rest.Teams.EditTeamBySlug(ctx, "org", "team", github.NewTeam{Name: "team"}, false)
+			return err
+		},
+		"DeleteTeam": func() error {
+			_, err := conn.rest.Teams.DeleteTeamBySlug(ctx, "org", "team")
+			return err
+		},
+		"UpdateBranchProtection": func() error {
+			_, _, err := conn.rest.Repositories.UpdateBranchProtection(ctx, "org", "repo", "main", &github.ProtectionRequest{})
+			return err
+		},
+		"GraphQLMutation": func() error {
+			var m struct {
+				DeleteProjectV2 struct {
+					ClientMutationID string
+				} `graphql:"deleteProjectV2(input: $input)"`
+			}
+			return conn.graphql.Mutate(ctx, &m, githubv4.DeleteProjectV2Input{ProjectID: githubv4.ID("P_1")}, nil)
+		},
+	}
+	for name, mutate := range mutations {
+		if err := mutate(); err == nil || !strings.Contains(err.Error(), errDryRun) {
+			t.Errorf("%s: want a dry-run error, got %v", name, err)
+		}
+	}
+
+	// Reads are sent as usual, including GraphQL queries, which are POSTed.
+	if _, _, err := conn.rest.Teams.GetTeamBySlug(ctx, "org", "team"); err != nil {
+		t.Errorf("GetTeamBySlug(...): %v", err)
+	}
+	var q struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := conn.graphql.Query(ctx, &q, nil); err != nil {
+		t.Errorf("Query(...): %v", err)
+	}
+
+	want := []string{"GET /api/v3/orgs/org/teams/team", "POST /api/graphql"}
+	if diff := cmp.Diff(want, rec.requests); diff != "" {
+		t.Errorf("requests: -want, +got:\n%s", diff)
+	}
+}
+
+func TestDryRunExternal(t *testing.T) {
+	errUnexpected := errors.New("the wrapped external client must not mutate in dry-run mode")
+	mutating := managed.ExternalClientFns{
+		CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+			return managed.ExternalCreation{}, errUnexpected
+		},
+		UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+			return managed.ExternalUpdate{}, errUnexpected
+		},
+		DeleteFn: func(context.Context, resource.Managed) error {
+			return errUnexpected
+		},
+	}
+	observing := func(o managed.ExternalObservation) managed.ExternalClientFns {
+		fns := mutating
+		fns.ObserveFn = func(context.Context, resource.Managed) (managed.ExternalObservation, error) { return o, nil }
+		return fns
+	}
+	deleted := func() resource.Managed {
+		mg := &fake.Managed{}
+		meta.SetExternalName(mg, "team")
+		now := metav1.Now()
+		mg.SetDeletionTimestamp(&now)
+		return mg
+	}
+
+	cases := map[string]struct {
+		reason string
+		ext    managed.ExternalClientFns
+		paused bool
+		mg     resource.Managed
+		want   managed.ExternalObservation
+		err    bool
+	}{
+		"WouldCreate": {
+			reason: "A missing external resource should be reported as existing and up to date, so that it is not created.",
+			ext:    observing(managed.ExternalObservation{}),
+			mg:     &fake.Managed{},
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"WouldUpdate": {
+			reason: "An outdated external resource should be reported as up to date, so that it is not updated.",
+			ext:    observing(managed.ExternalObservation{ResourceExists: true, Diff: "-a +b"}),
+			mg:     &fake.Managed{},
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, Diff: "-a +b"},
+		},
+		"WouldDelete": {
+			reason: "The external resource of a deleted managed resource should be reported as gone, so that it is not deleted.",
+			ext:    observing(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}),
+			mg:     deleted(),
+			want:   managed.ExternalObservation{},
+		},
+		"PausedDelete": {
+			reason: "The deletion of a managed resource should wait while changes are paused.",
+			ext:    observing(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}),
+			paused: true,
+			mg:     deleted(),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			err:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &dryRunExternal{ExternalClient: tc.ext, record: event.NewNopRecorder(), log: logging.NewNopLogger(), reason: reasonDryRun, prefix: "dry run", paused: tc.paused}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if (err != nil) != tc.err {
+				t.Errorf("\n%s\nObserve(...): want error %t, got %v", tc.reason, tc.err, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if _, err := e.Create(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nCreate(...): %v", tc.reason, err)
+			}
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): %v", tc.reason, err)
+			}
+			if err := e.Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nDelete(...): %v", tc.reason, err)
+			}
+		})
+	}
+
+	mg := &fake.Managed{}
+	e := &dryRunExternal{ExternalClient: observing(managed.ExternalObservation{}), record: event.NewNopRecorder(), log: logging.NewNopLogger(), prefix: "dry run"}
+	if _, err := e.Observe(context.Background(), mg); err != nil {
+		t.Fatal(err)
+	}
+	if got := mg.GetCondition(xpv1.TypeReady).Reason; got != xpv1.ReasonUnavailable {
+		t.Errorf("Observe(...): want a managed resource that would be created to be unavailable, got reason %q", got)
+	}
+}
+
+func TestPausedUntil(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	pdt := time.FixedZone("PDT", -7*60*60)
+	until := time.Date(2026, time.October, 15, 9, 0, 0, 0, time.UTC)
+
+	// Timestamps read from the API server are decoded into the local zone,
+	// whatever zone they were written in.
+	decoded := &metav1.Time{}
+	if err := decoded.UnmarshalJSON([]byte(`"2026-10-15T11:00:00+02:00"`)); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		until  *metav1.Time
+		now    time.Time
+		paused bool
+	}{
+		"Unset": {
+			reason: "Changes should not be paused without pauseMutationsUntil.",
+			now:    until,
+		},
+		"JustBefore": {
+			reason: "Changes should be paused until the very end of the window.",
+			until:  &metav1.Time{Time: until},
+			now:    until.Add(-time.Nanosecond),
+			paused: true,
+		},
+		"AtEnd": {
+			reason: "Changes should resume at the time pauseMutationsUntil names.",
+			until:  &metav1.Time{Time: until},
+			now:    until,
+		},
+		"After": {
+			reason: "Changes should not be paused once the window ended.",
+			until:  &metav1.Time{Time: until},
+			now:    until.Add(time.Second),
+		},
+		"UntilInAnotherZone": {
+			reason: "The end of the window should be the same instant whatever zone it is written in.",
+			until:  &metav1.Time{Time: until.In(ist)},
+			now:    until.Add(-time.Second),
+			paused: true,
+		},
+		"UntilInAnotherZoneEnded": {
+			reason: "A window written in a zone ahead of UTC should not end later than the instant it names.",
+			until:  &metav1.Time{Time: until.In(ist)},
+			now:    until,
+		},
+		"NowInAnotherZone": {
+			reason: "The current time should be compared as an instant, not by its wall clock.",
+			until:  &metav1.Time{Time: until},
+			now:    until.In(pdt).Add(-time.Second),
+			paused: true,
+		},
+		"NowInAnotherZoneEnded": {
+			reason: "A clock behind UTC should not make a window that ended seem to be still open.",
+			until:  &metav1.Time{Time: until},
+			now:    until.In(pdt),
+		},
+		"Decoded": {
+			reason: "A decoded timestamp should end the window at the instant it was written as.",
+			until:  decoded,
+			now:    until.Add(-time.Second),
+			paused: true,
+		},
+		"DecodedEnded": {
+			reason: "A decoded timestamp should not end the window later because of its offset.",
+			until:  decoded,
+			now:    until,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{}
+			pc.Spec.PauseMutationsUntil = tc.until
+			got := pausedUntil(pc, tc.now)
+			if (got != nil) != tc.paused {
+				t.Errorf("\n%s\npausedUntil(%s, %s): want paused %t, got %v", tc.reason, tc.until, tc.now, tc.paused, got)
+			}
+			if got != nil && !got.Equal(tc.until) {
+				t.Errorf("\n%s\npausedUntil(...): want paused until %s, got %s", tc.reason, tc.until, got)
+			}
+		})
+	}
+}
+
+func TestDryRunConnecterPaused(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	// The window ends at 03:30 UTC, written as 09:00 in India.
+	future := time.Date(2099, time.January, 1, 9, 0, 0, 0, ist)
+	past := time.Now().Add(-time.Minute)
+
+	cases := map[string]struct {
+		reason string
+		until  time.Time
+		// paused is whether the managed resource was paused before.
+		paused bool
+		want   xpv1.Condition
+	}{
+		"Paused": {
+			reason: "A paused managed resource should name the end of the window in UTC, whatever zone it was written in.",
+			until:  future,
+			want:   common.MutationsPaused(fmt.Sprintf(errFmtPaused, "default", "2099-01-01T03:30:00Z")),
+		},
+		"Resumed": {
+			reason: "A managed resource that was paused should be marked as resumed once the window ended.",
+			until:  past,
+			paused: true,
+			want:   common.MutationsResumed(),
+		},
+		"NeverPaused": {
+			reason: "A managed resource that was never paused should not get the condition.",
+			until:  past,
+			want:   xpv1.Condition{Type: common.TypeMutationsPaused, Status: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				pc := obj.(*apisv1alpha1.ProviderConfig)
+				pc.SetName("default")
+				pc.Spec.PauseMutationsUntil = &metav1.Time{Time: tc.until}
+				return nil
+			}}
+			c := &dryRunConnecter{
+				ExternalConnecter: managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+					return managed.ExternalClientFns{}, nil
+				}),
+				kube:   kube,
+				record: event.NewNopRecorder(),
+				log:    logging.NewNopLogger(),
+			}
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+			if tc.paused {
+				mg.SetConditions(common.MutationsPaused("paused"))
+			}
+
+			ext, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\nConnect(...): %v", tc.reason, err)
+			}
+			if _, ok := ext.(*dryRunExternal); ok != (tc.until == future) {
+				t.Errorf("\n%s\nConnect(...): want an external client that only observes %t, got %T", tc.reason, tc.until == future, ext)
+			}
+			if diff := cmp.Diff(tc.want, mg.GetCondition(common.TypeMutationsPaused), test.EquateConditions(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
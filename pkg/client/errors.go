@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+)
+
+// An APIError is an error returned by the GitHub API, described by what was
+// being done when it was returned and the details GitHub gave for it.
+type APIError struct {
+	// StatusCode is the HTTP status code of the failed request.
+	StatusCode int
+
+	// RequestID is the ID GitHub assigned to the failed request. GitHub
+	// support asks for it when investigating a failure.
+	RequestID string
+
+	msg string
+	err error
+}
+
+func (e *APIError) Error() string { return e.msg }
+
+// Unwrap returns the error returned by the GitHub client.
+func (e *APIError) Unwrap() error { return e.err }
+
+// Cause returns the error returned by the GitHub client.
+func (e *APIError) Cause() error { return e.err }
+
+// WrapAPIError wraps the supplied error returned by the GitHub client with
+// the supplied message. Errors returned by the GitHub API are described by
+// their status, the validation errors GitHub gave for them, if any, and the
+// ID of their request. Other errors are wrapped as by errors.Wrap.
+func WrapAPIError(err error, msg string) error {
+	er := &github.ErrorResponse{}
+	if !errors.As(err, &er) || er.Response == nil {
+		return errors.Wrap(err, msg)
+	}
+
+	e := &APIError{StatusCode: er.Response.StatusCode, RequestID: er.Response.Header.Get("X-GitHub-Request-Id"), err: err}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s: %d %s", msg, e.StatusCode, er.Message)
+	for i, d := range er.Errors {
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		b.WriteString(sep + d.Error())
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(b, " (request ID %s)", e.RequestID)
+	}
+	e.msg = b.String()
+	return e
+}
+
+// IsNotFound reports whether the supplied error was returned by the GitHub
+// API because what the request concerns does not exist, or is not visible
+// with the credentials used.
+func IsNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// IsGone reports whether the supplied error was returned by the GitHub API
+// because what the request concerns was deleted, as GitHub reports for
+// deleted issues.
+func IsGone(err error) bool {
+	return statusCode(err) == http.StatusGone
+}
+
+// IsConflict reports whether the supplied error was returned by the GitHub
+// API because the request conflicts with the current state of what it
+// concerns.
+func IsConflict(err error) bool {
+	return statusCode(err) == http.StatusConflict
+}
+
+// IsUnprocessable reports whether the supplied error was returned by the
+// GitHub API because the request failed validation.
+func IsUnprocessable(err error) bool {
+	return statusCode(err) == http.StatusUnprocessableEntity
+}
+
+// IsForbidden reports whether the supplied error was returned by the GitHub
+// API because the credentials used lack permission for the request. Rate
+// limited requests are not considered forbidden.
+func IsForbidden(err error) bool {
+	return statusCode(err) == http.StatusForbidden && !IsRateLimit(err)
+}
+
+// IsGraphQLNotFound reports whether the supplied error was returned by the
+// GraphQL API because the requested node or object does not exist. The API
+// only distinguishes this case by the error message.
+func IsGraphQLNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Could not resolve to")
+}
+
+// statusCode returns the HTTP status code of the GitHub API response the
+// supplied error was returned for, or zero if it was not returned for one.
+func statusCode(err error) int {
+	er := &github.ErrorResponse{}
+	if errors.As(err, &er) && er.Response != nil {
+		return er.Response.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+)
+
+// errorResponse returns an error like the GitHub client returns for a
+// response with the supplied status, request ID, and validation errors.
+func errorResponse(status int, id, msg string, errs ...github.Error) error {
+	h := http.Header{}
+	if id != "" {
+		h.Set("X-GitHub-Request-Id", id)
+	}
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Header: h, Request: &http.Request{Method: http.MethodPost, URL: &url.URL{}}},
+		Message:  msg,
+		Errors:   errs,
+	}
+}
+
+func TestWrapAPIError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		msg       string
+		status    int
+		requestID string
+	}
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"NotAnAPIError": {
+			reason: "Errors not returned by the GitHub API should be wrapped as they are.",
+			err:    errBoom,
+			want:   want{msg: "cannot create team: boom"},
+		},
+		"NoDetails": {
+			reason: "The status and message of an API error should be kept.",
+			err:    errorResponse(http.StatusNotFound, "", "Not Found"),
+			want:   want{msg: "cannot create team: 404 Not Found", status: http.StatusNotFound},
+		},
+		"RequestID": {
+			reason: "The ID of the failed request should be kept, since GitHub support asks for it.",
+			err:    errorResponse(http.StatusBadGateway, "ABCD:1234", "Server Error"),
+			want:   want{msg: "cannot create team: 502 Server Error (request ID ABCD:1234)", status: http.StatusBadGateway, requestID: "ABCD:1234"},
+		},
+		"ValidationErrors": {
+			reason: "Every validation error of a 422 should be kept.",
+			err: errorResponse(http.StatusUnprocessableEntity, "ABCD:1234", "Validation Failed",
+				github.Error{Resource: "Team", Field: "name", Code: "already_exists"},
+				github.Error{Resource: "Team", Field: "privacy", Code: "invalid"}),
+			want: want{
+				msg:       "cannot create team: 422 Validation Failed: already_exists error caused by name field on Team resource, invalid error caused by privacy field on Team resource (request ID ABCD:1234)",
+				status:    http.StatusUnprocessableEntity,
+				requestID: "ABCD:1234",
+			},
+		},
+		"Wrapped": {
+			reason: "API errors should be found even if they were wrapped.",
+			err:    errors.Wrap(errorResponse(http.StatusForbidden, "", "Must have admin rights"), "cannot get team"),
+			want:   want{msg: "cannot create team: 403 Must have admin rights", status: http.StatusForbidden},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := WrapAPIError(tc.err, "cannot create team")
+			if !errors.Is(err, tc.err) {
+				t.Errorf("\n%s\nWrapAPIError(...): want the wrapped error to be %v", tc.reason, tc.err)
+			}
+			got := want{msg: err.Error()}
+			if e := (&APIError{}); errors.As(err, &e) {
+				got.status, got.requestID = e.StatusCode, e.RequestID
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nWrapAPIError(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWrapAPIErrorResponse(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"Team","field":"name","code":"already_exists"}]}`))
+	}))
+	defer s.Close()
+	c := github.NewClient(s.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+
+	_, _, err := c.Teams.CreateTeam(context.Background(), "acme", github.NewTeam{Name: "platform"})
+	want := "cannot create team: 422 Validation Failed: already_exists error caused by name field on Team resource (request ID ABCD:1234)"
+	if diff := cmp.Diff(want, WrapAPIError(err, "cannot create team").Error()); diff != "" {
+		t.Errorf("\nThe details GitHub responds with should be kept.\nWrapAPIError(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsStatus(t *testing.T) {
+	type want struct {
+		notFound, gone, conflict, unprocessable, forbidden, rateLimit, secondary bool
+	}
+	cases := map[string]struct {
+		err  error
+		want want
+	}{
+		"Nil":           {err: nil},
+		"NotAnAPIError": {err: errors.New("boom")},
+		"NotFound":      {err: errorResponse(http.StatusNotFound, "", ""), want: want{notFound: true}},
+		"Gone":          {err: errorResponse(http.StatusGone, "", ""), want: want{gone: true}},
+		"Conflict":      {err: errorResponse(http.StatusConflict, "", ""), want: want{conflict: true}},
+		"Unprocessable": {err: errorResponse(http.StatusUnprocessableEntity, "", ""), want: want{unprocessable: true}},
+		"Forbidden":     {err: errorResponse(http.StatusForbidden, "", ""), want: want{forbidden: true}},
+		"WrappedNotFound": {
+			err:  WrapAPIError(errorResponse(http.StatusNotFound, "", ""), "cannot get team"),
+			want: want{notFound: true},
+		},
+		"RateLimit": {
+			err:  errors.Wrap(&RateLimitError{}, "cannot get team"),
+			want: want{rateLimit: true},
+		},
+		"SecondaryRateLimit": {
+			err:  &RateLimitError{Secondary: true},
+			want: want{rateLimit: true, secondary: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{
+				notFound:      IsNotFound(tc.err),
+				gone:          IsGone(tc.err),
+				conflict:      IsConflict(tc.err),
+				unprocessable: IsUnprocessable(tc.err),
+				forbidden:     IsForbidden(tc.err),
+				rateLimit:     IsRateLimit(tc.err),
+				secondary:     IsSecondaryRateLimit(tc.err),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("Is...(%v): -want, +got:\n%s", tc.err, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultETagCacheSize is the default number of responses kept by the ETag
+// cache.
+const DefaultETagCacheSize = 1000
+
+var etagCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "github_etag_cache_requests_total",
+	Help: "Number of cacheable GitHub API requests, by whether a cached response was found (hit or miss) and whether GitHub reported it unchanged (not_modified).",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(etagCacheRequests)
+}
+
+var etags = newETagCache(DefaultETagCacheSize)
+
+// SetETagCacheSize sets the number of responses kept by the ETag cache shared
+// by all clients. A size of zero disables the cache.
+func SetETagCacheSize(size int) {
+	etags.resize(size)
+}
+
+// A cachedResponse is a response GitHub returned with an ETag.
+type cachedResponse struct {
+	key    string
+	etag   string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// An etagCache is a bounded LRU cache of responses.
+type etagCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newETagCache(size int) *etagCache {
+	return &etagCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *etagCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cachedResponse), true
+}
+
+func (c *etagCache) add(r *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[r.key]; ok {
+		e.Value = r
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[r.key] = c.order.PushFront(r)
+	c.evict()
+}
+
+func (c *etagCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+func (c *etagCache) resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	c.evict()
+}
+
+// evict drops the least recently used responses until the cache fits its
+// size. It must be called while holding the lock.
+func (c *etagCache) evict() {
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cachedResponse).key)
+	}
+}
+
+// An etagTransport makes GET requests conditional on the ETag of a cached
+// response and serves the cached response if GitHub reports it unchanged.
+// Such requests do not count against the rate limit.
+type etagTransport struct {
+	base  http.RoundTripper
+	cache *etagCache
+
+	// scope separates the responses of clients that use different
+	// credentials, and thus may see different data.
+	scope string
+}
+
+func newETagTransport(base http.RoundTripper, c *etagCache, scope string) *etagTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &etagTransport{base: base, cache: c, scope: scope}
+}
+
+// RoundTrip sends the supplied request, serving it from the cache if
+// possible. Responses to mutating requests are never cached.
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := t.scope + "\x00" + req.Header.Get("Accept") + "\x00" + req.URL.String()
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		// A mutation may change what a later GET returns.
+		t.cache.remove(key)
+		return t.base.RoundTrip(req)
+	}
+
+	cached, ok := t.cache.get(key)
+	if ok {
+		etagCacheRequests.WithLabelValues("hit").Inc()
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	} else {
+		etagCacheRequests.WithLabelValues("miss").Inc()
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && res.StatusCode == http.StatusNotModified {
+		etagCacheRequests.WithLabelValues("not_modified").Inc()
+		_ = res.Body.Close()
+		return cached.response(req, res.Header), nil
+	}
+
+	etag := res.Header.Get("ETag")
+	if res.StatusCode != http.StatusOK || etag == "" {
+		t.cache.remove(key)
+		return res, nil
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.add(&cachedResponse{key: key, etag: etag, status: res.StatusCode, header: res.Header.Clone(), body: body})
+	return res, nil
+}
+
+// response returns a response to the supplied request with the cached body.
+// Headers describing the current rate limit are taken from the supplied
+// response GitHub returned for a 304 Not Modified.
+func (r *cachedResponse) response(req *http.Request, current http.Header) *http.Response {
+	h := r.header.Clone()
+	for _, k := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-RateLimit-Used", "X-RateLimit-Resource", "X-OAuth-Scopes", "Date"} {
+		if v := current.Get(k); v != "" {
+			h.Set(k, v)
+		}
+	}
+	return &http.Response{
+		Status:        http.StatusText(r.status),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
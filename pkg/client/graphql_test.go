@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// tlsServer returns a TLS server that serves the supplied handler, and the
+// PEM encoded certificate to trust it with.
+func tlsServer(t *testing.T, h http.Handler) (*httptest.Server, []byte) {
+	t.Helper()
+	srv := httptest.NewTLSServer(h)
+	t.Cleanup(srv.Close)
+	return srv, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+func TestGraphQLURL(t *testing.T) {
+	cases := map[string]struct {
+		base string
+		want string
+	}{
+		"GitHubDotCom": {
+			base: "https://api.github.com/",
+			want: "https://api.github.com/graphql",
+		},
+		"EnterpriseServer": {
+			base: "https://ghe.example.com/api/v3/",
+			want: "https://ghe.example.com/api/graphql",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(tc.base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, graphQLURL(u)); diff != "" {
+				t.Errorf("graphQLURL(%q): -want, +got:\n%s", tc.base, diff)
+			}
+		})
+	}
+}
+
+// A graphQLServer is a fake Enterprise Server that answers GraphQL node
+// queries, and records the requests it receives.
+type graphQLServer struct {
+	mu       sync.Mutex
+	requests []string
+	nodes    map[string]string
+}
+
+func (s *graphQLServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/api/v3/user":
+		_, _ = w.Write([]byte(`{"login":"fake"}`))
+	case "/api/graphql":
+		var q struct {
+			Variables struct {
+				ID string `json:"id"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, ok := s.nodes[q.Variables.ID]
+		if !ok {
+			_, _ = w.Write([]byte(`{"data":{"node":null},"errors":[{"message":"Could not resolve to a node with the global id of '` + q.Variables.ID + `'"}]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"node": map[string]string{"id": id}}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestGraphQLConnection(t *testing.T) {
+	legacy := base64.StdEncoding.EncodeToString([]byte("010:Repository42"))
+	gs := &graphQLServer{nodes: map[string]string{legacy: "R_kgDOAAAAKg"}}
+	srv, ca := tlsServer(t, gs)
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("graphql")
+	pc.Spec.BaseURL = pointer.String(srv.URL)
+	conn, err := newConnection(pc, []byte("secret-token"), ca, "graphql")
+	if err != nil {
+		t.Fatalf("newConnection(...): %v", err)
+	}
+
+	if _, _, err := conn.rest.Users.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Users.Get(...): %v", err)
+	}
+	id, err := NodeID(context.Background(), conn.graphql, "Repository", 42)
+	if err != nil {
+		t.Fatalf("NodeID(...): %v", err)
+	}
+	if diff := cmp.Diff("R_kgDOAAAAKg", id); diff != "" {
+		t.Errorf("NodeID(...): -want, +got:\n%s", diff)
+	}
+	_, err = NodeID(context.Background(), conn.graphql, "Repository", 43)
+	if !IsGraphQLNotFound(err) {
+		t.Errorf("NodeID(...): want a GraphQL not found error for an unknown node, got %v", err)
+	}
+
+	// Both clients share the transport, and with it the credentials, of the
+	// connection, and GraphQL requests use the GraphQL endpoint of the
+	// Enterprise Server.
+	want := []string{
+		"GET /api/v3/user Bearer secret-token",
+		"POST /api/graphql Bearer secret-token",
+		"POST /api/graphql Bearer secret-token",
+	}
+	if diff := cmp.Diff(want, gs.requests); diff != "" {
+		t.Errorf("requests: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "github_api_requests_total",
+		Help: "Number of requests sent to the GitHub API, by ProviderConfig, method, endpoint group, and status code.",
+	}, []string{"provider_config", "method", "endpoint", "code"})
+
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_rate_limit_remaining",
+		Help: "Number of requests remaining in the current GitHub API rate limit window, by ProviderConfig and rate limit resource.",
+	}, []string{"provider_config", "resource"})
+
+	rateLimitReset = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "github_rate_limit_reset_seconds",
+		Help: "Seconds until the current GitHub API rate limit window resets, by ProviderConfig and rate limit resource.",
+	}, []string{"provider_config", "resource"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, rateLimitRemaining, rateLimitReset)
+}
+
+var (
+	rateLimitResourcesMu sync.Mutex
+	rateLimitResources   = map[string]map[string]bool{}
+)
+
+// observeRateLimitResource records that rate limit gauges exist for the
+// supplied ProviderConfig and rate limit resource.
+func observeRateLimitResource(pc, resource string) {
+	rateLimitResourcesMu.Lock()
+	defer rateLimitResourcesMu.Unlock()
+	if rateLimitResources[pc] == nil {
+		rateLimitResources[pc] = map[string]bool{}
+	}
+	rateLimitResources[pc][resource] = true
+}
+
+// forgetMetrics drops the rate limit gauges of the supplied ProviderConfig.
+// Request counters are kept, as they describe requests that were made.
+func forgetMetrics(pc string) {
+	rateLimitResourcesMu.Lock()
+	defer rateLimitResourcesMu.Unlock()
+	for resource := range rateLimitResources[pc] {
+		rateLimitRemaining.DeleteLabelValues(pc, resource)
+		rateLimitReset.DeleteLabelValues(pc, resource)
+	}
+	delete(rateLimitResources, pc)
+	budgetConsumed.DeleteLabelValues(pc)
+	budgetRemaining.DeleteLabelValues(pc)
+}
+
+// A metricsTransport records metrics about the requests of a ProviderConfig.
+type metricsTransport struct {
+	base http.RoundTripper
+	pc   string
+}
+
+// RoundTrip sends the supplied request and records its outcome.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	code := "error"
+	if res != nil {
+		code = strconv.Itoa(res.StatusCode)
+	}
+	apiRequests.WithLabelValues(t.pc, req.Method, endpointGroup(req.URL.Path), code).Inc()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := res.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		observeRateLimitResource(t.pc, resource)
+		rateLimitRemaining.WithLabelValues(t.pc, resource).Set(float64(remaining))
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimitReset.WithLabelValues(t.pc, resource).Set(time.Until(time.Unix(reset, 0)).Seconds())
+	}
+	return res, nil
+}
+
+// endpointGroup returns a group of the API endpoint with the supplied path
+// that is coarse enough to be used as a metric label, for example orgs/teams
+// for /orgs/example/teams/example/members.
+func endpointGroup(path string) string {
+	// The paths of a GitHub Enterprise Server are prefixed.
+	path = strings.TrimPrefix(path, "/api/v3")
+	path = strings.TrimPrefix(path, "/api")
+	s := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(s) >= 4 && s[0] == "repos":
+		return "repos/" + s[3]
+	case len(s) >= 3 && (s[0] == "orgs" || s[0] == "enterprises" || s[0] == "users"):
+		return s[0] + "/" + s[2]
+	default:
+		return s[0]
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// mutationInterval is the minimum time between two mutating requests using
+// the same ProviderConfig. GitHub recommends pausing for at least a second
+// between mutating requests to avoid secondary rate limits.
+const mutationInterval = time.Second
+
+var mutationQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "github_mutation_queue_depth",
+	Help: "Number of mutating GitHub API requests waiting for their turn, by ProviderConfig.",
+}, []string{"provider_config"})
+
+func init() {
+	metrics.Registry.MustRegister(mutationQueueDepth)
+}
+
+var (
+	mutationLimitersMu sync.Mutex
+	mutationLimiters   = map[string]*mutationLimiter{}
+)
+
+// mutationLimiterFor returns the mutationLimiter shared by all clients using
+// the supplied ProviderConfig.
+func mutationLimiterFor(pc string) *mutationLimiter {
+	mutationLimitersMu.Lock()
+	defer mutationLimitersMu.Unlock()
+	l, ok := mutationLimiters[pc]
+	if !ok {
+		l = &mutationLimiter{
+			slot:   make(chan struct{}, 1),
+			queued: mutationQueueDepth.WithLabelValues(pc),
+		}
+		mutationLimiters[pc] = l
+	}
+	return l
+}
+
+// forgetMutationLimiter drops the mutationLimiter of the supplied
+// ProviderConfig.
+func forgetMutationLimiter(pc string) {
+	mutationLimitersMu.Lock()
+	defer mutationLimitersMu.Unlock()
+	delete(mutationLimiters, pc)
+	mutationQueueDepth.DeleteLabelValues(pc)
+}
+
+// A mutationLimiter serializes mutating requests and paces them
+// mutationInterval apart.
+type mutationLimiter struct {
+	slot   chan struct{}
+	queued prometheus.Gauge
+
+	// last is only accessed while holding the slot.
+	last time.Time
+}
+
+// acquire blocks until it is the caller's turn to send a mutating request.
+// The caller must call release once the request completed.
+func (l *mutationLimiter) acquire(ctx context.Context) error {
+	l.queued.Inc()
+	defer l.queued.Dec()
+
+	select {
+	case l.slot <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	d := time.Until(l.last.Add(mutationInterval))
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.abandon()
+		return ctx.Err()
+	}
+}
+
+func (l *mutationLimiter) release() {
+	l.last = time.Now()
+	<-l.slot
+}
+
+// abandon gives up the slot without a request having been sent, so the next
+// request need not wait any longer than it would have otherwise.
+func (l *mutationLimiter) abandon() {
+	<-l.slot
+}
+
+// A mutationTransport sends mutating requests through a mutationLimiter.
+type mutationTransport struct {
+	base    http.RoundTripper
+	limiter *mutationLimiter
+}
+
+func newMutationTransport(base http.RoundTripper, l *mutationLimiter) *mutationTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &mutationTransport{base: base, limiter: l}
+}
+
+// RoundTrip sends the supplied request, waiting for its turn if it mutates.
+func (t *mutationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mutates, err := isMutation(req)
+	if err != nil {
+		return nil, err
+	}
+	if !mutates {
+		return t.base.RoundTrip(req)
+	}
+	if err := t.limiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	defer t.limiter.release()
+	return t.base.RoundTrip(req)
+}
+
+// isMutation reports whether the supplied request mutates. All GraphQL
+// requests are POSTed, so their body tells queries from mutations. The body
+// is restored so it can still be sent.
+func isMutation(req *http.Request) (bool, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+	default:
+		return false, nil
+	}
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/graphql") || req.Body == nil {
+		return true, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return false, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	q := struct {
+		Query string `json:"query"`
+	}{}
+	if err := json.Unmarshal(body, &q); err != nil {
+		// Pace requests we cannot tell apart, to be safe.
+		return true, nil //nolint:nilerr
+	}
+	return strings.HasPrefix(strings.TrimSpace(q.Query), "mutation"), nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAcquireCancelled(t *testing.T) {
+	l := &mutationLimiter{slot: make(chan struct{}, 1), queued: prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"})}
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire(...): %v", err)
+	}
+	l.release()
+	last := l.last
+
+	// The context is done before the interval since the last request passed.
+	ctx, cancel := context.WithTimeout(context.Background(), mutationInterval/10)
+	defer cancel()
+	if err := l.acquire(ctx); err == nil {
+		t.Fatal("acquire(...): want an error when the context is done while waiting")
+	}
+	if !l.last.Equal(last) {
+		t.Errorf("acquire(...): want the time of the last request to stay %v when no request was sent, got %v", last, l.last)
+	}
+
+	// The slot was given up, and the next request is paced from the last
+	// one that was sent rather than from the cancelled one.
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire(...): %v", err)
+	}
+	if got := time.Since(last); got > mutationInterval+mutationInterval/2 {
+		t.Errorf("acquire(...): want to wait until %v after the last request, waited until %v", mutationInterval, got)
+	}
+	l.release()
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+const errResolveNodeID = "cannot resolve node ID"
+
+// NodeID returns the GraphQL node ID of the object of the supplied GraphQL
+// type, e.g. Repository or Team, with the supplied REST API ID. It is for the
+// few REST API responses that do not include a node_id.
+//
+// GitHub still resolves the legacy node IDs derived from the type and the
+// REST ID, and reports the node ID it currently uses for those objects.
+func NodeID(ctx context.Context, c *githubv4.Client, typename string, id int64) (string, error) {
+	legacy := fmt.Sprintf("0%d:%s%d", len(typename), typename, id)
+
+	var q struct {
+		Node struct {
+			ID githubv4.ID
+		} `graphql:"node(id: $id)"`
+	}
+	err := c.Query(ctx, &q, map[string]interface{}{
+		"id": githubv4.ID(base64.StdEncoding.EncodeToString([]byte(legacy))),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errResolveNodeID)
+	}
+	s, _ := q.Node.ID.(string)
+	if s == "" {
+		return "", errors.Errorf("%s: no %s with ID %d", errResolveNodeID, typename, id)
+	}
+	return s, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultPageSize is the number of items ListAll requests per page,
+	// which is the most GitHub allows.
+	DefaultPageSize = 100
+
+	// DefaultMaxPages is the number of pages after which ListAll gives up,
+	// so that a list that never ends cannot use up the rate limit.
+	DefaultMaxPages = 100
+
+	errFmtTooManyPages = "list has more than %d pages"
+)
+
+// A ListFunc lists one page of items of a GitHub API list endpoint.
+type ListFunc[T any] func(opts *github.ListOptions) ([]T, *github.Response, error)
+
+// A ListOption configures ListAll.
+type ListOption func(o *listOptions)
+
+type listOptions struct {
+	pageSize int
+	maxPages int
+}
+
+// WithPageSize sets the number of items requested per page.
+func WithPageSize(n int) ListOption {
+	return func(o *listOptions) { o.pageSize = n }
+}
+
+// WithMaxPages sets the number of pages after which ListAll returns an
+// error.
+func WithMaxPages(n int) ListOption {
+	return func(o *listOptions) { o.maxPages = n }
+}
+
+// ListAll returns the items of all pages the supplied function lists. It
+// stops when the supplied context is done.
+func ListAll[T any](ctx context.Context, list ListFunc[T], o ...ListOption) ([]T, error) {
+	lo := &listOptions{pageSize: DefaultPageSize, maxPages: DefaultMaxPages}
+	for _, fn := range o {
+		fn(lo)
+	}
+
+	var all []T
+	opts := &github.ListOptions{PerPage: lo.pageSize}
+	for pages := 0; ; pages++ {
+		if pages == lo.maxPages {
+			return nil, errors.Errorf(errFmtTooManyPages, lo.maxPages)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, res, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if res == nil || res.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+func TestListAll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type want struct {
+		teams int
+		pages int
+		err   error
+		// status is the status of the API error returned, if any.
+		status int
+	}
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		teams  int
+		o      []ListOption
+		// before is called before each page is listed, with the number of
+		// pages listed so far.
+		before func(s *ghserver.Server, pages int)
+		want   want
+	}{
+		"Empty": {
+			reason: "Listing nothing should take a single request.",
+			want:   want{pages: 1},
+		},
+		"SinglePage": {
+			reason: "A list that fits into one page should take a single request.",
+			teams:  DefaultPageSize,
+			want:   want{teams: DefaultPageSize, pages: 1},
+		},
+		"MultiplePages": {
+			reason: "The items of every page should be returned.",
+			teams:  250,
+			want:   want{teams: 250, pages: 3},
+		},
+		"PageSize": {
+			reason: "The supplied page size should be requested.",
+			teams:  250,
+			o:      []ListOption{WithPageSize(50)},
+			want:   want{teams: 250, pages: 5},
+		},
+		"TooManyPages": {
+			reason: "Listing should stop with an error once the supplied number of pages was listed.",
+			teams:  250,
+			o:      []ListOption{WithMaxPages(2)},
+			want:   want{pages: 2, err: errors.Errorf(errFmtTooManyPages, 2)},
+		},
+		"PageError": {
+			reason: "An error listing any page should be returned.",
+			teams:  250,
+			before: func(s *ghserver.Server, pages int) {
+				if pages == 1 {
+					s.Fail(http.MethodGet, "/orgs/acme/teams", http.StatusBadGateway, 1)
+				}
+			},
+			want: want{pages: 2, status: http.StatusBadGateway},
+		},
+		"ContextDone": {
+			reason: "Nothing should be listed once the context is done.",
+			ctx:    ctx,
+			teams:  250,
+			want:   want{err: context.Canceled},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			for i := 0; i < tc.teams; i++ {
+				s.AddTeam("acme", fmt.Sprintf("team-%d", i))
+			}
+			c := s.GitHubClient()
+			if tc.ctx == nil {
+				tc.ctx = context.Background()
+			}
+
+			pages := 0
+			teams, err := ListAll(tc.ctx, func(opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+				if tc.before != nil {
+					tc.before(s, pages)
+				}
+				pages++
+				return c.Teams.ListTeams(tc.ctx, "acme", opts)
+			}, tc.o...)
+
+			got := want{teams: len(teams), pages: pages, err: err, status: statusCode(err)}
+			if got.status != 0 {
+				// API errors include the URL of the test server.
+				got.err = nil
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nListAll(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			for i, team := range teams {
+				if want := fmt.Sprintf("team-%d", i); team.GetName() != want {
+					t.Fatalf("\n%s\nListAll(...): want team %d to be %s, got %s", tc.reason, i, want, team.GetName())
+				}
+			}
+		})
+	}
+}
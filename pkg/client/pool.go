@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// parseTokens returns the tokens of the supplied credentials, one per line.
+func parseTokens(creds []byte) []string {
+	var tokens []string
+	for _, t := range strings.Split(string(creds), "\n") {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// A pooledToken is a token of a tokenPoolTransport, its remaining quota, and
+// its rate limit, which is independent of the rate limits of the other tokens
+// of the pool.
+type pooledToken struct {
+	token string
+	limit *rateLimitTransport
+
+	mu sync.Mutex
+	// remaining is negative while the quota is unknown.
+	remaining int
+}
+
+// A tokenPoolTransport authenticates requests with one of several tokens. Read
+// requests use the token with the most remaining quota whose rate limit is
+// not exhausted. Mutating requests always use the first token, so that
+// changes are attributed to a single user in the audit log.
+type tokenPoolTransport struct {
+	tokens []*pooledToken
+}
+
+func newTokenPoolClient(tokens []string, base http.RoundTripper) *http.Client {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &tokenPoolTransport{}
+	for _, tk := range tokens {
+		t.tokens = append(t.tokens, &pooledToken{token: tk, limit: newRateLimitTransport(base), remaining: -1})
+	}
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip sends the supplied request authenticated with a token of the pool.
+func (t *tokenPoolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mutates, err := isMutation(req)
+	if err != nil {
+		return nil, err
+	}
+	tk := t.tokens[0]
+	if !mutates {
+		tk = t.healthiest()
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tk.token)
+	res, err := tk.limit.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		tk.mu.Lock()
+		tk.remaining = remaining
+		tk.mu.Unlock()
+	}
+	return res, nil
+}
+
+// healthiest returns the token with the most remaining quota whose rate limit
+// is not exhausted. Tokens whose quota is unknown are preferred, so that all
+// tokens are used. If the rate limits of all tokens are exhausted it returns
+// the token whose rate limit resets first.
+func (t *tokenPoolTransport) healthiest() *pooledToken {
+	var best, soonest *pooledToken
+	bestRemaining := 0
+	var soonestReset time.Time
+	for _, tk := range t.tokens {
+		if reset, exhausted := tk.limit.exhausts(); exhausted {
+			if soonest == nil || reset.Before(soonestReset) {
+				soonest, soonestReset = tk, reset
+			}
+			continue
+		}
+		tk.mu.Lock()
+		r := tk.remaining
+		tk.mu.Unlock()
+		if r < 0 {
+			return tk
+		}
+		if best == nil || r > bestRemaining {
+			best, bestRemaining = tk, r
+		}
+	}
+	if best == nil {
+		return soonest
+	}
+	return best
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTokens(t *testing.T) {
+	got := parseTokens([]byte("  a \n\nb\r\n c\n"))
+	if diff := cmp.Diff([]string{"a", "b", "c"}, got); diff != "" {
+		t.Errorf("parseTokens(...): -want, +got:\n%s", diff)
+	}
+}
+
+// quotaServer returns a transport that answers requests with the remaining
+// quota it tracks for the token they are authenticated with, and records
+// which token each request used.
+func quotaServer(quota map[string]int, reset time.Time, used *[]string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		tk := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		*used = append(*used, tk)
+		if quota[tk] > 0 {
+			quota[tk]--
+		}
+		h := http.Header{}
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(quota[tk]))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		return &http.Response{StatusCode: http.StatusOK, Header: h, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+	})
+}
+
+func TestTokenPoolTransport(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+
+	type request struct {
+		method string
+		err    bool
+	}
+	get := request{method: http.MethodGet}
+	cases := map[string]struct {
+		reason   string
+		quota    map[string]int
+		requests []request
+		want     []string
+	}{
+		"SpreadReads": {
+			reason:   "Reads should try every token once, then use the token with the most remaining quota.",
+			quota:    map[string]int{"a": 10, "b": 50, "c": 20},
+			requests: []request{get, get, get, get, get},
+			want:     []string{"a", "b", "c", "b", "b"},
+		},
+		"MutationsUseFirstToken": {
+			reason:   "Mutations should always use the first token, whatever its quota.",
+			quota:    map[string]int{"a": 10, "b": 50, "c": 20},
+			requests: []request{get, get, get, {method: http.MethodPatch}, {method: http.MethodPost}},
+			want:     []string{"a", "b", "c", "a", "a"},
+		},
+		"SkipExhaustedToken": {
+			reason:   "An exhausted token should not block reads that other tokens can serve.",
+			quota:    map[string]int{"a": 1, "b": 2, "c": 1},
+			requests: []request{get, get, get, get},
+			want:     []string{"a", "b", "c", "b"},
+		},
+		"AllExhausted": {
+			reason:   "Reads should fail with a rate limit error once every token is exhausted.",
+			quota:    map[string]int{"a": 1, "b": 1, "c": 1},
+			requests: []request{get, get, get, {method: http.MethodGet, err: true}},
+			want:     []string{"a", "b", "c"},
+		},
+		"ExhaustedMutationToken": {
+			reason:   "Mutations should fail with a rate limit error once the first token is exhausted, even if others are not.",
+			quota:    map[string]int{"a": 1, "b": 10, "c": 10},
+			requests: []request{{method: http.MethodPost}, {method: http.MethodPost, err: true}, get},
+			want:     []string{"a", "b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var used []string
+			c := newTokenPoolClient([]string{"a", "b", "c"}, quotaServer(tc.quota, reset, &used))
+			for i, r := range tc.requests {
+				req, _ := http.NewRequest(r.method, "https://api.github.com/repos/o/r", nil)
+				res, err := c.Transport.RoundTrip(req)
+				if r.err {
+					if !IsRateLimit(err) {
+						t.Errorf("\n%s\nrequest %d: want a rate limit error, got %v", tc.reason, i, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("\n%s\nrequest %d: %v", tc.reason, i, err)
+				}
+				_ = res.Body.Close()
+			}
+			if diff := cmp.Diff(tc.want, used); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want tokens, +got tokens:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// maxRateLimitWait is the longest a request waits for an exhausted rate limit
+// to reset before it fails with a RateLimitError.
+const maxRateLimitWait = 30 * time.Second
+
+// secondaryRateLimitWait is how long to wait after hitting a secondary rate
+// limit that did not specify when to retry. GitHub asks for at least a minute.
+const secondaryRateLimitWait = time.Minute
+
+// maxErrorBodySize is the largest error response body inspected to detect
+// secondary rate limits.
+const maxErrorBodySize = 64 << 10
+
+// A RateLimitError is returned for requests that cannot be sent because a
+// GitHub rate limit is exhausted.
+type RateLimitError struct {
+	// Reset is the time the rate limit resets.
+	Reset time.Time
+
+	// Secondary is true if a secondary rate limit was hit. GitHub imposes
+	// these on, for example, many concurrent mutating requests.
+	Secondary bool
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Secondary {
+		return fmt.Sprintf("GitHub API secondary rate limit exceeded until %s", e.Reset.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("GitHub API rate limit exceeded until %s", e.Reset.Format(time.RFC3339))
+}
+
+// IsRateLimit reports whether the supplied error was returned because a
+// GitHub rate limit is exhausted.
+func IsRateLimit(err error) bool {
+	rl := &RateLimitError{}
+	return errors.As(err, &rl)
+}
+
+// IsSecondaryRateLimit reports whether the supplied error was returned
+// because a GitHub secondary rate limit was hit.
+func IsSecondaryRateLimit(err error) bool {
+	rl := &RateLimitError{}
+	return errors.As(err, &rl) && rl.Secondary
+}
+
+// A rateLimitTransport tracks the primary rate limit GitHub reports for its
+// requests. Requests sent while the limit is exhausted wait for the reset if
+// it is near and fail with a RateLimitError otherwise.
+type rateLimitTransport struct {
+	base http.RoundTripper
+
+	mu        sync.Mutex
+	exhausted bool
+	reset     time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{base: base}
+}
+
+// RoundTrip sends the supplied request unless the rate limit is exhausted.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := t.wait(ctx); err != nil {
+		return nil, recordRateLimit(ctx, err)
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.observe(res); err != nil {
+		_ = res.Body.Close()
+		return nil, recordRateLimit(ctx, err)
+	}
+	return res, nil
+}
+
+// wait blocks until the rate limit resets if it is exhausted and resets
+// within maxRateLimitWait.
+func (t *rateLimitTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	exhausted, reset := t.exhausted, t.reset
+	t.mu.Unlock()
+
+	d := time.Until(reset)
+	if !exhausted || d <= 0 {
+		return nil
+	}
+	if d > maxRateLimitWait {
+		return &RateLimitError{Reset: reset}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// exhausts returns when the rate limit resets, and whether it is exhausted
+// until then.
+func (t *rateLimitTransport) exhausts() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.reset, t.exhausted && time.Until(t.reset) > 0
+}
+
+// observe records the rate limit reported by the supplied response and
+// returns a RateLimitError if the request was refused because of it.
+func (t *rateLimitTransport) observe(res *http.Response) error {
+	remaining, rerr := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	reset, serr := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if rerr == nil && serr == nil {
+		t.mu.Lock()
+		t.exhausted = remaining == 0
+		t.reset = time.Unix(reset, 0)
+		t.mu.Unlock()
+	}
+
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	secondary := isSecondaryRateLimit(res)
+	if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		return &RateLimitError{Reset: time.Now().Add(time.Duration(s) * time.Second), Secondary: secondary}
+	}
+	if rerr == nil && serr == nil && remaining == 0 {
+		return &RateLimitError{Reset: time.Unix(reset, 0)}
+	}
+	if secondary {
+		return &RateLimitError{Reset: time.Now().Add(secondaryRateLimitWait), Secondary: true}
+	}
+	// Any other refusal is a permission problem.
+	return nil
+}
+
+// isSecondaryRateLimit reports whether the supplied response refuses a
+// request because of a secondary rate limit. GitHub only distinguishes these
+// by the error message. The body is restored so it can still be decoded.
+func isSecondaryRateLimit(res *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && bytes.Contains(body, []byte("secondary rate limit"))
+}
+
+type rateLimitKey struct{}
+
+// A rateLimitRecord holds the latest reset time of the rate limits hit
+// during a reconcile.
+type rateLimitRecord struct {
+	mu    sync.Mutex
+	reset time.Time
+}
+
+// recordRateLimit records the reset time of the supplied error in the
+// rateLimitRecord of the supplied context, if any, and returns it. Errors
+// other than a RateLimitError or BudgetExceededError are not recorded.
+func recordRateLimit(ctx context.Context, err error) error {
+	var reset time.Time
+	switch e := err.(type) {
+	case *RateLimitError:
+		reset = e.Reset
+	case *BudgetExceededError:
+		reset = e.Reset
+	default:
+		return err
+	}
+	if r, ok := ctx.Value(rateLimitKey{}).(*rateLimitRecord); ok {
+		r.mu.Lock()
+		if reset.After(r.reset) {
+			r.reset = reset
+		}
+		r.mu.Unlock()
+	}
+	return err
+}
+
+// RequeueOnRateLimit wraps the supplied reconciler so that a reconcile that
+// hits a GitHub rate limit, or the rate budget of its ProviderConfig, is
+// requeued once the limit resets, rather than being retried with exponential
+// backoff as if it had failed.
+func RequeueOnRateLimit(r reconcile.Reconciler) reconcile.Reconciler {
+	return &rateLimitRequeuer{inner: r}
+}
+
+type rateLimitRequeuer struct {
+	inner reconcile.Reconciler
+}
+
+func (r *rateLimitRequeuer) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	rec := &rateLimitRecord{}
+	result, err := r.inner.Reconcile(context.WithValue(ctx, rateLimitKey{}, rec), req)
+
+	rec.mu.Lock()
+	reset := rec.reset
+	rec.mu.Unlock()
+	if reset.IsZero() {
+		return result, err
+	}
+	// Requeue at least a second later so that a reset that just passed does
+	// not cause a hot loop.
+	d := time.Until(reset)
+	if d < time.Second {
+		d = time.Second
+	}
+	return reconcile.Result{RequeueAfter: d}, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultMaxAttempts    = 3
+	defaultBackoff        = time.Second
+)
+
+// A timeoutTransport bounds how long a single request may take.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// RoundTrip sends the supplied request with a deadline. The deadline also
+// applies to reading the response body.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// A cancelOnClose cancels the context of a request once its response body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// A retryTransport retries requests that failed because of a server error or
+// a broken connection. Only requests with safe methods are retried, because a
+// mutating request may have taken effect even though it appeared to fail.
+type retryTransport struct {
+	base        http.RoundTripper
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, p *apisv1alpha1.RetryPolicy) *retryTransport {
+	t := &retryTransport{base: base, maxAttempts: defaultMaxAttempts, backoff: defaultBackoff}
+	if p != nil {
+		t.maxAttempts = pointer.IntDeref(p.MaxAttempts, defaultMaxAttempts)
+		if p.Backoff != nil {
+			t.backoff = p.Backoff.Duration
+		}
+	}
+	return t
+}
+
+// RoundTrip sends the supplied request, retrying it if it is safe to do so.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	wait := t.backoff
+	for attempt := 1; ; attempt++ {
+		res, err := t.base.RoundTrip(req)
+		if attempt >= t.maxAttempts || !retryable(res, err) {
+			return res, err
+		}
+		if res != nil {
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// retryable reports whether a request that returned the supplied response or
+// error may succeed when retried.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded)
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
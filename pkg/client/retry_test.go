@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// A roundTripperFunc is a function that sends HTTP requests.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// failing returns a transport that fails with the supplied response status
+// or error, and counts the requests it receives.
+func failing(status int, err error, count *int) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		*count++
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		method   string
+		status   int
+		err      error
+		attempts int
+	}{
+		"GetServerError": {
+			reason:   "GET requests that fail with a server error should be retried.",
+			method:   http.MethodGet,
+			status:   http.StatusBadGateway,
+			attempts: 3,
+		},
+		"HeadConnectionReset": {
+			reason:   "HEAD requests whose connection was reset should be retried.",
+			method:   http.MethodHead,
+			err:      syscall.ECONNRESET,
+			attempts: 3,
+		},
+		"GetClientError": {
+			reason:   "GET requests that fail with a client error should not be retried.",
+			method:   http.MethodGet,
+			status:   http.StatusNotFound,
+			attempts: 1,
+		},
+		"GetOtherError": {
+			reason:   "GET requests that fail for reasons a retry cannot fix should not be retried.",
+			method:   http.MethodGet,
+			err:      errors.New("boom"),
+			attempts: 1,
+		},
+		"PostServerError": {
+			reason:   "POST requests should never be retried, because they may have taken effect.",
+			method:   http.MethodPost,
+			status:   http.StatusBadGateway,
+			attempts: 1,
+		},
+		"PatchConnectionReset": {
+			reason:   "PATCH requests should never be retried, because they may have taken effect.",
+			method:   http.MethodPatch,
+			err:      syscall.ECONNRESET,
+			attempts: 1,
+		},
+		"PutServerError": {
+			reason:   "PUT requests should never be retried, because they may have taken effect.",
+			method:   http.MethodPut,
+			status:   http.StatusInternalServerError,
+			attempts: 1,
+		},
+		"DeleteServerError": {
+			reason:   "DELETE requests should never be retried, because they may have taken effect.",
+			method:   http.MethodDelete,
+			status:   http.StatusServiceUnavailable,
+			attempts: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			count := 0
+			rt := newRetryTransport(failing(tc.status, tc.err, &count), &apisv1alpha1.RetryPolicy{MaxAttempts: pointer.Int(3)})
+			rt.backoff = time.Millisecond
+			req, _ := http.NewRequest(tc.method, "https://api.github.com/repos/o/r", nil)
+			res, err := rt.RoundTrip(req)
+			if err == nil {
+				_ = res.Body.Close()
+			}
+			if diff := cmp.Diff(tc.attempts, count); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want attempts, +got attempts:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryTransportSucceeds(t *testing.T) {
+	count := 0
+	rt := &retryTransport{maxAttempts: 3, backoff: time.Millisecond, base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		count++
+		status := http.StatusOK
+		if count == 1 {
+			status = http.StatusBadGateway
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	res, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip(...): %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK || count != 2 {
+		t.Errorf("RoundTrip(...): want status 200 after 2 attempts, got status %d after %d", res.StatusCode, count)
+	}
+}
+
+func TestNewRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		policy      *apisv1alpha1.RetryPolicy
+		maxAttempts int
+	}{
+		"Default":  {maxAttempts: defaultMaxAttempts},
+		"Disabled": {policy: &apisv1alpha1.RetryPolicy{MaxAttempts: pointer.Int(1)}, maxAttempts: 1},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := newRetryTransport(nil, tc.policy).maxAttempts; got != tc.maxAttempts {
+				t.Errorf("newRetryTransport(...): want %d attempts, got %d", tc.maxAttempts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+)
+
+var (
+	requiredScopesMu sync.RWMutex
+	requiredScopes   = map[string][]string{}
+)
+
+// RequireScopes declares OAuth scopes the controller of the supplied kind of
+// managed resource requires its token to have. ProviderConfigs whose token
+// lacks any scope a kind that uses them requires are warned about. It is
+// called by the Setup functions of controllers, so only enabled kinds declare
+// their scopes.
+func RequireScopes(kind string, scopes ...string) {
+	requiredScopesMu.Lock()
+	defer requiredScopesMu.Unlock()
+	requiredScopes[kind] = append(requiredScopes[kind], scopes...)
+}
+
+// requiringKinds returns the kinds that declared required scopes.
+func requiringKinds() []string {
+	requiredScopesMu.RLock()
+	defer requiredScopesMu.RUnlock()
+	kinds := make([]string, 0, len(requiredScopes))
+	for k := range requiredScopes {
+		kinds = append(kinds, k)
+	}
+	return kinds
+}
+
+// missingScopes returns the scopes the supplied kinds require that the
+// supplied scopes do not grant, and for each of them the kinds that require
+// it, sorted by name.
+func missingScopes(granted, kinds []string) map[string][]string {
+	requiredScopesMu.RLock()
+	defer requiredScopesMu.RUnlock()
+	missing := map[string][]string{}
+	for _, k := range kinds {
+		for _, s := range requiredScopes[k] {
+			if hasScope(granted, s) {
+				continue
+			}
+			if !contains(missing[s], k) {
+				missing[s] = append(missing[s], k)
+			}
+		}
+	}
+	for s := range missing {
+		sort.Strings(missing[s])
+	}
+	return missing
+}
+
+// formatMissingScopes returns a message that lists the supplied missing
+// scopes, as returned by missingScopes, and the kinds that require them.
+func formatMissingScopes(missing map[string][]string) string {
+	scopes := make([]string, 0, len(missing))
+	for s := range missing {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+	parts := make([]string, len(scopes))
+	for i, s := range scopes {
+		parts[i] = s + " (" + strings.Join(missing[s], ", ") + ")"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// impliedBy lists scopes that grant the scopes nested under them, for scopes
+// whose name does not tell.
+var impliedBy = map[string]string{
+	"repo:status":     "repo",
+	"repo_deployment": "repo",
+	"public_repo":     "repo",
+	"repo:invite":     "repo",
+	"security_events": "repo",
+	"read:user":       "user",
+	"user:email":      "user",
+	"user:follow":     "user",
+}
+
+// hasScope reports whether the supplied scopes grant the wanted scope, either
+// directly or through a scope that includes it. For example admin:org
+// includes write:org, which includes read:org.
+func hasScope(granted []string, want string) bool {
+	for _, g := range granted {
+		if g == want || impliedBy[want] == g {
+			return true
+		}
+		level, name, ok := strings.Cut(want, ":")
+		if !ok {
+			continue
+		}
+		switch level {
+		case "read":
+			if g == "write:"+name || g == "admin:"+name {
+				return true
+			}
+		case "write":
+			if g == "admin:"+name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// identify returns the login and the scopes of the credentials of the
+// supplied connection. Scopes are nil if the credentials do not report them.
+func identify(ctx context.Context, conn *connection) (string, []string, *time.Time, error) {
+	if conn.app != nil {
+		scopes, err := appPermissions(ctx, conn.app)
+		return "", scopes, nil, err
+	}
+
+	// The authenticated user is cheap to fetch and, thanks to conditional
+	// requests, rarely counts against the rate limit.
+	u, res, err := conn.rest.Users.Get(ctx, "")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return u.GetLogin(), parseScopes(res.Response), parseExpiry(res.Response), nil
+}
+
+// parseExpiry returns when the token of the supplied response expires, or nil
+// if GitHub does not report that it expires.
+func parseExpiry(res *http.Response) *time.Time {
+	h := res.Header.Get("GitHub-Authentication-Token-Expiration")
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, h); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// parseScopes returns the OAuth scopes GitHub reports for the token of the
+// supplied response, or nil if it does not report them.
+func parseScopes(res *http.Response) []string {
+	h, ok := res.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		return nil
+	}
+	scopes := []string{}
+	for _, s := range strings.Split(strings.Join(h, ","), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// appPermissions returns the permissions of the supplied installation
+// transport's token, such as members:write.
+func appPermissions(ctx context.Context, tr *ghinstallation.Transport) ([]string, error) {
+	// The permissions are only known once a token was issued.
+	if _, err := tr.Token(ctx); err != nil {
+		return nil, err
+	}
+	p, err := tr.Permissions()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	levels := map[string]string{}
+	if err := json.Unmarshal(raw, &levels); err != nil {
+		return nil, err
+	}
+	scopes := make([]string, 0, len(levels))
+	for name, level := range levels {
+		scopes = append(scopes, name+":"+level)
+	}
+	sort.Strings(scopes)
+	return scopes, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// withRequiredScopes replaces the scopes kinds require for the duration of
+// the supplied test.
+func withRequiredScopes(t *testing.T, scopes map[string][]string) {
+	t.Helper()
+	requiredScopesMu.Lock()
+	saved := requiredScopes
+	requiredScopes = scopes
+	requiredScopesMu.Unlock()
+	t.Cleanup(func() {
+		requiredScopesMu.Lock()
+		requiredScopes = saved
+		requiredScopesMu.Unlock()
+	})
+}
+
+func TestMissingScopes(t *testing.T) {
+	withRequiredScopes(t, map[string][]string{
+		"Team":       {"admin:org"},
+		"Repository": {"repo"},
+		"Label":      {"repo"},
+		"ProjectV2":  {"project"},
+	})
+
+	cases := map[string]struct {
+		reason  string
+		granted []string
+		kinds   []string
+		want    map[string][]string
+	}{
+		"OnlyKindsInUse": {
+			reason:  "Scopes required by kinds that do not use the ProviderConfig should not be reported.",
+			granted: []string{"repo"},
+			kinds:   []string{"Repository", "Label"},
+			want:    map[string][]string{},
+		},
+		"MissingForKind": {
+			reason:  "Scopes a kind in use requires should be reported with the kinds that require them.",
+			granted: []string{"read:org"},
+			kinds:   []string{"Team", "Repository", "Label"},
+			want: map[string][]string{
+				"admin:org": {"Team"},
+				"repo":      {"Label", "Repository"},
+			},
+		},
+		"ImpliedScope": {
+			reason:  "Scopes granted through a broader scope should not be reported.",
+			granted: []string{"admin:org", "repo"},
+			kinds:   []string{"Team", "Repository"},
+			want:    map[string][]string{},
+		},
+		"UnknownKind": {
+			reason:  "Kinds that declared no scopes require none.",
+			granted: []string{},
+			kinds:   []string{"Webhook"},
+			want:    map[string][]string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := missingScopes(tc.granted, tc.kinds)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmissingScopes(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFormatMissingScopes(t *testing.T) {
+	got := formatMissingScopes(map[string][]string{
+		"repo":      {"Label", "Repository"},
+		"admin:org": {"Team"},
+	})
+	want := "admin:org (Team), repo (Label, Repository)"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("formatMissingScopes(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	cases := map[string]struct {
+		granted []string
+		want    string
+		has     bool
+	}{
+		"Exact":            {granted: []string{"repo"}, want: "repo", has: true},
+		"WriteGrantsRead":  {granted: []string{"write:org"}, want: "read:org", has: true},
+		"AdminGrantsWrite": {granted: []string{"admin:org"}, want: "write:org", has: true},
+		"ReadIsNotWrite":   {granted: []string{"read:org"}, want: "write:org", has: false},
+		"Implied":          {granted: []string{"repo"}, want: "public_repo", has: true},
+		"Unrelated":        {granted: []string{"admin:org_hook"}, want: "admin:org", has: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := hasScope(tc.granted, tc.want); got != tc.has {
+				t.Errorf("hasScope(%v, %q): want %t, got %t", tc.granted, tc.want, tc.has, got)
+			}
+		})
+	}
+}
+
+func TestUsingKinds(t *testing.T) {
+	withRequiredScopes(t, map[string][]string{"Team": {"admin:org"}, "Repository": {"repo"}})
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("default")
+
+	usage := func(kind string) apisv1alpha1.ProviderConfigUsage {
+		u := apisv1alpha1.ProviderConfigUsage{}
+		u.ResourceReference = xpv1.TypedReference{Kind: kind}
+		return u
+	}
+
+	cases := map[string]struct {
+		reason string
+		list   test.MockListFn
+		want   []string
+	}{
+		"Usages": {
+			reason: "The kinds of the managed resources that use the ProviderConfig should be returned once each.",
+			list: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if lo.LabelSelector.String() != xpv1.LabelKeyProviderName+"=default" {
+					return errors.Errorf("unexpected selector %q", lo.LabelSelector)
+				}
+				obj.(*apisv1alpha1.ProviderConfigUsageList).Items = []apisv1alpha1.ProviderConfigUsage{usage("Team"), usage("Label"), usage("Team")}
+				return nil
+			},
+			want: []string{"Label", "Team"},
+		},
+		"NoUsages": {
+			reason: "A ProviderConfig that is not used requires no scopes.",
+			list:   test.NewMockListFn(nil),
+			want:   []string{},
+		},
+		"ListError": {
+			reason: "All kinds enabled at startup should be returned if usages cannot be listed.",
+			list:   test.NewMockListFn(errors.New("boom")),
+			want:   []string{"Repository", "Team"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := usingKinds(context.Background(), &test.MockClient{MockList: tc.list}, pc)
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nusingKinds(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
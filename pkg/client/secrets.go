@@ -0,0 +1,246 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	errGetPublicKey    = "cannot get public key"
+	errDecodePublicKey = "cannot decode public key"
+	errSealSecret      = "cannot encrypt secret"
+
+	errFmtPublicKeySize = "public key %s is %d bytes long instead of 32"
+)
+
+// publicKeyTTL is how long public keys are cached. GitHub rarely rotates
+// them, and a secret encrypted with a rotated key is written again using the
+// new one, so caching them long only delays noticing a rotation.
+const publicKeyTTL = 10 * time.Minute
+
+var publicKeys = newPublicKeyCache(publicKeyTTL)
+
+// A SecretScope is where GitHub stores a secret: an organization, a
+// repository, or an environment of a repository. Each has its own public key
+// that secrets stored in it must be encrypted with.
+type SecretScope struct {
+	// Owner is the organization of organization secrets, or the owner of
+	// the repository of repository secrets.
+	Owner string
+
+	// Repository is the name of the repository of repository secrets.
+	Repository string
+
+	// RepositoryID is the ID of the repository of environment secrets, which
+	// GitHub addresses by the ID instead of the name of their repository.
+	RepositoryID int
+
+	// Environment is the name of the environment of environment secrets.
+	Environment string
+}
+
+// OrganizationSecrets returns the scope of the secrets of the supplied
+// organization.
+func OrganizationSecrets(org string) SecretScope {
+	return SecretScope{Owner: org}
+}
+
+// RepositorySecrets returns the scope of the secrets of the supplied
+// repository.
+func RepositorySecrets(owner, repo string) SecretScope {
+	return SecretScope{Owner: owner, Repository: repo}
+}
+
+// EnvironmentSecrets returns the scope of the secrets of the supplied
+// environment of the repository with the supplied ID.
+func EnvironmentSecrets(repoID int, env string) SecretScope {
+	return SecretScope{RepositoryID: repoID, Environment: env}
+}
+
+// String returns the path of the secrets of the scope.
+func (s SecretScope) String() string {
+	switch {
+	case s.Environment != "":
+		return fmt.Sprintf("repositories/%d/environments/%s/secrets", s.RepositoryID, s.Environment)
+	case s.Repository != "":
+		return fmt.Sprintf("repos/%s/%s/actions/secrets", s.Owner, s.Repository)
+	default:
+		return fmt.Sprintf("orgs/%s/actions/secrets", s.Owner)
+	}
+}
+
+// publicKey gets the current public key of the scope.
+func (s SecretScope) publicKey(ctx context.Context, c *github.Client) (*github.PublicKey, error) {
+	var k *github.PublicKey
+	var err error
+	switch {
+	case s.Environment != "":
+		k, _, err = c.Actions.GetEnvPublicKey(ctx, s.RepositoryID, s.Environment)
+	case s.Repository != "":
+		k, _, err = c.Actions.GetRepoPublicKey(ctx, s.Owner, s.Repository)
+	default:
+		k, _, err = c.Actions.GetOrgPublicKey(ctx, s.Owner)
+	}
+	return k, WrapAPIError(err, errGetPublicKey)
+}
+
+// Encrypt encrypts the supplied value with the public key of the supplied
+// scope, and writes the encrypted secret using the supplied function. The
+// function sets the name, and the visibility of organization secrets, before
+// passing the secret to the CreateOrUpdate method of the scope.
+//
+// Public keys are cached for a while, so that writing a secret does not take
+// a request for the key every time. If GitHub rejects a secret because its
+// key was rotated meanwhile, the key is fetched again and the secret written
+// once more.
+func Encrypt(ctx context.Context, c *github.Client, s SecretScope, value []byte, write func(context.Context, *github.EncryptedSecret) error) error {
+	return publicKeys.encrypt(ctx, c, s, value, write)
+}
+
+// A cachedPublicKey is a public key of a secret scope, and when it expires.
+type cachedPublicKey struct {
+	key     *github.PublicKey
+	expires time.Time
+}
+
+// A publicKeyCache holds the public keys of secret scopes, keyed by the
+// GitHub instance and the scope they belong to.
+type publicKeyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*cachedPublicKey
+}
+
+func newPublicKeyCache(ttl time.Duration) *publicKeyCache {
+	return &publicKeyCache{ttl: ttl, entries: map[string]*cachedPublicKey{}}
+}
+
+// encrypt implements Encrypt using the cache.
+func (pk *publicKeyCache) encrypt(ctx context.Context, c *github.Client, s SecretScope, value []byte, write func(context.Context, *github.EncryptedSecret) error) error {
+	k, err := pk.get(ctx, c, s)
+	if err != nil {
+		return err
+	}
+	err = sealAndWrite(ctx, k, value, write)
+	if !isKeyMismatch(err) {
+		return err
+	}
+	pk.forget(c, s, k.GetKeyID())
+	if k, err = pk.get(ctx, c, s); err != nil {
+		return err
+	}
+	return sealAndWrite(ctx, k, value, write)
+}
+
+// get returns the public key of the supplied scope, fetching it unless it is
+// cached and has not expired.
+func (pk *publicKeyCache) get(ctx context.Context, c *github.Client, s SecretScope) (*github.PublicKey, error) {
+	key := publicKeyCacheKey(c, s)
+	pk.mu.Lock()
+	e, ok := pk.entries[key]
+	pk.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.key, nil
+	}
+
+	k, err := s.publicKey(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	pk.entries[key] = &cachedPublicKey{key: k, expires: time.Now().Add(pk.ttl)}
+	return k, nil
+}
+
+// forget drops the public key of the supplied scope if it is the one with the
+// supplied ID. A newer key that was fetched meanwhile is kept.
+func (pk *publicKeyCache) forget(c *github.Client, s SecretScope, id string) {
+	key := publicKeyCacheKey(c, s)
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	if e, ok := pk.entries[key]; ok && e.key.GetKeyID() == id {
+		delete(pk.entries, key)
+	}
+}
+
+// publicKeyCacheKey identifies the supplied scope of the GitHub instance the
+// supplied client talks to.
+func publicKeyCacheKey(c *github.Client, s SecretScope) string {
+	return c.BaseURL.String() + s.String()
+}
+
+// sealAndWrite encrypts the supplied value with the supplied key, and writes
+// it using the supplied function.
+func sealAndWrite(ctx context.Context, k *github.PublicKey, value []byte, write func(context.Context, *github.EncryptedSecret) error) error {
+	es, err := seal(k, value, rand.Reader)
+	if err != nil {
+		return err
+	}
+	return write(ctx, es)
+}
+
+// seal encrypts the supplied value with the supplied public key into a
+// libsodium sealed box, as GitHub requires for secrets. The randomness of the
+// ephemeral key pair of the box is read from the supplied reader.
+func seal(k *github.PublicKey, value []byte, r io.Reader) (*github.EncryptedSecret, error) {
+	raw, err := base64.StdEncoding.DecodeString(k.GetKey())
+	if err != nil {
+		return nil, errors.Wrap(err, errDecodePublicKey)
+	}
+	if len(raw) != 32 {
+		return nil, errors.Errorf(errFmtPublicKeySize, k.GetKeyID(), len(raw))
+	}
+	var recipient [32]byte
+	copy(recipient[:], raw)
+	sealed, err := box.SealAnonymous(nil, value, &recipient, r)
+	if err != nil {
+		return nil, errors.Wrap(err, errSealSecret)
+	}
+	return &github.EncryptedSecret{KeyID: k.GetKeyID(), EncryptedValue: base64.StdEncoding.EncodeToString(sealed)}, nil
+}
+
+// isKeyMismatch reports whether the supplied error was returned by the GitHub
+// API because a secret was encrypted with a public key that is no longer
+// current. GitHub rejects such secrets as invalid, naming their key_id.
+func isKeyMismatch(err error) bool {
+	er := &github.ErrorResponse{}
+	if !IsUnprocessable(err) || !errors.As(err, &er) {
+		return false
+	}
+	if strings.Contains(er.Message, "key_id") {
+		return true
+	}
+	for _, e := range er.Errors {
+		if e.Field == "key_id" || strings.Contains(e.Message, "key_id") {
+			return true
+		}
+	}
+	return false
+}
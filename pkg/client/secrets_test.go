@@ -0,0 +1,360 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// repeat returns n bytes of the supplied value.
+func repeat(b byte, n int) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+func TestSeal(t *testing.T) {
+	// The key pair and the sealed box of the libsodium test vector of the
+	// golang.org/x/crypto/nacl/box tests, which was generated by libsodium
+	// with a random source that always returns 5.
+	private := repeat(1, 32)
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, _ := hex.DecodeString("50a61409b1ddd0325e9b16b700e719e9772c07000b1bd7786e907c653d20495d2af1697137a53b1b1dfc9befc49b6eeb38f86be720e155eb2be61976d2efb34d67ecd44a6ad634625eb9c288bfc883431a84ab0f5557dfe673aa6f74c19f033e648a947358cfcc606397fa1747d5219a")
+	_, corrupt := base64.StdEncoding.DecodeString("not a key")
+
+	type want struct {
+		secret *github.EncryptedSecret
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		key    *github.PublicKey
+		value  []byte
+		want   want
+	}{
+		"Libsodium": {
+			reason: "Secrets should be sealed exactly as libsodium seals them.",
+			key:    &github.PublicKey{KeyID: github.String("568250167242549743"), Key: github.String(base64.StdEncoding.EncodeToString(public))},
+			value:  repeat(3, 64),
+			want: want{secret: &github.EncryptedSecret{
+				KeyID:          "568250167242549743",
+				EncryptedValue: base64.StdEncoding.EncodeToString(sealed),
+			}},
+		},
+		"CorruptKey": {
+			reason: "Public keys that are not base64 encoded should be rejected.",
+			key:    &github.PublicKey{KeyID: github.String("1"), Key: github.String("not a key")},
+			want:   want{err: errors.Wrap(corrupt, errDecodePublicKey)},
+		},
+		"ShortKey": {
+			reason: "Public keys that are not Curve25519 keys should be rejected.",
+			key:    &github.PublicKey{KeyID: github.String("1"), Key: github.String(base64.StdEncoding.EncodeToString(repeat(1, 16)))},
+			want:   want{err: errors.Errorf(errFmtPublicKeySize, "1", 16)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := seal(tc.key, tc.value, bytes.NewReader(repeat(5, 32)))
+			if diff := cmp.Diff(tc.want, want{secret: s, err: err}, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nseal(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSealOpenAnonymous(t *testing.T) {
+	public, private, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &github.PublicKey{KeyID: github.String("1"), Key: github.String(base64.StdEncoding.EncodeToString(public[:]))}
+	value := []byte("hunter2")
+
+	s, err := seal(k, value, rand.Reader)
+	if err != nil {
+		t.Fatalf("seal(...): %v", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(s.EncryptedValue)
+	if err != nil {
+		t.Fatalf("seal(...): the encrypted value is not base64 encoded: %v", err)
+	}
+	got, ok := box.OpenAnonymous(nil, sealed, public, private)
+	if !ok {
+		t.Fatal("seal(...): the sealed box cannot be opened with the private key")
+	}
+	if diff := cmp.Diff(value, got); diff != "" {
+		t.Errorf("seal(...): -want, +got:\n%s", diff)
+	}
+}
+
+// A secretServer is a fake GitHub API that stores secrets of any scope, and
+// can rotate the public key of a scope.
+type secretServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    map[string]*[32]byte
+	ids     map[string]int
+	public  map[string]*[32]byte
+	secrets map[string]string
+	fetches map[string]int
+	writes  int
+	// stale makes writes fail as if the key was rotated, however often it is
+	// fetched.
+	stale bool
+}
+
+func newSecretServer(t *testing.T) *secretServer {
+	t.Helper()
+	s := &secretServer{keys: map[string]*[32]byte{}, ids: map[string]int{}, public: map[string]*[32]byte{}, secrets: map[string]string{}, fetches: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// rotate replaces the key pair of the supplied scope.
+func (s *secretServer) rotate(scope string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotateLocked(scope)
+}
+
+func (s *secretServer) rotateLocked(scope string) {
+	public, private, _ := box.GenerateKey(rand.Reader)
+	s.ids[scope]++
+	s.public[scope], s.keys[scope] = public, private
+}
+
+func (s *secretServer) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	scope, name := path[:strings.LastIndex(path, "/")], path[strings.LastIndex(path, "/")+1:]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys[scope] == nil {
+		s.rotateLocked(scope)
+	}
+	switch {
+	case r.Method == http.MethodGet && name == "public-key":
+		s.fetches[scope]++
+		_ = json.NewEncoder(w).Encode(&github.PublicKey{
+			KeyID: github.String(strings.Repeat("k", s.ids[scope])),
+			Key:   github.String(base64.StdEncoding.EncodeToString(s.public[scope][:])),
+		})
+	case r.Method == http.MethodPut:
+		s.writes++
+		es := &github.EncryptedSecret{}
+		_ = json.NewDecoder(r.Body).Decode(es)
+		if s.stale || es.KeyID != strings.Repeat("k", s.ids[scope]) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"Secret","field":"key_id","code":"invalid"}]}`))
+			return
+		}
+		sealed, _ := base64.StdEncoding.DecodeString(es.EncryptedValue)
+		value, ok := box.OpenAnonymous(nil, sealed, s.public[scope], s.keys[scope])
+		if !ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Bad request - unable to decrypt"}`))
+			return
+		}
+		s.secrets[path] = string(value)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// client returns a GitHub client of the server.
+func (s *secretServer) client() *github.Client {
+	c := github.NewClient(s.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c
+}
+
+// writeRepoSecret returns a function that writes the supplied secret of
+// acme/platform.
+func writeRepoSecret(c *github.Client, name string) func(context.Context, *github.EncryptedSecret) error {
+	return func(ctx context.Context, es *github.EncryptedSecret) error {
+		es.Name = name
+		_, err := c.Actions.CreateOrUpdateRepoSecret(ctx, "acme", "platform", es)
+		return err
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	repo := RepositorySecrets("acme", "platform").String()
+
+	type want struct {
+		fetches int
+		writes  int
+		secrets map[string]string
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		// between runs between two secrets being written.
+		between func(s *secretServer, pk *publicKeyCache)
+		stale   bool
+		want    want
+	}{
+		"Cached": {
+			reason: "The public key should be fetched once for all secrets of a scope.",
+			want: want{fetches: 1, writes: 2, secrets: map[string]string{
+				repo + "/FIRST":  "one",
+				repo + "/SECOND": "two",
+			}},
+		},
+		"Expired": {
+			reason: "The public key should be fetched again once it expired.",
+			between: func(_ *secretServer, pk *publicKeyCache) {
+				for _, e := range pk.entries {
+					e.expires = time.Now().Add(-time.Second)
+				}
+			},
+			want: want{fetches: 2, writes: 2, secrets: map[string]string{
+				repo + "/FIRST":  "one",
+				repo + "/SECOND": "two",
+			}},
+		},
+		"Rotated": {
+			reason: "A secret that GitHub rejects because its key was rotated should be encrypted with the new key and written again.",
+			between: func(s *secretServer, _ *publicKeyCache) {
+				s.rotate(repo)
+			},
+			want: want{fetches: 2, writes: 3, secrets: map[string]string{
+				repo + "/FIRST":  "one",
+				repo + "/SECOND": "two",
+			}},
+		},
+		"StillRejected": {
+			reason: "A secret should be written again only once, so that a key GitHub keeps rejecting is not fetched forever.",
+			stale:  true,
+			want:   want{fetches: 2, writes: 2, secrets: map[string]string{}, err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newSecretServer(t)
+			s.stale = tc.stale
+			c := s.client()
+			pk := newPublicKeyCache(time.Hour)
+			ctx := context.Background()
+
+			err := pk.encrypt(ctx, c, RepositorySecrets("acme", "platform"), []byte("one"), writeRepoSecret(c, "FIRST"))
+			if err == nil {
+				if tc.between != nil {
+					tc.between(s, pk)
+				}
+				err = pk.encrypt(ctx, c, RepositorySecrets("acme", "platform"), []byte("two"), writeRepoSecret(c, "SECOND"))
+			}
+
+			got := want{fetches: s.fetches[repo], writes: s.writes, secrets: s.secrets, err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\npk.encrypt(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEncryptScopes(t *testing.T) {
+	s := newSecretServer(t)
+	c := s.client()
+	pk := newPublicKeyCache(time.Hour)
+	ctx := context.Background()
+
+	writes := map[SecretScope]func(context.Context, *github.EncryptedSecret) error{
+		OrganizationSecrets("acme"): func(ctx context.Context, es *github.EncryptedSecret) error {
+			es.Name, es.Visibility = "TOKEN", "all"
+			_, err := c.Actions.CreateOrUpdateOrgSecret(ctx, "acme", es)
+			return err
+		},
+		RepositorySecrets("acme", "platform"): writeRepoSecret(c, "TOKEN"),
+		EnvironmentSecrets(42, "production"): func(ctx context.Context, es *github.EncryptedSecret) error {
+			es.Name = "TOKEN"
+			_, err := c.Actions.CreateOrUpdateEnvSecret(ctx, 42, "production", es)
+			return err
+		},
+	}
+	for i := 0; i < 2; i++ {
+		for scope, write := range writes {
+			if err := pk.encrypt(ctx, c, scope, []byte(scope.String()), write); err != nil {
+				t.Fatalf("pk.encrypt(%s): %v", scope, err)
+			}
+		}
+	}
+
+	wantFetches := map[string]int{
+		"orgs/acme/actions/secrets":                       1,
+		"repos/acme/platform/actions/secrets":             1,
+		"repositories/42/environments/production/secrets": 1,
+	}
+	if diff := cmp.Diff(wantFetches, s.fetches); diff != "" {
+		t.Errorf("pk.encrypt(...): each scope should have its own cached public key: -want, +got:\n%s", diff)
+	}
+	wantSecrets := map[string]string{}
+	for scope := range writes {
+		wantSecrets[scope.String()+"/TOKEN"] = scope.String()
+	}
+	if diff := cmp.Diff(wantSecrets, s.secrets); diff != "" {
+		t.Errorf("pk.encrypt(...): each secret should be encrypted with the key of its scope: -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsKeyMismatch(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"InvalidKeyID":  {err: errorResponse(http.StatusUnprocessableEntity, "", "Validation Failed", github.Error{Resource: "Secret", Field: "key_id", Code: "invalid"}), want: true},
+		"Message":       {err: errorResponse(http.StatusUnprocessableEntity, "", "Bad request - key_id does not match"), want: true},
+		"OtherField":    {err: errorResponse(http.StatusUnprocessableEntity, "", "Validation Failed", github.Error{Resource: "Secret", Field: "name", Code: "invalid"})},
+		"OtherStatus":   {err: errorResponse(http.StatusBadRequest, "", "key_id is missing")},
+		"NotAnAPIError": {err: errors.New("key_id")},
+		"NoError":       {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isKeyMismatch(tc.err); got != tc.want {
+				t.Errorf("isKeyMismatch(%v): want %t, got %t", tc.err, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// WithSyncStatus wraps the supplied ExternalConnecter so that the external
+// clients it connects record when they last observed Syncable managed
+// resources successfully.
+func WithSyncStatus(ec managed.ExternalConnecter) managed.ExternalConnecter {
+	return &syncConnecter{ExternalConnecter: ec}
+}
+
+type syncConnecter struct {
+	managed.ExternalConnecter
+}
+
+// Connect returns an external client that records successful observations.
+func (c *syncConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ext, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &syncExternal{ExternalClient: ext}, nil
+}
+
+type syncExternal struct {
+	managed.ExternalClient
+}
+
+// Observe observes the supplied managed resource and, if that succeeds,
+// records the time and the generation of the managed resource.
+func (e *syncExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if s, ok := mg.(common.Syncable); ok && err == nil {
+		now := metav1.Now()
+		st := s.GetSyncStatus()
+		st.LastSyncTime = &now
+		st.ObservedGeneration = mg.GetGeneration()
+	}
+	return o, err
+}
+
+// DesiredStateChanged returns a predicate that accepts changes to the spec,
+// labels, or annotations of a managed resource, but not changes to only its
+// status. Controllers update the status every time they observe an external
+// resource, so reconciling on them would reconcile continuously.
+func DesiredStateChanged() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+}
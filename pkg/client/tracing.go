@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// tracerName is the name of the tracer spans of the provider are recorded
+// with. Spans are only exported if a tracer provider is registered with the
+// otel package; by default they are not recorded at all.
+const tracerName = "github.com/hasheddan/kc-provider-github"
+
+// Span attributes.
+const (
+	attrKind            = attribute.Key("crossplane.kind")
+	attrName            = attribute.Key("crossplane.name")
+	attrExternalName    = attribute.Key("crossplane.external_name")
+	attrProviderConfig  = attribute.Key("crossplane.provider_config")
+	attrHTTPMethod      = attribute.Key("http.method")
+	attrHTTPRoute       = attribute.Key("http.route")
+	attrHTTPStatusCode  = attribute.Key("http.status_code")
+	attrRateLimitLeft   = attribute.Key("github.rate_limit.remaining")
+	attrRateLimitBucket = attribute.Key("github.rate_limit.resource")
+	attrGitHubRequestID = attribute.Key("github.request_id")
+)
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Trace returns a reconciler that records a span for every reconcile of the
+// supplied kind by the supplied reconciler. The span is passed on to the
+// reconciler with its context, so that the GitHub API calls made while
+// reconciling are recorded as its children.
+func Trace(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &tracingReconciler{kind: kind, inner: r}
+}
+
+type tracingReconciler struct {
+	kind  string
+	inner reconcile.Reconciler
+}
+
+func (r *tracingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracer().Start(ctx, "Reconcile "+r.kind, trace.WithAttributes(attrKind.String(r.kind), attrName.String(req.Name)))
+	defer span.End()
+
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
+
+// traceManaged adds the external name and ProviderConfig of the supplied
+// managed resource to the span of the reconcile in the supplied context.
+func traceManaged(ctx context.Context, mg resource.Managed) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(attrExternalName.String(meta.GetExternalName(mg)))
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		span.SetAttributes(attrProviderConfig.String(ref.Name))
+	}
+}
+
+// A tracingTransport records a span for every request to the GitHub API.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip records a client span for the supplied request, a child of the
+// span in the request's context if there is one.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := pathTemplate(req.URL.Path)
+	ctx, span := tracer().Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrHTTPMethod.String(req.Method), attrHTTPRoute.String(route)))
+	defer span.End()
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attrHTTPStatusCode.Int(res.StatusCode))
+	if id := res.Header.Get("X-GitHub-Request-Id"); id != "" {
+		span.SetAttributes(attrGitHubRequestID.String(id))
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		span.SetAttributes(attrRateLimitLeft.Int(remaining))
+	}
+	if resource := res.Header.Get("X-RateLimit-Resource"); resource != "" {
+		span.SetAttributes(attrRateLimitBucket.String(resource))
+	}
+	if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+	return res, nil
+}
+
+// pathTemplate returns the template of the API endpoint with the supplied
+// path, for example /orgs/{org}/teams/{id}/members for
+// /orgs/example/teams/example/members. Span names must not contain the names
+// of resources, or there would be a span name per resource.
+func pathTemplate(path string) string {
+	// The paths of a GitHub Enterprise Server are prefixed.
+	path = strings.TrimPrefix(path, "/api/v3")
+	path = strings.TrimPrefix(path, "/api")
+	s := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Most endpoints are scoped to an owner and alternate between the
+	// names of collections and items within them from there on.
+	first := len(s)
+	switch {
+	case len(s) >= 3 && s[0] == "repos":
+		s[1], s[2] = "{owner}", "{repo}"
+		first = 4
+	case len(s) >= 2 && (s[0] == "orgs" || s[0] == "enterprises" || s[0] == "users"):
+		s[1] = "{" + strings.TrimSuffix(s[0], "s") + "}"
+		first = 3
+	}
+	for i := first; i < len(s); i += 2 {
+		s[i] = "{id}"
+	}
+	return "/" + strings.Join(s, "/")
+}
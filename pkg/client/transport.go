@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/version"
+)
+
+const (
+	errGetCABundle   = "cannot get CA bundle"
+	errParseCABundle = "CA bundle does not contain any PEM encoded certificate"
+	errParseProxyURL = "cannot parse proxy URL"
+	errInsecureURL   = "base URL does not use https; set tls.insecureSkipVerify to allow this"
+)
+
+// caBundle returns the PEM encoded certificates the supplied TLS config trusts
+// in addition to the system's, if any.
+func caBundle(ctx context.Context, c client.Client, cfg *apisv1alpha1.TLSConfig) ([]byte, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var ca []byte
+	if cfg.CABundle != nil {
+		ca = append(ca, *cfg.CABundle...)
+		ca = append(ca, '\n')
+	}
+	if cfg.CABundleSecretRef != nil {
+		data, err := resource.ExtractSecret(ctx, c, xpv1.CommonCredentialSelectors{SecretRef: cfg.CABundleSecretRef})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetCABundle)
+		}
+		ca = append(ca, data...)
+	}
+	return ca, nil
+}
+
+// newTransport returns the transport connections of the supplied
+// ProviderConfig send their requests with, and the underlying HTTP transport
+// that holds their network connections. It trusts the supplied PEM encoded
+// certificates in addition to the system's.
+func newTransport(spec apisv1alpha1.ProviderConfigSpec, baseURL *url.URL, ca []byte) (http.RoundTripper, *http.Transport, error) {
+	insecure := spec.TLS != nil && spec.TLS.InsecureSkipVerify
+	if baseURL.Scheme != "https" && !insecure {
+		return nil, nil, errors.New(errInsecureURL)
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure, //nolint:gosec // Only when explicitly requested.
+	}
+	if len(ca) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, nil, errors.New(errParseCABundle)
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+	if spec.ProxyURL != nil {
+		u, err := url.Parse(*spec.ProxyURL)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errParseProxyURL)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	timeout := defaultRequestTimeout
+	if spec.RequestTimeout != nil {
+		timeout = spec.RequestTimeout.Duration
+	}
+	return newRetryTransport(&timeoutTransport{base: &headerTransport{base: tr}, timeout: timeout}, spec.Retry), tr, nil
+}
+
+// DefaultAPIVersion is the version of the REST API the provider is tested
+// against.
+const DefaultAPIVersion = "2022-11-28"
+
+// userAgent identifies the provider to GitHub.
+var userAgent = "provider-github/" + version.Version + " (+https://github.com/MisterMX/provider-example-github)"
+
+var apiVersion = DefaultAPIVersion
+
+// SetAPIVersion sets the version of the REST API all clients request. It is
+// meant to switch versions in emergencies, without a new release.
+func SetAPIVersion(v string) {
+	apiVersion = v
+}
+
+// A headerTransport identifies the provider and pins the REST API version of
+// all requests.
+type headerTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the supplied request with identifying headers.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+func TestCABundle(t *testing.T) {
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "crossplane-system"}, Key: "ca.crt"}
+
+	type want struct {
+		ca  string
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		cfg    *apisv1alpha1.TLSConfig
+		want   want
+	}{
+		"NoConfig": {
+			reason: "No certificates should be trusted in addition to the system's without a TLS config.",
+		},
+		"Inline": {
+			reason: "Inline certificates should be trusted.",
+			cfg:    &apisv1alpha1.TLSConfig{CABundle: pointer.String("inline")},
+			want:   want{ca: "inline\n"},
+		},
+		"SecretAndInline": {
+			reason: "Certificates from the inline bundle and from the secret should both be trusted.",
+			get:    secretGet(map[string][]byte{"ca.crt": []byte("secret")}),
+			cfg:    &apisv1alpha1.TLSConfig{CABundle: pointer.String("inline"), CABundleSecretRef: ref},
+			want:   want{ca: "inline\nsecret"},
+		},
+		"SecretError": {
+			reason: "Errors getting the CA bundle secret should be returned.",
+			get:    test.NewMockGetFn(errors.New("boom")),
+			cfg:    &apisv1alpha1.TLSConfig{CABundleSecretRef: ref},
+			want:   want{err: errors.Wrap(errors.Wrap(errors.New("boom"), "cannot get credentials secret"), errGetCABundle)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ca, err := caBundle(context.Background(), &test.MockClient{MockGet: tc.get}, tc.cfg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncaBundle(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ca, string(ca)); diff != "" {
+				t.Errorf("\n%s\ncaBundle(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewTransportTLS(t *testing.T) {
+	srv, ca := tlsServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := url.Parse("http://ghe.example.com/api/v3/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   apisv1alpha1.ProviderConfigSpec
+		url    *url.URL
+		ca     []byte
+		// errNew and errGet are substrings of the errors expected from
+		// building the transport, and from sending a request with it.
+		errNew string
+		errGet string
+	}{
+		"CustomCA": {
+			reason: "A server whose certificate was issued by a trusted custom CA should be reachable.",
+			url:    u,
+			ca:     ca,
+		},
+		"UntrustedCA": {
+			reason: "A server whose certificate was issued by an untrusted CA should be rejected.",
+			url:    u,
+			errGet: "certificate",
+		},
+		"InvalidCA": {
+			reason: "A CA bundle without certificates should be rejected.",
+			url:    u,
+			ca:     []byte("not a certificate"),
+			errNew: errParseCABundle,
+		},
+		"InsecureSkipVerify": {
+			reason: "An untrusted certificate should be accepted when verification is explicitly disabled.",
+			spec:   apisv1alpha1.ProviderConfigSpec{TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: true}},
+			url:    u,
+		},
+		"PlainHTTP": {
+			reason: "A base URL that does not use TLS should be rejected unless verification is explicitly disabled.",
+			url:    plain,
+			errNew: errInsecureURL,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rt, _, err := newTransport(tc.spec, tc.url, tc.ca)
+			if !matches(err, tc.errNew) {
+				t.Fatalf("\n%s\nnewTransport(...): want error containing %q, got %v", tc.reason, tc.errNew, err)
+			}
+			if err != nil {
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			res, err := rt.RoundTrip(req)
+			if err == nil {
+				_ = res.Body.Close()
+			}
+			if !matches(err, tc.errGet) {
+				t.Errorf("\n%s\nRoundTrip(...): want error containing %q, got %v", tc.reason, tc.errGet, err)
+			}
+		})
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	u, _ := url.Parse("https://ghe.example.com/api/v3/")
+	spec := apisv1alpha1.ProviderConfigSpec{ProxyURL: pointer.String("http://proxy.example.com:3128")}
+	_, tr, err := newTransport(spec, u, nil)
+	if err != nil {
+		t.Fatalf("newTransport(...): %v", err)
+	}
+	got, err := tr.Proxy(&http.Request{URL: u})
+	if err != nil {
+		t.Fatalf("Proxy(...): %v", err)
+	}
+	if diff := cmp.Diff("http://proxy.example.com:3128", got.String()); diff != "" {
+		t.Errorf("Proxy(...): -want, +got:\n%s", diff)
+	}
+}
+
+// matches reports whether the supplied error contains the supplied substring,
+// or is nil if the substring is empty.
+func matches(err error, substr string) bool {
+	if substr == "" {
+		return err == nil
+	}
+	return err != nil && strings.Contains(err.Error(), substr)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// WaitForRepository wraps the supplied connecter so that repository-scoped
+// managed resources whose Repository is not ready yet are not observed.
+// Observing them would fail with a 404, and retrying with exponential backoff
+// until the repository exists. Instead they are reported as up to date, and
+// are observed again after the poll interval.
+//
+// When a repository is deleted together with the managed resources in it, as
+// when a composition is deleted, the repository may be gone first. Requests
+// for managed resources that are being deleted then fail with a 404, which is
+// treated as if they were deleted, so that their finalizers are removed.
+//
+// Managed resources whose account the credentials of their ProviderConfig
+// cannot access are marked with the InvalidProviderConfig condition, and are
+// not observed either. Only changing their ProviderConfig can help.
+func WaitForRepository(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		if common.IsWaitingForRepository(mg) {
+			return waitingExternal{}, nil
+		}
+		e, err := c.Connect(ctx, mg)
+		if IsInaccessibleAccount(err) {
+			mg.SetConditions(common.InvalidProviderConfig(err.Error()))
+			return waitingExternal{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &repositoryScopedExternal{ExternalClient: e}, nil
+	})
+}
+
+// A repositoryScopedExternal is the external client of a managed resource
+// in a repository, which may be deleted before the managed resource is.
+type repositoryScopedExternal struct {
+	managed.ExternalClient
+}
+
+func (e *repositoryScopedExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if meta.WasDeleted(mg) && gone(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return o, err
+}
+
+func (e *repositoryScopedExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := e.ExternalClient.Delete(ctx, mg); !gone(err) {
+		return err
+	}
+	return nil
+}
+
+// gone returns true if the supplied error was returned because what was
+// requested, or the repository it is in, does not exist.
+func gone(err error) bool {
+	return IsNotFound(err) || IsGraphQLNotFound(err)
+}
+
+// A waitingExternal is the external client of a managed resource that waits
+// for its repository, or whose account is inaccessible. Nothing the
+// credentials can access exists, so there is nothing to delete.
+type waitingExternal struct{}
+
+func (waitingExternal) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	// The resource is reported to exist, although it was never observed,
+	// because the managed reconciler calls Create for resources that do not.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (waitingExternal) Create(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (waitingExternal) Update(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (waitingExternal) Delete(context.Context, resource.Managed) error {
+	return nil
+}
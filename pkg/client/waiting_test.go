@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+)
+
+// A callRecorder records the methods of the external clients it connects
+// that were called.
+type callRecorder struct {
+	managed.ExternalConnecter
+	calls []string
+}
+
+func (r *callRecorder) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := r.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &managed.ExternalClientFns{
+		ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+			r.calls = append(r.calls, "Observe")
+			return e.Observe(ctx, mg)
+		},
+		CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+			r.calls = append(r.calls, "Create")
+			return e.Create(ctx, mg)
+		},
+		UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+			r.calls = append(r.calls, "Update")
+			return e.Update(ctx, mg)
+		},
+		DeleteFn: func(ctx context.Context, mg resource.Managed) error {
+			r.calls = append(r.calls, "Delete")
+			return e.Delete(ctx, mg)
+		},
+	}, nil
+}
+
+// TestWaitForRepositoryNeverCreates runs the managed reconciler, since what
+// matters is which method of the external client it calls next. A waiting
+// resource is reported to exist, which a real external client would not do,
+// because the reconciler calls Create for resources that do not exist.
+func TestWaitForRepositoryNeverCreates(t *testing.T) {
+	pollInterval := 10 * time.Minute
+
+	type want struct {
+		calls  []string
+		result reconcile.Result
+	}
+
+	cases := map[string]struct {
+		reason    string
+		condition xpv1.Condition
+		connect   error
+		want      want
+	}{
+		"WaitingForRepository": {
+			reason:    "A resource whose repository is not ready should be observed again after the poll interval, without being created.",
+			condition: common.WaitingForRepository("repository platform is not ready"),
+			want: want{
+				calls:  []string{"Observe"},
+				result: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+		"InaccessibleAccount": {
+			reason:  "A resource whose account the credentials cannot access should be observed again after the poll interval, without being created.",
+			connect: &InaccessibleAccountError{msg: "the GitHub App of the ProviderConfig is installed on acme"},
+			want: want{
+				calls:  []string{"Observe"},
+				result: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					mg := obj.(*fake.Managed)
+					mg.SetName("platform-alice")
+					mg.SetConditions(tc.condition)
+					return nil
+				},
+				MockUpdate:       test.NewMockUpdateFn(nil),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+			}
+			inner := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				if tc.connect != nil {
+					return nil, tc.connect
+				}
+				return nil, errors.New("the client of a waiting resource should not be connected")
+			})
+			rec := &callRecorder{ExternalConnecter: WaitForRepository(inner)}
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: fake.SchemeWith(&fake.Managed{})},
+				resource.ManagedKind(fake.GVK(&fake.Managed{})),
+				managed.WithExternalConnecter(rec),
+				managed.WithReferenceResolver(managed.ReferenceResolverFn(func(context.Context, resource.Managed) error { return nil })),
+				managed.WithInitializers(),
+				managed.WithConnectionPublishers(),
+				managed.WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(context.Context, resource.Object) error { return nil }}),
+				managed.WithPollInterval(pollInterval))
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if err != nil {
+				t.Fatalf("\n%s\nReconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, rec.calls); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
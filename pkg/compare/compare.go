@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compare compares the desired state of managed resources with the
+// observed state of their external resources. Controllers should use it to
+// determine whether an external resource is up to date, so that all kinds
+// treat optional fields the same way.
+package compare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Optional reports whether the observed value of an optional field satisfies
+// its desired value. A nil desired value leaves the field unmanaged, so any
+// observed value satisfies it. A nil observed value is treated as the zero
+// value, so a desired zero value, like an empty description, requires the
+// field to be cleared.
+func Optional[T comparable](desired, observed *T) bool {
+	if desired == nil {
+		return true
+	}
+	return *desired == deref(observed)
+}
+
+// Set reports whether the observed elements of a field equal its desired
+// elements, ignoring their order and duplicates. A nil desired slice leaves
+// the field unmanaged, while an empty one requires it to be empty.
+func Set[T comparable](desired, observed []T) bool {
+	if desired == nil {
+		return true
+	}
+	want, got := set(desired), set(observed)
+	if len(want) != len(got) {
+		return false
+	}
+	for e := range want {
+		if _, ok := got[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// A Diff collects the fields of a managed resource that differ from its
+// external resource.
+type Diff struct {
+	fields []string
+}
+
+// DiffOptional adds the supplied optional field to the supplied diff unless
+// its observed value satisfies its desired value, as determined by Optional.
+func DiffOptional[T comparable](d *Diff, field string, desired, observed *T) {
+	if !Optional(desired, observed) {
+		d.Add(field, *desired, deref(observed))
+	}
+}
+
+// DiffSet adds the supplied field to the supplied diff unless its observed
+// elements equal its desired elements, as determined by Set.
+func DiffSet[T comparable](d *Diff, field string, desired, observed []T) {
+	if !Set(desired, observed) {
+		d.Add(field, desired, observed)
+	}
+}
+
+// Add adds the supplied field, which differs, to the diff.
+func (d *Diff) Add(field string, desired, observed interface{}) {
+	d.fields = append(d.fields, fmt.Sprintf("%s: %s", field, cmp.Diff(observed, desired)))
+}
+
+// UpToDate reports whether no fields differ.
+func (d *Diff) UpToDate() bool {
+	return len(d.fields) == 0
+}
+
+// String returns a cmp.Diff of each field that differs, from the observed to
+// the desired value, suitable for the Diff of an ExternalObservation.
+func (d *Diff) String() string {
+	return strings.Join(d.fields, "\n")
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+func set[T comparable](s []T) map[T]struct{} {
+	m := make(map[T]struct{}, len(s))
+	for _, e := range s {
+		m[e] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitrunnerconfig
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotJITRunnerConfig = "managed resource is not a JITRunnerConfig custom resource"
+	errCreateService      = "failed to create client service"
+	errGetRunner          = "cannot get runner"
+	errGenerateConfig     = "cannot generate just-in-time runner configuration"
+	errRemoveRunner       = "cannot remove runner"
+	errInvalidID          = "external name is not a runner ID"
+	errNoScope            = "either org or a repository must be set"
+)
+
+// SetupJITRunnerConfig adds a controller that reconciles JITRunnerConfig
+// managed resources.
+func SetupJITRunnerConfig(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.JITRunnerConfigGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.JITRunnerConfigKind, "repo", "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.JITRunnerConfigGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.JITRunnerConfig{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.JITRunnerConfigGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.JITRunnerConfigKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.JITRunnerConfig); !ok {
+		return nil, errors.New(errNotJITRunnerConfig)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes the runner of a just-in-time configuration, and
+// generates a new configuration when the runner is gone or the configuration is
+// to be regenerated.
+type external struct {
+	service *github.Client
+}
+
+// ConnectionDetailEncodedJITConfig is the connection detail the encoded
+// just-in-time configuration is published as. It is passed to the runner as
+// its --jitconfig flag.
+const ConnectionDetailEncodedJITConfig = "encodedJITConfig"
+
+// defaultRunnerGroupID is the ID of the Default runner group, which
+// repository runners always join.
+const defaultRunnerGroupID = 1
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.JITRunnerConfig)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotJITRunnerConfig)
+	}
+
+	// GitHub assigns the ID of a runner when its configuration is generated.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInvalidID)
+	}
+
+	p := cr.Spec.ForProvider
+	var r *github.Runner
+	if p.Org != nil {
+		r, _, err = c.service.Actions.GetOrganizationRunner(ctx, *p.Org, id)
+	} else {
+		r, _, err = c.service.Actions.GetRunner(ctx, p.Owner, p.Repository, id)
+	}
+	// GitHub removes an ephemeral runner once it ran its job, which makes
+	// generating a new configuration the way to replace it.
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetRunner)
+	}
+
+	regenerate := cr.GetAnnotations()[v1alpha1.AnnotationKeyRegenerate]
+	// Creating a configuration cannot record the annotation it was generated
+	// for in the status, so the first observation after it does.
+	if cr.Status.AtProvider.RunnerID != id {
+		cr.Status.AtProvider.Regenerated = regenerate
+	}
+	cr.Status.AtProvider.RunnerID = id
+	cr.Status.AtProvider.Status = r.GetStatus()
+	cr.Status.AtProvider.Busy = r.GetBusy()
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: cr.Status.AtProvider.Regenerated == regenerate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.JITRunnerConfig)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotJITRunnerConfig)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	p := cr.Spec.ForProvider
+	req := &github.GenerateJITConfigRequest{
+		Name:          p.Name,
+		RunnerGroupID: pointer.Int64Deref(p.RunnerGroupID, defaultRunnerGroupID),
+		WorkFolder:    p.WorkFolder,
+		Labels:        p.Labels,
+	}
+	var cfg *github.JITRunnerConfig
+	var err error
+	switch {
+	case p.Org != nil:
+		cfg, _, err = c.service.Actions.GenerateOrgJITConfig(ctx, *p.Org, req)
+	case p.Repository != "":
+		cfg, _, err = c.service.Actions.GenerateRepoJITConfig(ctx, p.Owner, p.Repository, req)
+	default:
+		return managed.ExternalCreation{}, errors.New(errNoScope)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errGenerateConfig)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(cfg.GetRunner().GetID(), 10))
+	return managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+		ConnectionDetailEncodedJITConfig: []byte(cfg.GetEncodedJITConfig()),
+	}}, nil
+}
+
+// Update removes the runner of a configuration that is to be regenerated.
+// A runner name can only be registered once, so the runner has to go before
+// a new configuration is generated for it, which then happens as the runner
+// is observed to be gone.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.JITRunnerConfig)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotJITRunnerConfig)
+	}
+
+	err := c.remove(ctx, cr)
+	if err != nil && !kcgitclient.IsNotFound(err) {
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errRemoveRunner)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.JITRunnerConfig)
+	if !ok {
+		return errors.New(errNotJITRunnerConfig)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	return kcgitclient.DeleteError(ctx, cr, c.remove(ctx, cr), errRemoveRunner)
+}
+
+// remove removes the runner of the supplied configuration.
+func (c *external) remove(ctx context.Context, cr *v1alpha1.JITRunnerConfig) error {
+	p := cr.Spec.ForProvider
+	id := cr.Status.AtProvider.RunnerID
+	if p.Org != nil {
+		_, err := c.service.Actions.RemoveOrganizationRunner(ctx, *p.Org, id)
+		return err
+	}
+	_, err := c.service.Actions.RemoveRunner(ctx, p.Owner, p.Repository, id)
+	return err
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationoidcsubjectclaim
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationOIDCSubjectClaim = "managed resource is not an OrganizationOIDCSubjectClaim custom resource"
+	errCreateService                   = "failed to create client service"
+	errGetTemplate                     = "cannot get organization OIDC subject claim template"
+	errSetTemplate                     = "cannot set organization OIDC subject claim template"
+)
+
+// defaultClaimKeys are the claim keys of the subject claim template GitHub
+// uses for organizations that have not customized it.
+var defaultClaimKeys = []string{"repo", "context"}
+
+// SetupOrganizationOIDCSubjectClaim adds a controller that reconciles
+// OrganizationOIDCSubjectClaim managed resources.
+func SetupOrganizationOIDCSubjectClaim(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationOIDCSubjectClaimGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationOIDCSubjectClaimKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationOIDCSubjectClaimGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationOIDCSubjectClaim{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationOIDCSubjectClaimGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationOIDCSubjectClaimKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationOIDCSubjectClaim); !ok {
+		return nil, errors.New(errNotOrganizationOIDCSubjectClaim)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes an
+// organization's OIDC subject claim template.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationOIDCSubjectClaim)
+	}
+
+	tmpl, _, err := c.service.Actions.GetOrgOIDCSubjectClaimCustomTemplate(ctx, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetTemplate)
+	}
+
+	cr.Status.AtProvider.IncludeClaimKeys = tmpl.IncludeClaimKeys
+
+	// Every organization has a subject claim template, so it only ceases to
+	// exist once it has been restored to the default during deletion.
+	if meta.WasDeleted(cr) && cmp.Equal(tmpl.IncludeClaimKeys, defaultClaimKeys) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// GitHub builds the subject claim by joining the keys in order, so
+		// the order is significant and must be compared exactly.
+		ResourceUpToDate: cmp.Equal(cr.Spec.ForProvider.IncludeClaimKeys, tmpl.IncludeClaimKeys, cmpopts.EquateEmpty()),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationOIDCSubjectClaim)
+	}
+
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider.Org, cr.Spec.ForProvider.IncludeClaimKeys), errSetTemplate)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationOIDCSubjectClaim)
+	}
+
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider.Org, cr.Spec.ForProvider.IncludeClaimKeys), errSetTemplate)
+}
+
+// Delete restores the default subject claim template of the organization.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrganizationOIDCSubjectClaim)
+	if !ok {
+		return errors.New(errNotOrganizationOIDCSubjectClaim)
+	}
+
+	return kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider.Org, defaultClaimKeys), errSetTemplate)
+}
+
+func (c *external) set(ctx context.Context, org string, keys []string) error {
+	_, err := c.service.Actions.SetOrgOIDCSubjectClaimCustomTemplate(ctx, org, &github.OIDCSubjectClaimCustomTemplate{
+		IncludeClaimKeys: keys,
+	})
+	return err
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositoryoidcsubjectclaim
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRepositoryOIDCSubjectClaim = "managed resource is not a RepositoryOIDCSubjectClaim custom resource"
+	errCreateService                 = "failed to create client service"
+	errGetTemplate                   = "cannot get repository OIDC subject claim template"
+	errSetTemplate                   = "cannot set repository OIDC subject claim template"
+)
+
+// SetupRepositoryOIDCSubjectClaim adds a controller that reconciles
+// RepositoryOIDCSubjectClaim managed resources.
+func SetupRepositoryOIDCSubjectClaim(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RepositoryOIDCSubjectClaimGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RepositoryOIDCSubjectClaimKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RepositoryOIDCSubjectClaimGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RepositoryOIDCSubjectClaim{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RepositoryOIDCSubjectClaimGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RepositoryOIDCSubjectClaimKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RepositoryOIDCSubjectClaim); !ok {
+		return nil, errors.New(errNotRepositoryOIDCSubjectClaim)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a
+// repository's OIDC subject claim template.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryOIDCSubjectClaim)
+	}
+
+	p := cr.Spec.ForProvider
+	tmpl, _, err := c.service.Actions.GetRepoOIDCSubjectClaimCustomTemplate(ctx, p.Owner, p.Repository)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetTemplate)
+	}
+
+	cr.Status.AtProvider.UseDefault = tmpl.GetUseDefault()
+	cr.Status.AtProvider.IncludeClaimKeys = tmpl.IncludeClaimKeys
+
+	// Every repository has a subject claim template, so it only ceases to
+	// exist once it has been restored to the default during deletion.
+	if meta.WasDeleted(cr) && tmpl.GetUseDefault() {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	upToDate := p.UseDefault == tmpl.GetUseDefault()
+	// GitHub builds the subject claim by joining the keys in order, so the
+	// order is significant and must be compared exactly.
+	if !p.UseDefault && !cmp.Equal(p.IncludeClaimKeys, tmpl.IncludeClaimKeys, cmpopts.EquateEmpty()) {
+		upToDate = false
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryOIDCSubjectClaim)
+	}
+
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(c.set(ctx, cr), errSetTemplate)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryOIDCSubjectClaim)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryOIDCSubjectClaim)
+	}
+
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(c.set(ctx, cr), errSetTemplate)
+}
+
+// Delete restores the default subject claim template of the repository.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RepositoryOIDCSubjectClaim)
+	if !ok {
+		return errors.New(errNotRepositoryOIDCSubjectClaim)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := c.service.Actions.SetRepoOIDCSubjectClaimCustomTemplate(ctx, p.Owner, p.Repository, &github.OIDCSubjectClaimCustomTemplate{
+		UseDefault: github.Bool(true),
+	})
+	return kcgitclient.WrapAPIError(err, errSetTemplate)
+}
+
+func (c *external) set(ctx context.Context, cr *v1alpha1.RepositoryOIDCSubjectClaim) error {
+	p := cr.Spec.ForProvider
+	tmpl := &github.OIDCSubjectClaimCustomTemplate{
+		UseDefault: github.Bool(p.UseDefault),
+	}
+	if !p.UseDefault {
+		tmpl.IncludeClaimKeys = p.IncludeClaimKeys
+	}
+	_, err := c.service.Actions.SetRepoOIDCSubjectClaimCustomTemplate(ctx, p.Owner, p.Repository, tmpl)
+	return err
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnergroup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRunnerGroup    = "managed resource is not a RunnerGroup custom resource"
+	errCreateService     = "failed to create client service"
+	errGetRunnerGroup    = "cannot get runner group"
+	errCreateRunnerGroup = "cannot create runner group"
+	errUpdateRunnerGroup = "cannot update runner group"
+	errDeleteRunnerGroup = "cannot delete runner group"
+	errGetRepository     = "cannot get repository of selected workflow"
+	errInvalidID         = "external name is not a runner group ID"
+	errFmtNoRepository   = "repository %s/%s of selected workflow %s does not exist"
+)
+
+// SetupRunnerGroup adds a controller that reconciles RunnerGroup managed
+// resources.
+func SetupRunnerGroup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RunnerGroupGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RunnerGroupKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RunnerGroupGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RunnerGroup{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RunnerGroupGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RunnerGroupKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RunnerGroup); !ok {
+		return nil, errors.New(errNotRunnerGroup)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a runner group
+// of an organization.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RunnerGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRunnerGroup)
+	}
+
+	// GitHub assigns the ID of a runner group when it is created.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInvalidID)
+	}
+
+	p := cr.Spec.ForProvider
+	g, _, err := c.service.Actions.GetOrganizationRunnerGroup(ctx, p.Org, id)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetRunnerGroup)
+	}
+
+	cr.Status.AtProvider = v1alpha1.RunnerGroupObservation{
+		ID:                           g.GetID(),
+		Default:                      g.GetDefault(),
+		Inherited:                    g.GetInherited(),
+		WorkflowRestrictionsReadOnly: g.GetWorkflowRestrictionsReadOnly(),
+	}
+	cr.SetConditions(xpv1.Available())
+
+	d := diff(p, g)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: d.UpToDate(),
+		Diff:             d.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RunnerGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRunnerGroup)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	p := cr.Spec.ForProvider
+	if err := c.validate(ctx, p.SelectedWorkflows); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	g, _, err := c.service.Actions.CreateOrganizationRunnerGroup(ctx, p.Org, github.CreateRunnerGroupRequest{
+		Name:                     github.String(p.Name),
+		Visibility:               github.String(p.Visibility),
+		AllowsPublicRepositories: github.Bool(p.AllowsPublicRepositories),
+		RestrictedToWorkflows:    github.Bool(len(p.SelectedWorkflows) > 0),
+		SelectedWorkflows:        workflows(p.SelectedWorkflows),
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateRunnerGroup)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(g.GetID(), 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RunnerGroup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRunnerGroup)
+	}
+
+	p := cr.Spec.ForProvider
+	if err := c.validate(ctx, p.SelectedWorkflows); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err := c.service.Actions.UpdateOrganizationRunnerGroup(ctx, p.Org, cr.Status.AtProvider.ID, github.UpdateRunnerGroupRequest{
+		Name:                     github.String(p.Name),
+		Visibility:               github.String(p.Visibility),
+		AllowsPublicRepositories: github.Bool(p.AllowsPublicRepositories),
+		RestrictedToWorkflows:    github.Bool(len(p.SelectedWorkflows) > 0),
+		SelectedWorkflows:        workflows(p.SelectedWorkflows),
+	})
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateRunnerGroup)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RunnerGroup)
+	if !ok {
+		return errors.New(errNotRunnerGroup)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	_, err := c.service.Actions.DeleteOrganizationRunnerGroup(ctx, cr.Spec.ForProvider.Org, cr.Status.AtProvider.ID)
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteRunnerGroup)
+}
+
+// validate returns an error if the repository of a selected workflow does not
+// exist. GitHub accepts workflows of any repository, so a typo would restrict
+// the runner group to a workflow that never runs.
+func (c *external) validate(ctx context.Context, selected []v1alpha1.SelectedWorkflow) error {
+	checked := map[string]bool{}
+	for _, w := range selected {
+		if checked[w.Owner+"/"+w.Repository] {
+			continue
+		}
+		_, _, err := c.service.Repositories.Get(ctx, w.Owner, w.Repository)
+		if kcgitclient.IsNotFound(err) {
+			return errors.Errorf(errFmtNoRepository, w.Owner, w.Repository, w.Path)
+		}
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, errGetRepository)
+		}
+		checked[w.Owner+"/"+w.Repository] = true
+	}
+	return nil
+}
+
+// workflow identifies a workflow file at a ref of a repository.
+type workflow struct {
+	Owner, Repository, Path, Ref string
+}
+
+// String returns the workflow the way GitHub expects it, such as
+// acme/infra/.github/workflows/deploy.yml@refs/heads/main.
+func (w workflow) String() string {
+	return fmt.Sprintf("%s/%s/%s@%s", w.Owner, w.Repository, w.Path, w.Ref)
+}
+
+// parse returns the workflow of the supplied string. Strings it cannot parse
+// are kept as the path of a workflow, so that they show up as a difference.
+func parse(s string) workflow {
+	file, ref, _ := strings.Cut(s, "@")
+	parts := strings.SplitN(file, "/", 3)
+	if len(parts) != 3 {
+		return workflow{Path: s}
+	}
+	return workflow{Owner: parts[0], Repository: parts[1], Path: parts[2], Ref: ref}
+}
+
+// workflows returns the strings of the supplied selected workflows. GitHub
+// rejects null where it expects a list.
+func workflows(selected []v1alpha1.SelectedWorkflow) []string {
+	s := make([]string, 0, len(selected))
+	for _, w := range selected {
+		s = append(s, workflow{Owner: w.Owner, Repository: w.Repository, Path: w.Path, Ref: w.Ref}.String())
+	}
+	return s
+}
+
+// diff returns the differences between the desired and the observed runner
+// group. The order of the selected workflows does not matter, and GitHub
+// treats the owner and name of their repositories case-insensitively.
+func diff(p v1alpha1.RunnerGroupParameters, g *github.RunnerGroup) *compare.Diff {
+	desired := make([]workflow, 0, len(p.SelectedWorkflows))
+	for _, w := range p.SelectedWorkflows {
+		desired = append(desired, workflow{Owner: w.Owner, Repository: w.Repository, Path: w.Path, Ref: w.Ref})
+	}
+	have := []workflow{}
+	if g.GetRestrictedToWorkflows() {
+		for _, s := range g.SelectedWorkflows {
+			have = append(have, parse(s))
+		}
+	}
+	byString := func(w []workflow) {
+		sort.Slice(w, func(i, j int) bool { return w[i].String() < w[j].String() })
+	}
+	byString(desired)
+	byString(have)
+	ignoreCase := cmp.Comparer(func(a, b workflow) bool {
+		return strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Repository, b.Repository) && a.Path == b.Path && a.Ref == b.Ref
+	})
+
+	d := &compare.Diff{}
+	if p.Name != g.GetName() {
+		d.Add("name", p.Name, g.GetName())
+	}
+	if p.Visibility != g.GetVisibility() {
+		d.Add("visibility", p.Visibility, g.GetVisibility())
+	}
+	if p.AllowsPublicRepositories != g.GetAllowsPublicRepositories() {
+		d.Add("allowsPublicRepositories", p.AllowsPublicRepositories, g.GetAllowsPublicRepositories())
+	}
+	if !cmp.Equal(desired, have, cmpopts.EquateEmpty(), ignoreCase) {
+		d.Add("selectedWorkflows", desired, have)
+	}
+	return d
+}
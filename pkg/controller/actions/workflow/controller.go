@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/actions/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotWorkflow     = "managed resource is not a Workflow custom resource"
+	errCreateService   = "failed to create client service"
+	errNoWorkflow      = "either workflowFileName or workflowID must be set"
+	errGetWorkflow     = "cannot get workflow"
+	errWorkflowMissing = "workflow does not exist in repository; workflows are defined by files under .github/workflows"
+	errSetState        = "cannot set workflow state"
+)
+
+// SetupWorkflow adds a controller that reconciles Workflow managed resources.
+func SetupWorkflow(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.WorkflowGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.WorkflowKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WorkflowGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Workflow{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.WorkflowGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.WorkflowKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Workflow); !ok {
+		return nil, errors.New(errNotWorkflow)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then updates the state of a workflow. Workflows are
+// defined by files in the repository, so they are adopted rather than created
+// and are left untouched on deletion.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkflow)
+	}
+
+	// The workflow file is not owned by the managed resource, so there is
+	// nothing to wait for once it is being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	wf, _, err := c.get(ctx, cr)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetWorkflow)
+	}
+
+	cr.Status.AtProvider = v1alpha1.WorkflowObservation{
+		ID:       wf.GetID(),
+		NodeID:   wf.GetNodeID(),
+		Name:     wf.GetName(),
+		Path:     wf.GetPath(),
+		State:    wf.GetState(),
+		BadgeURL: wf.GetBadgeURL(),
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isActive(cr.Spec.ForProvider.State) == isActive(wf.GetState()),
+	}, nil
+}
+
+// Create verifies that the workflow exists and sets its state, since a
+// workflow cannot be created through the API.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkflow)
+	}
+
+	wf, _, err := c.get(ctx, cr)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalCreation{}, errors.New(errWorkflowMissing)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errGetWorkflow)
+	}
+
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(c.setState(ctx, cr, wf.GetID()), errSetState)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkflow)
+	}
+
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(c.setState(ctx, cr, cr.Status.AtProvider.ID), errSetState)
+}
+
+// Delete is a no-op. The workflow keeps whatever state it was pinned to.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.Workflow); !ok {
+		return errors.New(errNotWorkflow)
+	}
+	return nil
+}
+
+func (c *external) get(ctx context.Context, cr *v1alpha1.Workflow) (*github.Workflow, *github.Response, error) {
+	p := cr.Spec.ForProvider
+	switch {
+	case p.WorkflowID != nil:
+		return c.service.Actions.GetWorkflowByID(ctx, p.Owner, p.Repository, *p.WorkflowID)
+	case p.WorkflowFileName != nil:
+		return c.service.Actions.GetWorkflowByFileName(ctx, p.Owner, p.Repository, *p.WorkflowFileName)
+	}
+	return nil, nil, errors.New(errNoWorkflow)
+}
+
+func (c *external) setState(ctx context.Context, cr *v1alpha1.Workflow, id int64) error {
+	p := cr.Spec.ForProvider
+	if isActive(p.State) {
+		_, err := c.service.Actions.EnableWorkflowByID(ctx, p.Owner, p.Repository, id)
+		return err
+	}
+	_, err := c.service.Actions.DisableWorkflowByID(ctx, p.Owner, p.Repository, id)
+	return err
+}
+
+// isActive reports whether the supplied workflow state is active. GitHub
+// reports several disabled states (disabled_manually, disabled_inactivity,
+// disabled_fork) that all satisfy a desired state of disabled_manually.
+func isActive(state string) bool {
+	return state == "active"
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+// defaultAuditLogInterval is the interval at which audit logs are polled
+// unless their ProviderConfig configures one.
+const defaultAuditLogInterval = 1 * time.Minute
+
+const errGetAuditLog = "cannot get audit log"
+
+// auditLogActions are the actions of audit log events that may change
+// managed resources.
+var auditLogActions = []string{"team.edit", "repo.update", "protected_branch"}
+
+var auditLogEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "github_audit_log_events_total",
+	Help: "Number of polled GitHub audit log events, by ProviderConfig and whether they matched managed resources.",
+}, []string{"provider_config", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(auditLogEvents)
+}
+
+// SetupAuditLog adds a controller that polls the audit logs of organizations
+// and triggers reconciles of the managed resources that were changed, if
+// audit log polling is enabled.
+func SetupAuditLog(mgr ctrl.Manager, o controller.Options) error {
+	if !o.Features.Enabled(features.EnableAlphaAuditLogPolling) {
+		return nil
+	}
+	name := "auditlog/" + v1alpha1.ProviderConfigGroupKind
+
+	r := &auditLogReconciler{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		log:    o.Logger.WithValues("controller", name),
+		polled: map[string]*auditLogCursor{},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(kcgitclient.RequeueOnRateLimit(r))
+}
+
+// An auditLogCursor records how far the audit log of an organization was
+// polled.
+type auditLogCursor struct {
+	// since is the time of the latest event seen.
+	since time.Time
+
+	// seen holds the document IDs of the events at since, which are
+	// returned again by the next poll.
+	seen map[string]bool
+}
+
+// An auditLogReconciler polls the audit logs of ProviderConfigs.
+type auditLogReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logging.Logger
+
+	mu     sync.Mutex
+	polled map[string]*auditLogCursor
+}
+
+// Reconcile polls the audit logs of a ProviderConfig.
+func (r *auditLogReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetPC)
+	}
+	a := pc.Spec.AuditLog
+	if a == nil || meta.WasDeleted(pc) {
+		r.forget(pc.GetName())
+		return reconcile.Result{}, nil
+	}
+
+	svc, err := kcgitclient.ConnectProviderConfig(ctx, r.client, pc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errConnect)
+	}
+	for _, org := range a.Organizations {
+		if err := r.poll(ctx, svc, pc.GetName(), org); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	interval := defaultAuditLogInterval
+	if a.Interval != nil {
+		interval = a.Interval.Duration
+	}
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// poll triggers reconciles of the managed resources concerned by the events
+// in the audit log of the supplied organization since it was last polled.
+// The first poll of an organization only records where the next one starts.
+func (r *auditLogReconciler) poll(ctx context.Context, svc *github.Client, pc, org string) error {
+	r.mu.Lock()
+	c, ok := r.polled[pc+"/"+org]
+	if !ok {
+		c = &auditLogCursor{since: time.Now(), seen: map[string]bool{}}
+		r.polled[pc+"/"+org] = c
+	}
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	since, seen := c.since, map[string]bool{}
+	for _, action := range auditLogActions {
+		opts := &github.GetAuditLogOptions{
+			Phrase: github.String(fmt.Sprintf("action:%s created:>=%s", action, c.since.UTC().Format(time.RFC3339))),
+			Order:  github.String("asc"),
+		}
+		for {
+			entries, rsp, err := svc.Organizations.GetAuditLog(ctx, org, opts)
+			if err != nil {
+				return kcgitclient.WrapAPIError(err, errGetAuditLog)
+			}
+			for _, e := range entries {
+				if c.seen[e.GetDocumentID()] {
+					continue
+				}
+				r.trigger(ctx, pc, org, e)
+
+				t := e.GetCreatedAt().Time
+				switch {
+				case t.After(since):
+					since, seen = t, map[string]bool{e.GetDocumentID(): true}
+				case t.Equal(since):
+					seen[e.GetDocumentID()] = true
+				}
+			}
+			if rsp.After == "" {
+				break
+			}
+			opts.After = rsp.After
+		}
+	}
+
+	r.mu.Lock()
+	if !since.Equal(c.since) {
+		c.since, c.seen = since, seen
+	} else {
+		for id := range seen {
+			c.seen[id] = true
+		}
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// trigger triggers reconciles of the managed resources concerned by the
+// supplied audit log event.
+func (r *auditLogReconciler) trigger(ctx context.Context, pc, org string, e *github.AuditEntry) {
+	ev := webhook.Event{Org: org}
+	if repo, ok := e.AdditionalFields["repo"].(string); ok {
+		ev.Owner, ev.Repository, _ = strings.Cut(repo, "/")
+	}
+	if team, ok := e.AdditionalFields["team"].(string); ok {
+		_, ev.Team, _ = strings.Cut(team, "/")
+	}
+
+	n := webhook.Enqueue(ctx, r.client, r.scheme, r.log, ev)
+	r.log.Debug("Polled audit log event", "provider-config", pc, "org", org, "action", e.GetAction(), "actor", e.GetActor(), "matched", n)
+	result := "unmatched"
+	if n > 0 {
+		result = "matched"
+	}
+	auditLogEvents.WithLabelValues(pc, result).Inc()
+}
+
+// forget forgets how far the audit logs of the supplied ProviderConfig were
+// polled.
+func (r *auditLogReconciler) forget(pc string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k := range r.polled {
+		if strings.HasPrefix(k, pc+"/") {
+			delete(r.polled, k)
+		}
+	}
+}
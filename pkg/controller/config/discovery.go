@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+)
+
+// LabelKeyDiscovered is the key of the label of managed resources that were
+// created for discovered GitHub resources. Its value is the name of the
+// ProviderConfig they were discovered with.
+const LabelKeyDiscovered = "github.hasheddan.io/discovered-by"
+
+// discoveryInterval is the interval at which the resources of a
+// ProviderConfig are discovered.
+const discoveryInterval = 10 * time.Minute
+
+const (
+	errConnect         = "cannot connect to GitHub"
+	errListTeams       = "cannot list teams"
+	errListManagedTeam = "cannot list Team managed resources"
+	errCreateTeam      = "cannot create Team managed resource"
+	errDeleteTeam      = "cannot delete Team managed resource"
+)
+
+// SetupDiscovery adds a controller that discovers existing GitHub resources
+// and creates observe-only managed resources for them, if discovery is
+// enabled.
+func SetupDiscovery(mgr ctrl.Manager, o controller.Options) error {
+	if !o.Features.Enabled(features.EnableAlphaDiscovery) {
+		return nil
+	}
+	name := "discovery/" + v1alpha1.ProviderConfigGroupKind
+
+	r := &discoveryReconciler{client: mgr.GetClient(), log: o.Logger.WithValues("controller", name)}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// A discoveryReconciler discovers the GitHub resources of ProviderConfigs.
+type discoveryReconciler struct {
+	client client.Client
+	log    logging.Logger
+}
+
+// Reconcile discovers the GitHub resources of a ProviderConfig.
+func (r *discoveryReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetPC)
+	}
+	d := pc.Spec.Discovery
+	if d == nil || !d.Teams || meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	svc, err := kcgitclient.ConnectProviderConfig(ctx, r.client, pc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errConnect)
+	}
+	for _, org := range d.Organizations {
+		if err := r.discoverTeams(ctx, svc, pc, org); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: discoveryInterval}, nil
+}
+
+// discoverTeams creates a Team managed resource for every team of the
+// supplied organization that is not managed yet, and deletes the managed
+// resources of discovered teams that no longer exist.
+func (r *discoveryReconciler) discoverTeams(ctx context.Context, svc *github.Client, pc *v1alpha1.ProviderConfig, org string) error {
+	teams, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+		return svc.Teams.ListTeams(ctx, org, opts)
+	})
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListTeams)
+	}
+
+	l := &orgv1beta1.TeamList{}
+	if err := r.client.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListManagedTeam)
+	}
+	managed := map[string]bool{}
+	for _, t := range l.Items {
+		if t.Spec.ForProvider.Org == org && t.GetProviderConfigReference() != nil && t.GetProviderConfigReference().Name == pc.GetName() {
+			managed[meta.GetExternalName(&t)] = true
+		}
+	}
+
+	exists := map[string]bool{}
+	for _, t := range teams {
+		exists[t.GetName()] = true
+		if managed[t.GetName()] {
+			continue
+		}
+		cr := discoveredTeam(pc, org, t)
+		if err := r.client.Create(ctx, cr); err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, errCreateTeam)
+		}
+		r.log.Debug("Discovered team", "provider-config", pc.GetName(), "org", org, "team", t.GetName(), "name", cr.GetName())
+	}
+
+	// Discovered teams are observe-only and orphaned when their managed
+	// resources are deleted, so deleting them never deletes a team.
+	for i := range l.Items {
+		t := &l.Items[i]
+		if t.GetLabels()[LabelKeyDiscovered] != pc.GetName() || t.Spec.ForProvider.Org != org || exists[meta.GetExternalName(t)] {
+			continue
+		}
+		if err := r.client.Delete(ctx, t); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errDeleteTeam)
+		}
+		r.log.Debug("Discovered team no longer exists", "provider-config", pc.GetName(), "org", org, "team", meta.GetExternalName(t), "name", t.GetName())
+	}
+	return nil
+}
+
+// discoveredTeam returns an observe-only Team managed resource for the
+// supplied team.
+func discoveredTeam(pc *v1alpha1.ProviderConfig, org string, t *github.Team) *orgv1beta1.Team {
+	cr := &orgv1beta1.Team{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        strings.ReplaceAll(strings.ToLower(org+"-"+t.GetSlug()), "_", "-"),
+			Labels:      map[string]string{LabelKeyDiscovered: pc.GetName()},
+			Annotations: map[string]string{kcgitclient.AnnotationKeyObserveOnly: "true"},
+		},
+		Spec: orgv1beta1.TeamSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &xpv1.Reference{Name: pc.GetName()},
+				DeletionPolicy:          xpv1.DeletionOrphan,
+			},
+			ForProvider: orgv1beta1.TeamParameters{
+				Org:         org,
+				Description: t.Description,
+				Privacy:     t.Privacy,
+			},
+		},
+	}
+	meta.SetExternalName(cr, t.GetName())
+	if p := t.GetParent(); p != nil {
+		cr.Spec.ForProvider.ParentTeam = p.Slug
+	}
+	return cr
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+)
+
+const (
+	timeout = 1 * time.Minute
+
+	errGetPC = "cannot get ProviderConfig"
+)
+
+// DefaultHealthCheckInterval is the default interval at which the credentials
+// of ProviderConfigs are checked.
+const DefaultHealthCheckInterval = 5 * time.Minute
+
+var healthCheckInterval = DefaultHealthCheckInterval
+
+// SetHealthCheckInterval sets the interval at which the credentials of
+// ProviderConfigs are checked.
+func SetHealthCheckInterval(d time.Duration) {
+	healthCheckInterval = d
+}
+
+// SetupHealth adds a controller that checks the credentials of
+// ProviderConfigs periodically and whenever they or the secrets they
+// reference change, so that rotated credentials are used, and invalid ones
+// surfaced, right away.
+func SetupHealth(mgr ctrl.Manager, o controller.Options) error {
+	name := "health/" + v1alpha1.ProviderConfigGroupKind
+
+	r := &healthReconciler{client: mgr.GetClient(), log: o.Logger.WithValues("controller", name), record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.referencing)).
+		Complete(r)
+}
+
+// A healthReconciler checks the credentials of ProviderConfigs.
+type healthReconciler struct {
+	client client.Client
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reasons of the warnings emitted when the token of a ProviderConfig starts
+// to expire soon, or to lack scopes managed resources using it require.
+const (
+	reasonTokenExpiring event.Reason = "TokenExpiring"
+	reasonMissingScopes event.Reason = "MissingScopes"
+)
+
+// Reconcile checks the credentials of a ProviderConfig.
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			kcgitclient.ForgetProviderConfig(req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, errGetPC)
+	}
+	// Failed checks are not retried with backoff here: credentials that are
+	// known to be bad are not tried again until their own backoff expires.
+	expiring := pc.Status.GetCondition(v1alpha1.TypeDegraded).Reason == v1alpha1.ReasonTokenExpiring
+	missing := pc.Status.GetCondition(v1alpha1.TypeScopesMissing)
+	if err := kcgitclient.CheckProviderConfig(ctx, r.client, pc); err != nil {
+		r.log.Debug("ProviderConfig is unhealthy", "name", pc.GetName(), "error", err)
+	}
+	if c := pc.Status.GetCondition(v1alpha1.TypeDegraded); c.Reason == v1alpha1.ReasonTokenExpiring && !expiring {
+		r.record.Event(pc, event.Warning(reasonTokenExpiring, errors.New(c.Message)))
+	}
+	if c := pc.Status.GetCondition(v1alpha1.TypeScopesMissing); c.Reason == v1alpha1.ReasonMissingScopes && c.Message != missing.Message {
+		r.record.Event(pc, event.Warning(reasonMissingScopes, errors.New(c.Message)))
+	}
+	return reconcile.Result{RequeueAfter: healthCheckInterval}, nil
+}
+
+// referencing returns requests for the ProviderConfigs that reference the
+// supplied secret.
+func (r *healthReconciler) referencing(o client.Object) []reconcile.Request {
+	l := &v1alpha1.ProviderConfigList{}
+	if err := r.client.List(context.Background(), l); err != nil {
+		r.log.Debug("Cannot list ProviderConfigs", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, pc := range l.Items {
+		if references(pc.Spec, o) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKey{Name: pc.GetName()}})
+		}
+	}
+	return reqs
+}
+
+// references reports whether the supplied ProviderConfig spec references the
+// supplied secret.
+func references(spec v1alpha1.ProviderConfigSpec, secret client.Object) bool {
+	refs := []*xpv1.SecretKeySelector{spec.Credentials.SecretRef}
+	if spec.TLS != nil {
+		refs = append(refs, spec.TLS.CABundleSecretRef)
+	}
+	for _, ref := range refs {
+		if ref != nil && ref.Namespace == secret.GetNamespace() && ref.Name == secret.GetName() {
+			return true
+		}
+	}
+	return false
+}
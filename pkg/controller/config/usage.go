@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+)
+
+// usageGracePeriod is how old a ProviderConfigUsage must be before it is
+// considered stale, so that usages are not collected while the managed
+// resource that created them is still being created.
+const usageGracePeriod = time.Minute
+
+const (
+	errGetPCU    = "cannot get ProviderConfigUsage"
+	errDeletePCU = "cannot delete ProviderConfigUsage"
+	errGetUser   = "cannot get the resource using the ProviderConfig"
+)
+
+var usagesCollected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "github_provider_config_usages_collected_total",
+	Help: "Number of stale ProviderConfigUsages that were deleted, or would have been in dry-run mode, by ProviderConfig.",
+}, []string{"provider_config"})
+
+func init() {
+	metrics.Registry.MustRegister(usagesCollected)
+}
+
+var usageGCDryRun bool
+
+// SetUsageGCDryRun sets whether stale ProviderConfigUsages are only reported
+// rather than deleted.
+func SetUsageGCDryRun(enabled bool) {
+	usageGCDryRun = enabled
+}
+
+// SetupUsageGC adds a controller that deletes ProviderConfigUsages whose
+// managed resources no longer exist. Usages are usually deleted along with
+// their managed resources by the garbage collector of the API server, but
+// a usage that outlives its managed resource blocks the deletion of its
+// ProviderConfig forever.
+func SetupUsageGC(mgr ctrl.Manager, o controller.Options) error {
+	name := "gc/" + v1alpha1.ProviderConfigUsageGroupKind
+
+	r := &usageReconciler{
+		client: mgr.GetClient(),
+		reader: mgr.GetAPIReader(),
+		scheme: mgr.GetScheme(),
+		log:    o.Logger.WithValues("controller", name),
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfigUsage{}).
+		Complete(r)
+}
+
+// A usageReconciler deletes stale ProviderConfigUsages.
+type usageReconciler struct {
+	client client.Client
+
+	// reader reads managed resources from the API server directly, so that
+	// the provider does not cache every kind of managed resource just to
+	// check whether they exist.
+	reader client.Reader
+	scheme *runtime.Scheme
+	log    logging.Logger
+}
+
+// Reconcile deletes a ProviderConfigUsage if its managed resource no longer
+// exists.
+func (r *usageReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pcu := &v1alpha1.ProviderConfigUsage{}
+	if err := r.client.Get(ctx, req.NamespacedName, pcu); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetPCU)
+	}
+	if age := time.Since(pcu.GetCreationTimestamp().Time); age < usageGracePeriod {
+		return reconcile.Result{RequeueAfter: usageGracePeriod - age}, nil
+	}
+
+	ref := pcu.GetResourceReference()
+	gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+	obj, err := r.scheme.New(gvk)
+	if err != nil {
+		// The kind is not one of ours, or no longer served. Whether its
+		// resources exist cannot be told.
+		return reconcile.Result{}, nil
+	}
+	user := obj.(client.Object)
+	err = r.reader.Get(ctx, client.ObjectKey{Name: ref.Name}, user)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrap(err, errGetUser)
+	}
+	// Usages are named after the UID of their managed resource, so a managed
+	// resource that was recreated with the same name has a usage of its own.
+	if err == nil && string(user.GetUID()) == pcu.GetName() {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.WithValues("name", pcu.GetName(), "provider-config", pcu.ProviderConfigReference.Name, "kind", ref.Kind, "resource", ref.Name)
+	usagesCollected.WithLabelValues(pcu.ProviderConfigReference.Name).Inc()
+	if usageGCDryRun {
+		log.Info("Would delete stale ProviderConfigUsage")
+		return reconcile.Result{}, nil
+	}
+	log.Debug("Deleting stale ProviderConfigUsage")
+	return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(r.client.Delete(ctx, pcu)), errDeletePCU)
+}
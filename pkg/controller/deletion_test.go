@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+const finalizer = "finalizer.managedresource.crossplane.io"
+
+// composedTree returns the objects of a composition that composed a
+// repository and the protections of two of its branches, which are served by
+// the supplied server.
+func composedTree(s *ghserver.Server) []client.Object {
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.SetName("default")
+	pc.Spec.BaseURL = pointer.String(s.URL)
+	pc.Spec.TLS = &apisv1alpha1.TLSConfig{InsecureSkipVerify: true}
+	pc.Spec.Retry = &apisv1alpha1.RetryPolicy{MaxAttempts: pointer.Int(1)}
+	pc.Spec.Credentials = apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+			Key:             "token",
+		}},
+	}
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "crossplane-system"},
+		Data:       map[string][]byte{"token": []byte("t0ken")},
+	}
+
+	repo := &v1alpha1.Repository{}
+	repo.SetName("platform")
+	repo.SetUID("repository-platform")
+	repo.SetFinalizers([]string{finalizer})
+	meta.SetExternalName(repo, "platform")
+	repo.Spec.ForProvider.Owner = "acme"
+	repo.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc.GetName()}
+	repo.SetConditions(xpv1.Available())
+
+	objs := []client.Object{pc, creds, repo}
+	for _, branch := range []string{"main", "release"} {
+		bp := &v1alpha1.BranchProtection{}
+		bp.SetName("platform-" + branch)
+		bp.SetUID(types.UID("protection-" + branch))
+		bp.SetFinalizers([]string{finalizer})
+		bp.Spec.ForProvider = v1alpha1.BranchProtectionParameters{
+			Owner:         "acme",
+			Repository:    "platform",
+			RepositoryRef: &xpv1.Reference{Name: repo.GetName()},
+			Branch:        branch,
+			EnforceAdmins: true,
+		}
+		bp.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc.GetName()}
+		bp.SetConditions(xpv1.Available())
+		objs = append(objs, bp)
+	}
+	return objs
+}
+
+func TestDeleteComposedTree(t *testing.T) {
+	repository := managed.ControllerName(v1alpha1.RepositoryGroupKind)
+	protection := managed.ControllerName(v1alpha1.BranchProtectionGroupKind)
+
+	cases := map[string]struct {
+		reason string
+		// order is the order in which the controllers reconcile the
+		// managed resources in every round.
+		order []string
+	}{
+		"RepositoryFirst": {
+			reason: "Protections whose repository is gone should be considered deleted, so that their finalizers are removed.",
+			order:  []string{repository, protection},
+		},
+		"ProtectionsFirst": {
+			reason: "Deleting the protections before their repository should leave nothing behind.",
+			order:  []string{protection, repository},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			t.Cleanup(func() { kcgitclient.ForgetProviderConfig("default") })
+
+			ctx := context.Background()
+			gh := s.GitHubClient()
+			if _, _, err := gh.Repositories.Create(ctx, "acme", &github.Repository{Name: github.String("platform")}); err != nil {
+				t.Fatal(err)
+			}
+			for _, branch := range []string{"main", "release"} {
+				if _, _, err := gh.Repositories.UpdateBranchProtection(ctx, "acme", "platform", branch, &github.ProtectionRequest{EnforceAdmins: true}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			objs := composedTree(s)
+			mgr := newRecordingManager(t, objs...)
+			o := controller.Options{
+				Logger:                  logging.NewNopLogger(),
+				GlobalRateLimiter:       ratelimiter.NewGlobal(1000),
+				PollInterval:            time.Minute,
+				MaxConcurrentReconciles: 1,
+				Features:                &feature.Flags{},
+			}
+			if err := Setup(mgr, o); err != nil {
+				t.Fatalf("Setup(...): %v", err)
+			}
+			cs := mgr.controllers(t)
+
+			// Deleting the composition deletes all of its resources at once.
+			kube := mgr.GetClient()
+			var mrs []resource.Managed
+			for _, obj := range objs {
+				if mg, ok := obj.(resource.Managed); ok {
+					if err := kube.Delete(ctx, mg); err != nil {
+						t.Fatal(err)
+					}
+					mrs = append(mrs, mg)
+				}
+			}
+
+			// Every reconcile either removes a finalizer or requeues, so a
+			// few rounds are enough to delete the tree.
+			for round := 0; round < 5 && len(mrs) > 0; round++ {
+				for _, c := range tc.order {
+					r := cs[c].do.Interface().(reconcile.Reconciler)
+					for _, mg := range mrs {
+						if managed.ControllerName(mg.GetObjectKind().GroupVersionKind().GroupKind().String()) != c {
+							continue
+						}
+						if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}}); err != nil {
+							t.Fatalf("\n%s\n%s: Reconcile(%s): %v", tc.reason, c, mg.GetName(), err)
+						}
+					}
+				}
+				remaining := mrs[:0]
+				for _, mg := range mrs {
+					err := kube.Get(ctx, types.NamespacedName{Name: mg.GetName()}, mg)
+					if kerrors.IsNotFound(err) {
+						continue
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					remaining = append(remaining, mg)
+				}
+				mrs = remaining
+			}
+
+			for _, mg := range mrs {
+				t.Errorf("\n%s\n%s was not deleted, its finalizers are %v, and its conditions %v", tc.reason, mg.GetName(), mg.GetFinalizers(), mg.GetCondition(xpv1.TypeSynced))
+			}
+			if s.Repository("acme", "platform") != nil {
+				t.Errorf("\n%s\nthe repository was not deleted from GitHub", tc.reason)
+			}
+			for _, branch := range []string{"main", "release"} {
+				if s.Protection("acme", "platform", branch) != nil {
+					t.Errorf("\n%s\nthe protection of %s was not deleted from GitHub", tc.reason, branch)
+				}
+			}
+		})
+	}
+}
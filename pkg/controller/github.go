@@ -17,26 +17,109 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
 
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/actions/jitrunnerconfig"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/actions/organizationoidcsubjectclaim"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/actions/repositoryoidcsubjectclaim"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/actions/runnergroup"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/actions/workflow"
 	"github.com/hasheddan/kc-provider-github/pkg/controller/config"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/announcementbanner"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/customrepositoryrole"
 	"github.com/hasheddan/kc-provider-github/pkg/controller/org/membership"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationcustomproperty"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationmemberprivileges"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationmembershipset"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationroleassignment"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationsettings"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/organizationwebhook"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/projectv2"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/projectv2field"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/securitymanagers"
 	"github.com/hasheddan/kc-provider-github/pkg/controller/org/team"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/teamexternalgroup"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/org/teamrepositoryset"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/branchconflict"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/branchprotection"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/codescanningdefaultsetup"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/discussioncategory"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/issue"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/label"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/labelset"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/milestone"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/projectv2repository"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repository"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repositorycollaborator"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repositorycustompropertyvalues"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repositorydefaults"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/ruleset"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
 )
 
-// Setup creates all Template controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
+// Setup creates all Template controllers with the supplied options and adds
+// them to the supplied manager.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
+		config.SetupHealth,
+		config.SetupDiscovery,
+		config.SetupAuditLog,
+		config.SetupUsageGC,
 		membership.SetupMembership,
 		team.SetupTeam,
+		organizationoidcsubjectclaim.SetupOrganizationOIDCSubjectClaim,
+		repositoryoidcsubjectclaim.SetupRepositoryOIDCSubjectClaim,
+		workflow.SetupWorkflow,
+		runnergroup.SetupRunnerGroup,
+		jitrunnerconfig.SetupJITRunnerConfig,
+		label.SetupLabel,
+		labelset.SetupLabelSet,
+		milestone.SetupMilestone,
+		projectv2.SetupProjectV2,
+		projectv2field.SetupProjectV2Field,
+		projectv2repository.SetupProjectV2Repository,
+		discussioncategory.SetupDiscussionCategory,
+		organizationmembershipset.SetupOrganizationMembershipSet,
+		organizationsettings.SetupOrganizationSettings,
+		organizationwebhook.SetupOrganizationWebhook,
+		organizationmemberprivileges.SetupOrganizationMemberPrivileges,
+		securitymanagers.SetupSecurityManagers,
+		customrepositoryrole.SetupCustomRepositoryRole,
+		organizationcustomproperty.SetupOrganizationCustomProperty,
+		repositorycustompropertyvalues.SetupRepositoryCustomPropertyValues,
+		codescanningdefaultsetup.SetupCodeScanningDefaultSetup,
+		announcementbanner.SetupAnnouncementBanner,
+		teamexternalgroup.SetupTeamExternalGroup,
+		teamrepositoryset.SetupTeamRepositorySet,
+		issue.SetupIssue,
+		repositorydefaults.SetupRepositoryDefaults,
+		organizationroleassignment.SetupOrganizationRoleAssignment,
+		repository.SetupRepository,
+		repositorycollaborator.SetupRepositoryCollaborator,
+		branchconflict.Setup,
+		branchprotection.SetupBranchProtection,
+		ruleset.SetupRuleset,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, o); err != nil {
 			return err
 		}
 	}
+	// The controllers above returned the Sources of their kinds, so their
+	// kinds can now be indexed.
+	if webhook.Enabled(o.Features) {
+		return webhook.IndexFields(context.Background(), mgr.GetFieldIndexer(), mgr.GetScheme())
+	}
 	return nil
 }
+
+// SetupWebhooks adds the conversion webhooks of all APIs with more than one
+// version to the supplied manager.
+func SetupWebhooks(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&orgv1beta1.Team{}).Complete()
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+)
+
+// A recordingManager records the controllers that are added to it instead of
+// running them.
+type recordingManager struct {
+	manager.Manager
+	runnables []manager.Runnable
+}
+
+func (m *recordingManager) Add(r manager.Runnable) error {
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+// newRecordingManager returns a manager that knows all kinds of the provider
+// but never talks to an API server. Its client is a fake that holds the
+// supplied objects.
+func newRecordingManager(t *testing.T, objs ...client.Object) *recordingManager {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for gvk := range s.AllKnownTypes() {
+		scope := meta.RESTScopeRoot
+		if gvk.Group == "" {
+			scope = meta.RESTScopeNamespace
+		}
+		mapper.Add(gvk, scope)
+	}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:1"}, ctrl.Options{
+		Scheme:         s,
+		MapperProvider: func(*rest.Config) (meta.RESTMapper, error) { return mapper, nil },
+		NewClient: func(cache.Cache, *rest.Config, client.Options, ...client.Object) (client.Client, error) {
+			return kube, nil
+		},
+		MetricsBindAddress: "0",
+		EventBroadcaster:   &unsentBroadcaster{EventBroadcaster: record.NewBroadcaster()}, //nolint:staticcheck // There is no other way to keep events off the API server.
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &recordingManager{Manager: mgr}
+}
+
+// An unsentBroadcaster never sends the events it records to the API server.
+type unsentBroadcaster struct {
+	record.EventBroadcaster
+}
+
+func (b *unsentBroadcaster) StartRecordingToSink(record.EventSink) watch.Interface {
+	return watch.NewEmptyWatch()
+}
+
+// A setupController describes a controller that was set up.
+type setupController struct {
+	name                    string
+	maxConcurrentReconciles int
+	// reconcilers of managed resources, and global rate limited reconcilers
+	// reachable from the controller's reconciler.
+	managed []reflect.Value
+	limited []reflect.Value
+	// watches is the number of sources the controller watches.
+	watches int
+	do      reflect.Value
+}
+
+// controllers returns the controllers added to the supplied manager.
+func (m *recordingManager) controllers(t *testing.T) map[string]setupController {
+	t.Helper()
+	cs := map[string]setupController{}
+	for _, r := range m.runnables {
+		v := reflect.ValueOf(r)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		name := v.Elem().FieldByName("Name")
+		do := v.Elem().FieldByName("Do")
+		if !name.IsValid() || !do.IsValid() {
+			continue
+		}
+		cs[name.String()] = setupController{
+			name:                    name.String(),
+			maxConcurrentReconciles: int(v.Elem().FieldByName("MaxConcurrentReconciles").Int()),
+			managed:                 find(do, is(reflect.TypeOf(&managed.Reconciler{}))),
+			limited:                 find(do, is(reflect.TypeOf(&ratelimiter.Reconciler{}))),
+			watches:                 v.Elem().FieldByName("startWatches").Len(),
+			do:                      do,
+		}
+	}
+	return cs
+}
+
+// is returns a function that matches the supplied type.
+func is(t reflect.Type) func(reflect.Type) bool {
+	return func(o reflect.Type) bool { return o == t }
+}
+
+// named returns a function that matches types of the supplied name, such as
+// *team.observationCache, including unexported types of other packages.
+func named(name string) func(reflect.Type) bool {
+	return func(t reflect.Type) bool { return t.String() == name }
+}
+
+// find returns the pointer values of matching types that are reachable from
+// the supplied value through pointers, interfaces, and struct fields.
+func find(v reflect.Value, match func(reflect.Type) bool) []reflect.Value {
+	return walk(v, match, map[uintptr]bool{}, 0)
+}
+
+// walk implements find, following at most 20 pointers.
+func walk(v reflect.Value, match func(reflect.Type) bool, seen map[uintptr]bool, depth int) []reflect.Value {
+	if !v.IsValid() || depth > 20 {
+		return nil
+	}
+	switch v.Kind() { //nolint:exhaustive // Other kinds cannot lead to a reconciler.
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walk(v.Elem(), match, seen, depth)
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		if match(v.Type()) {
+			return []reflect.Value{v}
+		}
+		return walk(v.Elem(), match, seen, depth+1)
+	case reflect.Struct:
+		var found []reflect.Value
+		for i := 0; i < v.NumField(); i++ {
+			found = append(found, walk(v.Field(i), match, seen, depth)...)
+		}
+		return found
+	}
+	return nil
+}
+
+func TestSetupOptions(t *testing.T) {
+	mgr := newRecordingManager(t)
+	global := ratelimiter.NewGlobal(3)
+	o := controller.Options{
+		Logger:                  logging.NewNopLogger(),
+		GlobalRateLimiter:       global,
+		PollInterval:            42 * time.Second,
+		MaxConcurrentReconciles: 7,
+		Features:                &feature.Flags{},
+	}
+	if err := Setup(mgr, o); err != nil {
+		t.Fatalf("Setup(...): %v", err)
+	}
+
+	cs := mgr.controllers(t)
+	if len(cs) == 0 {
+		t.Fatal("Setup(...): no controllers were added to the manager")
+	}
+	for name, c := range cs {
+		if c.maxConcurrentReconciles != o.MaxConcurrentReconciles {
+			t.Errorf("%s: want %d concurrent reconciles, got %d", name, o.MaxConcurrentReconciles, c.maxConcurrentReconciles)
+		}
+		for _, r := range c.managed {
+			if got := time.Duration(r.Elem().FieldByName("pollInterval").Int()); got != o.PollInterval {
+				t.Errorf("%s: want poll interval %s, got %s", name, o.PollInterval, got)
+			}
+		}
+		if len(c.managed) > 0 && len(c.limited) == 0 {
+			t.Errorf("%s: want reconciles limited by the global rate limiter", name)
+		}
+		for _, r := range c.limited {
+			if got := r.Elem().FieldByName("limit"); got.Elem().Pointer() != reflect.ValueOf(global).Pointer() {
+				t.Errorf("%s: want reconciles limited by the global rate limiter, got another", name)
+			}
+		}
+	}
+}
+
+func TestSetupRegistersEveryKind(t *testing.T) {
+	mgr := newRecordingManager(t)
+	o := controller.DefaultOptions()
+
+	// Some kinds are only set up when their feature flag is enabled.
+	o.Features = &feature.Flags{}
+	o.Features.Enable(features.EnableAlphaRepositoryDefaults)
+	if err := Setup(mgr, o); err != nil {
+		t.Fatalf("Setup(...): %v", err)
+	}
+	cs := mgr.controllers(t)
+
+	for gvk := range mgr.GetScheme().AllKnownTypes() {
+		obj, err := mgr.GetScheme().New(gvk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := obj.(resource.Managed); !ok {
+			continue
+		}
+		name := managed.ControllerName(gvk.GroupKind().String())
+		if _, ok := cs[name]; !ok {
+			t.Errorf("Setup(...): kind %s is registered in the scheme, but no controller named %q reconciles it", gvk, name)
+		}
+	}
+}
+
+func TestSetupFeatures(t *testing.T) {
+	team := managed.ControllerName("team.org.github.hasheddan.io")
+	defaults := managed.ControllerName("repositorydefaults.repo.github.hasheddan.io")
+
+	type want struct {
+		// controller must be set up only when the feature is enabled.
+		controller string
+		// check returns a description of the supplied controller.
+		check func(c setupController) string
+	}
+	cases := map[string]struct {
+		reason string
+		flag   feature.Flag
+		want   want
+	}{
+		"RepositoryDefaults": {
+			reason: "The RepositoryDefaults controller should only be set up when its feature is enabled.",
+			flag:   features.EnableAlphaRepositoryDefaults,
+			want:   want{controller: defaults},
+		},
+		"TeamObservationCache": {
+			reason: "Teams should only be observed using a cache when its feature is enabled.",
+			flag:   features.EnableAlphaTeamObservationCache,
+			want: want{
+				controller: team,
+				check: func(c setupController) string {
+					for _, v := range find(c.do, named("*team.observationCache")) {
+						if !v.IsNil() {
+							return "cached"
+						}
+					}
+					return "uncached"
+				},
+			},
+		},
+		"WebhookSource": {
+			reason: "Controllers should only watch webhook events when their feature is enabled.",
+			flag:   features.EnableAlphaWebhookSource,
+			want: want{
+				controller: team,
+				check:      func(c setupController) string { return fmt.Sprintf("%d watches", c.watches) },
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setup := func(enabled bool) map[string]setupController {
+				mgr := newRecordingManager(t)
+				o := controller.DefaultOptions()
+				o.Features = &feature.Flags{}
+				if enabled {
+					o.Features.Enable(tc.flag)
+				}
+				if err := Setup(mgr, o); err != nil {
+					t.Fatalf("Setup(...): %v", err)
+				}
+				return mgr.controllers(t)
+			}
+			off, on := setup(false), setup(true)
+
+			c, ok := on[tc.want.controller]
+			if !ok {
+				t.Fatalf("\n%s\nSetup(...): want controller %q with %s enabled", tc.reason, tc.want.controller, tc.flag)
+			}
+			if tc.want.check == nil {
+				if _, ok := off[tc.want.controller]; ok {
+					t.Errorf("\n%s\nSetup(...): want no controller %q with %s disabled", tc.reason, tc.want.controller, tc.flag)
+				}
+				return
+			}
+			if a, b := tc.want.check(off[tc.want.controller]), tc.want.check(c); a == b {
+				t.Errorf("\n%s\nSetup(...): want %s to change the controller, got %s either way", tc.reason, tc.flag, a)
+			}
+		})
+	}
+}
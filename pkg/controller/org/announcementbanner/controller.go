@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announcementbanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotAnnouncementBanner = "managed resource is not an AnnouncementBanner custom resource"
+	errCreateService         = "failed to create client service"
+	errGetBanner             = "cannot get announcement banner"
+	errSetBanner             = "cannot set announcement banner"
+	errRemoveBanner          = "cannot remove announcement banner"
+	errGitHubDotCom          = "announcement banners are only available on GitHub Enterprise Server; configure the base URL of the ProviderConfig"
+)
+
+// SetupAnnouncementBanner adds a controller that reconciles AnnouncementBanner
+// managed resources.
+func SetupAnnouncementBanner(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.AnnouncementBannerGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.AnnouncementBannerKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AnnouncementBannerGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AnnouncementBanner{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.AnnouncementBannerGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.AnnouncementBannerKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.AnnouncementBanner); !ok {
+		return nil, errors.New(errNotAnnouncementBanner)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either sets or removes an announcement banner. The
+// announcement endpoints are not supported by the GitHub client, so requests
+// are built directly.
+type external struct {
+	service *github.Client
+}
+
+// An announcement is the representation of a banner in the announcement
+// endpoints.
+type announcement struct {
+	Announcement    *string           `json:"announcement"`
+	ExpiresAt       *github.Timestamp `json:"expires_at"`
+	UserDismissible *bool             `json:"user_dismissible,omitempty"`
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AnnouncementBanner)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAnnouncementBanner)
+	}
+
+	// The endpoints do not exist on github.com. Retrying cannot change
+	// that, so we report the resource as unavailable but otherwise settled
+	// until the ProviderConfig points at an Enterprise Server.
+	if kcgitclient.IsGitHubDotCom(c.service) {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(errGitHubDotCom))
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	a := &announcement{}
+	_, err := c.do(ctx, http.MethodGet, cr, nil, a)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetBanner)
+	}
+
+	cr.Status.AtProvider.ExpiresAt = nil
+	if a.ExpiresAt != nil {
+		t := metav1.NewTime(a.ExpiresAt.Time)
+		cr.Status.AtProvider.ExpiresAt = &t
+	}
+
+	p := cr.Spec.ForProvider
+	now := time.Now()
+	if !isActive(a, now) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		// A banner that expired as configured is up to date. A banner that
+		// expired although it should not have, for example because its
+		// expiry was removed from the spec, is applied again.
+		expired := p.ExpiresAt != nil && !p.ExpiresAt.Time.After(now)
+		if expired {
+			cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("the banner expired at %s", p.ExpiresAt.Format(time.RFC3339))))
+		}
+		return managed.ExternalObservation{ResourceExists: expired, ResourceUpToDate: expired}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isUpToDate(p, a),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AnnouncementBanner)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAnnouncementBanner)
+	}
+
+	_, err := c.do(ctx, http.MethodPatch, cr, generate(cr.Spec.ForProvider), nil)
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errSetBanner)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AnnouncementBanner)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAnnouncementBanner)
+	}
+
+	_, err := c.do(ctx, http.MethodPatch, cr, generate(cr.Spec.ForProvider), nil)
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errSetBanner)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AnnouncementBanner)
+	if !ok {
+		return errors.New(errNotAnnouncementBanner)
+	}
+
+	_, err := c.do(ctx, http.MethodDelete, cr, nil, nil)
+	return kcgitclient.DeleteError(ctx, cr, err, errRemoveBanner)
+}
+
+// do sends a request to the announcement endpoint of the organization of the
+// supplied banner or, if it has none, of the enterprise.
+func (c *external) do(ctx context.Context, method string, cr *v1alpha1.AnnouncementBanner, body, v interface{}) (*github.Response, error) {
+	u := "enterprise/settings/announcement"
+	if cr.Spec.ForProvider.Org != nil {
+		u = fmt.Sprintf("orgs/%s/announcement", *cr.Spec.ForProvider.Org)
+	}
+	req, err := c.service.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.service.Do(ctx, req, v)
+}
+
+// isActive reports whether the supplied announcement is shown at the
+// supplied time.
+func isActive(a *announcement, now time.Time) bool {
+	if a.Announcement == nil || *a.Announcement == "" {
+		return false
+	}
+	return a.ExpiresAt == nil || a.ExpiresAt.After(now)
+}
+
+func isUpToDate(p v1alpha1.AnnouncementBannerParameters, a *announcement) bool {
+	if p.Message != *a.Announcement {
+		return false
+	}
+	if p.UserDismissible != nil && *p.UserDismissible != pointer.BoolDeref(a.UserDismissible, false) {
+		return false
+	}
+	switch {
+	case p.ExpiresAt == nil:
+		return a.ExpiresAt == nil
+	case a.ExpiresAt == nil:
+		return false
+	}
+	return p.ExpiresAt.Unix() == a.ExpiresAt.Unix()
+}
+
+func generate(p v1alpha1.AnnouncementBannerParameters) *announcement {
+	a := &announcement{
+		Announcement:    &p.Message,
+		UserDismissible: p.UserDismissible,
+	}
+	if p.ExpiresAt != nil {
+		a.ExpiresAt = &github.Timestamp{Time: p.ExpiresAt.Time}
+	}
+	return a
+}
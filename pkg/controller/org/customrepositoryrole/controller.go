@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customrepositoryrole
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotCustomRepositoryRole = "managed resource is not a CustomRepositoryRole custom resource"
+	errCreateService           = "failed to create client service"
+	errListRoles               = "cannot list custom repository roles"
+	errCreateRole              = "cannot create custom repository role"
+	errUpdateRole              = "cannot update custom repository role"
+	errDeleteRole              = "cannot delete custom repository role"
+	errRolesUnavailable        = "custom repository roles are unavailable for this organization; they require a GitHub Enterprise plan"
+)
+
+// SetupCustomRepositoryRole adds a controller that reconciles
+// CustomRepositoryRole managed resources.
+func SetupCustomRepositoryRole(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.CustomRepositoryRoleGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.CustomRepositoryRoleKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CustomRepositoryRoleGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CustomRepositoryRole{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.CustomRepositoryRoleGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.CustomRepositoryRoleKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.CustomRepositoryRole); !ok {
+		return nil, errors.New(errNotCustomRepositoryRole)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a custom
+// repository role of an organization.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCustomRepositoryRole)
+	}
+
+	roles, _, err := c.service.Organizations.ListCustomRepoRoles(ctx, cr.Spec.ForProvider.Org)
+	if isUnavailable(err) {
+		// Retrying cannot succeed until the organization's plan changes, so
+		// we report the resource as unavailable but otherwise settled and
+		// let the poll interval pick up a plan change.
+		cr.SetConditions(xpv1.Unavailable().WithMessage(errRolesUnavailable))
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListRoles)
+	}
+
+	role := find(roles.CustomRepoRoles, meta.GetExternalName(cr), cr.Spec.ForProvider.Name)
+	if role == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Adopt a role that was matched by name. Reporting it as late
+	// initialized persists the new external name.
+	id := strconv.FormatInt(role.GetID(), 10)
+	adopted := meta.GetExternalName(cr) != id
+	meta.SetExternalName(cr, id)
+	cr.Status.AtProvider.ID = role.GetID()
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        isUpToDate(cr.Spec.ForProvider, role),
+		ResourceLateInitialized: adopted,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCustomRepositoryRole)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	role, _, err := c.service.Organizations.CreateCustomRepoRole(ctx, cr.Spec.ForProvider.Org, generateOptions(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateRole)
+	}
+
+	// The managed reconciler persists the external name with retries, so
+	// that the role is not created again if that fails at first.
+	meta.SetExternalName(cr, strconv.FormatInt(role.GetID(), 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CustomRepositoryRole)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCustomRepositoryRole)
+	}
+
+	_, _, err := c.service.Organizations.UpdateCustomRepoRole(ctx, cr.Spec.ForProvider.Org, cr.Status.AtProvider.ID, generateOptions(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateRole)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CustomRepositoryRole)
+	if !ok {
+		return errors.New(errNotCustomRepositoryRole)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	_, err := c.service.Organizations.DeleteCustomRepoRole(ctx, cr.Spec.ForProvider.Org, cr.Status.AtProvider.ID)
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteRole)
+}
+
+// isUnavailable returns true if the error indicates that the organization
+// cannot use custom repository roles. GitHub answers with a not found for
+// organizations whose plan does not include them.
+func isUnavailable(err error) bool {
+	return kcgitclient.IsNotFound(err)
+}
+
+// find returns the role whose ID is the supplied external name or, because
+// there is no way to get a single role by ID, whose name matches when the
+// role has not been created by this resource yet.
+func find(roles []*github.CustomRepoRoles, externalName, name string) *github.CustomRepoRoles {
+	id, err := strconv.ParseInt(externalName, 10, 64)
+	for _, r := range roles {
+		if err == nil && r.GetID() == id {
+			return r
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	for _, r := range roles {
+		if r.GetName() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func isUpToDate(p v1alpha1.CustomRepositoryRoleParameters, r *github.CustomRepoRoles) bool {
+	if p.Name != r.GetName() || p.BaseRole != r.GetBaseRole() {
+		return false
+	}
+	if p.Description != nil && *p.Description != r.GetDescription() {
+		return false
+	}
+	return cmp.Equal(p.Permissions, r.Permissions,
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func generateOptions(p v1alpha1.CustomRepositoryRoleParameters) *github.CreateOrUpdateCustomRepoRoleOptions {
+	perms := p.Permissions
+	if perms == nil {
+		// GitHub requires the permissions to be present, even when empty.
+		perms = []string{}
+	}
+	return &github.CreateOrUpdateCustomRepoRoleOptions{
+		Name:        pointer.String(p.Name),
+		Description: p.Description,
+		BaseRole:    pointer.String(p.BaseRole),
+		Permissions: perms,
+	}
+}
@@ -18,44 +18,53 @@ package membership
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/google/go-github/v45/github"
+	"github.com/google/go-github/v66/github"
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
 	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
 )
 
 const (
-	errNotMembership = "managed resource is not a MyType custom resource"
-	errCreateService = "failed to create client service"
+	errNotMembership    = "managed resource is not a MyType custom resource"
+	errCreateService    = "failed to create client service"
+	errRemoveMembership = "cannot remove team membership"
 )
 
 // SetupM adds a controller that reconciles MyType managed resources.
-func SetupMembership(mgr ctrl.Manager, l logging.Logger) error {
+func SetupMembership(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.MembershipGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.MembershipKind, "admin:org")
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.MembershipGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube: mgr.GetClient()},
-		),
-		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{
+			kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Membership{}).
-		Complete(r)
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Membership{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.MembershipGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.MembershipKind, r))), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -134,8 +143,6 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotMembership)
 	}
 
-	fmt.Printf("Creating: %+v", cr)
-
 	_, _, err := c.service.Teams.AddTeamMembershipBySlug(
 		ctx,
 		cr.Spec.ForProvider.Org,
@@ -148,13 +155,9 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.Membership)
-	if !ok {
+	if _, ok := mg.(*v1alpha1.Membership); !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotMembership)
 	}
-
-	fmt.Printf("Updating: %+v", cr)
-
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -164,8 +167,6 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotMembership)
 	}
 
-	fmt.Printf("Deleting: %+v", cr)
-
 	_, err := c.service.Teams.RemoveTeamMembershipBySlug(
 		ctx,
 		cr.Spec.ForProvider.Org,
@@ -173,5 +174,5 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		cr.Spec.ForProvider.User,
 	)
 
-	return err
+	return kcgitclient.DeleteError(ctx, cr, err, errRemoveMembership)
 }
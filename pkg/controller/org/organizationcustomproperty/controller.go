@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationcustomproperty
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationCustomProperty = "managed resource is not an OrganizationCustomProperty custom resource"
+	errCreateService                 = "failed to create client service"
+	errGetProperty                   = "cannot get custom property"
+	errPutProperty                   = "cannot create or update custom property"
+	errRemoveProperty                = "cannot remove custom property"
+	errDeletionProtection            = "cannot delete custom property while deletionProtection is true"
+)
+
+// SetupOrganizationCustomProperty adds a controller that reconciles
+// OrganizationCustomProperty managed resources.
+func SetupOrganizationCustomProperty(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationCustomPropertyGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationCustomPropertyKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationCustomPropertyGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationCustomProperty{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationCustomPropertyGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationCustomPropertyKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationCustomProperty); !ok {
+		return nil, errors.New(errNotOrganizationCustomProperty)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or removes a custom
+// property of an organization.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationCustomProperty)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationCustomProperty)
+	}
+
+	prop, _, err := c.service.Organizations.GetCustomProperty(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetProperty)
+	}
+
+	cr.Status.AtProvider.ValuesEditableBy = prop.GetValuesEditableBy()
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isUpToDate(cr.Spec.ForProvider, prop),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationCustomProperty)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationCustomProperty)
+	}
+
+	_, _, err := c.service.Organizations.CreateOrUpdateCustomProperty(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr), generate(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errPutProperty)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationCustomProperty)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationCustomProperty)
+	}
+
+	_, _, err := c.service.Organizations.CreateOrUpdateCustomProperty(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr), generate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errPutProperty)
+}
+
+// Delete removes the property definition, which also removes its values from
+// every repository of the organization.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrganizationCustomProperty)
+	if !ok {
+		return errors.New(errNotOrganizationCustomProperty)
+	}
+	if cr.Spec.ForProvider.DeletionProtection {
+		return errors.New(errDeletionProtection)
+	}
+
+	_, err := c.service.Organizations.RemoveCustomProperty(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
+	return kcgitclient.DeleteError(ctx, cr, err, errRemoveProperty)
+}
+
+// isUpToDate reports whether the supplied property matches the parameters.
+// Allowed values are compared verbatim, including their order and case,
+// because that is how GitHub stores and enforces them.
+func isUpToDate(p v1alpha1.OrganizationCustomPropertyParameters, prop *github.CustomProperty) bool {
+	switch {
+	case p.ValueType != prop.ValueType,
+		pointer.BoolDeref(p.Required, false) != prop.GetRequired(),
+		pointer.StringDeref(p.DefaultValue, "") != prop.GetDefaultValue(),
+		pointer.StringDeref(p.Description, "") != prop.GetDescription():
+		return false
+	}
+	return cmp.Equal(p.AllowedValues, prop.AllowedValues, cmpopts.EquateEmpty())
+}
+
+func generate(p v1alpha1.OrganizationCustomPropertyParameters) *github.CustomProperty {
+	return &github.CustomProperty{
+		ValueType:     p.ValueType,
+		Required:      p.Required,
+		DefaultValue:  p.DefaultValue,
+		Description:   p.Description,
+		AllowedValues: p.AllowedValues,
+	}
+}
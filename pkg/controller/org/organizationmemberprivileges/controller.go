@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationmemberprivileges
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationMemberPrivileges = "managed resource is not an OrganizationMemberPrivileges custom resource"
+	errCreateService                   = "failed to create client service"
+	errGetOrg                          = "cannot get organization"
+	errEditOrg                         = "cannot edit organization"
+	errListSettings                    = "cannot list OrganizationSettings"
+)
+
+// SetupOrganizationMemberPrivileges adds a controller that reconciles
+// OrganizationMemberPrivileges managed resources.
+func SetupOrganizationMemberPrivileges(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationMemberPrivilegesGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationMemberPrivilegesKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationMemberPrivilegesGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationMemberPrivileges{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationMemberPrivilegesGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationMemberPrivilegesKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationMemberPrivileges); !ok {
+		return nil, errors.New(errNotOrganizationMemberPrivileges)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An external observes, then updates the member privileges of an organization.
+type external struct {
+	service *github.Client
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMemberPrivileges)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationMemberPrivileges)
+	}
+
+	// An organization cannot be deleted through its privileges, so there is
+	// nothing to wait for once the managed resource is being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if err := c.detectConflicts(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListSettings)
+	}
+
+	p := cr.Spec.ForProvider
+	org, _, err := c.service.Organizations.Get(ctx, p.Org)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetOrg)
+	}
+
+	cr.Status.AtProvider.TwoFactorRequirementEnabled = org.GetTwoFactorRequirementEnabled()
+
+	permission := org.DefaultRepoPermission
+	if permission == nil {
+		permission = org.DefaultRepoSettings
+	}
+	upToDate := (p.DefaultRepositoryPermission == nil || *p.DefaultRepositoryPermission == pointer.StringDeref(permission, "")) &&
+		(p.MembersCanCreateRepositories == nil || *p.MembersCanCreateRepositories == org.GetMembersCanCreateRepos())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create applies the privileges. Every organization already has them, so
+// there is nothing to create.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMemberPrivileges)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationMemberPrivileges)
+	}
+
+	_, _, err := c.service.Organizations.Edit(ctx, cr.Spec.ForProvider.Org, generate(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errEditOrg)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMemberPrivileges)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationMemberPrivileges)
+	}
+
+	_, _, err := c.service.Organizations.Edit(ctx, cr.Spec.ForProvider.Org, generate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditOrg)
+}
+
+// Delete is a no-op. The organization keeps its current privileges.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.OrganizationMemberPrivileges); !ok {
+		return errors.New(errNotOrganizationMemberPrivileges)
+	}
+	return nil
+}
+
+// detectConflicts sets the Conflict condition of the supplied managed resource
+// depending on whether any OrganizationSettings manages one of its fields for
+// the same organization.
+func (c *external) detectConflicts(ctx context.Context, cr *v1alpha1.OrganizationMemberPrivileges) error {
+	l := &v1alpha1.OrganizationSettingsList{}
+	if err := c.kube.List(ctx, l); err != nil {
+		return err
+	}
+
+	p := cr.Spec.ForProvider
+	var conflicts []string
+	for _, s := range l.Items {
+		sp := s.Spec.ForProvider
+		if sp.Org != p.Org {
+			continue
+		}
+		if (p.DefaultRepositoryPermission != nil && sp.DefaultRepositoryPermission != nil) ||
+			(p.MembersCanCreateRepositories != nil && sp.MembersCanCreateRepositories != nil) {
+			conflicts = append(conflicts, s.GetName())
+		}
+	}
+
+	if len(conflicts) == 0 {
+		cr.SetConditions(common.NotConflicting())
+		return nil
+	}
+	cr.SetConditions(common.Conflicting(fmt.Sprintf("OrganizationSettings %s also manage member privileges of organization %s", strings.Join(conflicts, ", "), p.Org)))
+	return nil
+}
+
+func generate(p v1alpha1.OrganizationMemberPrivilegesParameters) *github.Organization {
+	return &github.Organization{
+		DefaultRepoPermission: p.DefaultRepositoryPermission,
+		MembersCanCreateRepos: p.MembersCanCreateRepositories,
+	}
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationmembershipset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationMembershipSet = "managed resource is not an OrganizationMembershipSet custom resource"
+	errCreateService                = "failed to create client service"
+	errListMembers                  = "cannot list members"
+	errListInvitations              = "cannot list pending invitations"
+	errGetUser                      = "cannot get authenticated user"
+	errEditMembership               = "cannot set membership of user %q"
+	errRemoveMembership             = "cannot remove user %q"
+	errCancelInvitation             = "cannot cancel invitation of user %q"
+	errRemovalsNotAllowed           = "pruning would remove members, which requires allowRemovals"
+	errDeleteNotAllowed             = "deleting would remove members, which requires allowRemovals or an Orphan deletion policy"
+	errFmtFailed                    = "cannot sync membership of %d users"
+)
+
+// SetupOrganizationMembershipSet adds a controller that reconciles
+// OrganizationMembershipSet managed resources.
+func SetupOrganizationMembershipSet(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationMembershipSetGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationMembershipSetKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationMembershipSetGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationMembershipSet{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationMembershipSetGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationMembershipSetKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationMembershipSet); !ok {
+		return nil, errors.New(errNotOrganizationMembershipSet)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then syncs the members of an organization with an
+// OrganizationMembershipSet.
+type external struct {
+	service *github.Client
+}
+
+const (
+	roleAdmin  = "admin"
+	roleMember = "member"
+)
+
+// invitationRoles are the roles of pending invitations to an organization,
+// by the role the invited user will have.
+var invitationRoles = map[string]string{
+	"admin":         roleAdmin,
+	"direct_member": roleMember,
+}
+
+// A membership is who is a member of an organization, keyed by lowercase
+// login.
+type membership struct {
+	// roles are the roles of the members.
+	roles map[string]string
+
+	// logins are the logins of the members, as GitHub spells them.
+	logins map[string]string
+
+	// invitations are the pending invitations with a login.
+	invitations map[string]*github.Invitation
+
+	// self is the lowercase login of the authenticated user, if any.
+	self string
+}
+
+// admins returns the number of admins.
+func (m *membership) admins() int {
+	n := 0
+	for _, r := range m.roles {
+		if r == roleAdmin {
+			n++
+		}
+	}
+	return n
+}
+
+// A plan is what it takes to sync an organization with a set.
+type plan struct {
+	edit      []v1alpha1.OrganizationMember
+	remove    []string
+	protected []string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMembershipSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationMembershipSet)
+	}
+
+	m, err := c.list(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	pl := diff(cr, m)
+
+	o := &cr.Status.AtProvider
+	o.Total = len(m.roles)
+	o.Admins = m.admins()
+	o.Pending = len(m.invitations)
+	o.OutOfSync = len(pl.edit) + len(pl.remove)
+	o.Protected = pl.protected
+	if o.OutOfSync == 0 {
+		o.Failed = nil
+	}
+
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: len(removals(cr, m)) > 0}, nil
+	}
+
+	// The set exists for as long as any of its users is a member, or is
+	// invited.
+	exists := false
+	for _, u := range cr.Spec.ForProvider.Members {
+		key := strings.ToLower(u.User)
+		if _, ok := m.roles[key]; ok {
+			exists = true
+		}
+		if _, ok := m.invitations[key]; ok {
+			exists = true
+		}
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: o.OutOfSync == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMembershipSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationMembershipSet)
+	}
+
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMembershipSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationMembershipSet)
+	}
+
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// Delete removes the users of the set from the organization, and cancels
+// their pending invitations. Like pruning, this requires allowRemovals, and
+// never removes the authenticated user or the last admin.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrganizationMembershipSet)
+	if !ok {
+		return errors.New(errNotOrganizationMembershipSet)
+	}
+
+	m, err := c.list(ctx, cr)
+	if err != nil {
+		return err
+	}
+	remove := removals(cr, m)
+	if len(remove) > 0 && !cr.Spec.ForProvider.AllowRemovals {
+		return errors.New(errDeleteNotAllowed)
+	}
+	org := cr.Spec.ForProvider.Org
+	for _, key := range remove {
+		if inv, ok := m.invitations[key]; ok {
+			_, err := c.service.Organizations.CancelInvite(ctx, org, inv.GetID())
+			if err := kcgitclient.DeleteError(ctx, cr, err, fmt.Sprintf(errCancelInvitation, inv.GetLogin())); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err := c.service.Organizations.RemoveOrgMembership(ctx, m.logins[key], org)
+		if err := kcgitclient.DeleteError(ctx, cr, err, fmt.Sprintf(errRemoveMembership, m.logins[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync sets the roles of, or invites, the users of the set and optionally
+// removes members that are not part of it. A user that cannot be synced is
+// recorded and skipped, so that one bad entry, such as a user that does not
+// exist, does not hold up the others. A rate limit or an exhausted rate
+// budget stops the sync, which later picks up where it left off.
+func (c *external) sync(ctx context.Context, cr *v1alpha1.OrganizationMembershipSet) error {
+	m, err := c.list(ctx, cr)
+	if err != nil {
+		return err
+	}
+	pl := diff(cr, m)
+
+	org := cr.Spec.ForProvider.Org
+	var failed []v1alpha1.OrganizationMemberFailure
+	for _, u := range pl.edit {
+		_, _, err := c.service.Organizations.EditOrgMembership(ctx, u.User, org, &github.Membership{Role: github.String(u.Role)})
+		if kcgitclient.IsRateLimit(err) || kcgitclient.IsBudgetExceeded(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errEditMembership, u.User))
+		}
+		if err != nil {
+			failed = append(failed, v1alpha1.OrganizationMemberFailure{User: u.User, Message: kcgitclient.WrapAPIError(err, fmt.Sprintf(errEditMembership, u.User)).Error()})
+		}
+	}
+	if len(pl.remove) > 0 && !cr.Spec.ForProvider.AllowRemovals {
+		cr.Status.AtProvider.Failed = failed
+		return errors.New(errRemovalsNotAllowed)
+	}
+	for _, login := range pl.remove {
+		_, err := c.service.Organizations.RemoveOrgMembership(ctx, login, org)
+		if kcgitclient.IsRateLimit(err) || kcgitclient.IsBudgetExceeded(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errRemoveMembership, login))
+		}
+		if err != nil && !kcgitclient.IsNotFound(err) {
+			failed = append(failed, v1alpha1.OrganizationMemberFailure{User: login, Message: kcgitclient.WrapAPIError(err, fmt.Sprintf(errRemoveMembership, login)).Error()})
+		}
+	}
+
+	cr.Status.AtProvider.Failed = failed
+	if len(failed) > 0 {
+		return errors.Errorf(errFmtFailed, len(failed))
+	}
+	return nil
+}
+
+// list returns the members and pending invitations of the organization.
+func (c *external) list(ctx context.Context, cr *v1alpha1.OrganizationMembershipSet) (*membership, error) {
+	org := cr.Spec.ForProvider.Org
+	m := &membership{roles: map[string]string{}, logins: map[string]string{}, invitations: map[string]*github.Invitation{}}
+	for _, role := range []string{roleAdmin, roleMember} {
+		role := role
+		users, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+			return c.service.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{Role: role, ListOptions: *opts})
+		})
+		if err != nil {
+			return nil, kcgitclient.WrapAPIError(err, errListMembers)
+		}
+		for _, u := range users {
+			m.roles[strings.ToLower(u.GetLogin())] = role
+			m.logins[strings.ToLower(u.GetLogin())] = u.GetLogin()
+		}
+	}
+
+	invitations, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Invitation, *github.Response, error) {
+		return c.service.Organizations.ListPendingOrgInvitations(ctx, org, opts)
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListInvitations)
+	}
+	for _, inv := range invitations {
+		// Users invited by email have no login until they accept.
+		if inv.GetLogin() != "" {
+			m.invitations[strings.ToLower(inv.GetLogin())] = inv
+		}
+	}
+
+	// GitHub App installations are not users, and cannot get the
+	// authenticated user. They cannot be members either.
+	u, _, err := c.service.Users.Get(ctx, "")
+	switch {
+	case kcgitclient.IsForbidden(err):
+	case err != nil:
+		return nil, kcgitclient.WrapAPIError(err, errGetUser)
+	default:
+		m.self = strings.ToLower(u.GetLogin())
+	}
+	return m, nil
+}
+
+// diff returns the plan to sync the supplied membership with the set. The
+// authenticated user and the last admin are neither removed nor demoted.
+func diff(cr *v1alpha1.OrganizationMembershipSet, m *membership) plan {
+	pl := plan{}
+	desired := map[string]bool{}
+	var demote []v1alpha1.OrganizationMember
+	for _, u := range cr.Spec.ForProvider.Members {
+		key := strings.ToLower(u.User)
+		desired[key] = true
+		role, member := m.roles[key]
+		switch {
+		case member && role == u.Role:
+		case member && role == roleAdmin:
+			demote = append(demote, u)
+		case member:
+			pl.edit = append(pl.edit, u)
+		case invitationRoles[m.invitations[key].GetRole()] == u.Role:
+		default:
+			pl.edit = append(pl.edit, u)
+		}
+	}
+	var remove []string
+	if cr.Spec.ForProvider.Prune {
+		for key := range m.roles {
+			if !desired[key] {
+				remove = append(remove, key)
+			}
+		}
+	}
+	sort.Strings(remove)
+
+	// Of the admins that would go, keep the authenticated user, and enough
+	// others that one admin remains.
+	admins := m.admins()
+	for _, u := range cr.Spec.ForProvider.Members {
+		if m.roles[strings.ToLower(u.User)] == roleMember && u.Role == roleAdmin {
+			admins++
+		}
+	}
+	keep := func(key string) bool {
+		if key == m.self {
+			return true
+		}
+		if m.roles[key] != roleAdmin {
+			return false
+		}
+		if admins == 1 {
+			return true
+		}
+		admins--
+		return false
+	}
+	for _, u := range demote {
+		key := strings.ToLower(u.User)
+		if keep(key) {
+			pl.protected = append(pl.protected, m.logins[key])
+			continue
+		}
+		pl.edit = append(pl.edit, u)
+	}
+	for _, key := range remove {
+		if keep(key) {
+			pl.protected = append(pl.protected, m.logins[key])
+			continue
+		}
+		pl.remove = append(pl.remove, m.logins[key])
+	}
+	return pl
+}
+
+// removals returns the lowercase logins of the users of the set that
+// deleting it removes from the organization, or whose invitations it
+// cancels. The authenticated user and the last admin are kept.
+func removals(cr *v1alpha1.OrganizationMembershipSet, m *membership) []string {
+	admins := m.admins()
+	var remove []string
+	for _, u := range cr.Spec.ForProvider.Members {
+		key := strings.ToLower(u.User)
+		if _, ok := m.invitations[key]; ok {
+			remove = append(remove, key)
+			continue
+		}
+		role, member := m.roles[key]
+		if !member || key == m.self || (role == roleAdmin && admins == 1) {
+			continue
+		}
+		if role == roleAdmin {
+			admins--
+		}
+		remove = append(remove, key)
+	}
+	return remove
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationmembershipset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+const org = "acme"
+
+func set(o ...func(*v1alpha1.OrganizationMembershipSetParameters)) *v1alpha1.OrganizationMembershipSet {
+	cr := &v1alpha1.OrganizationMembershipSet{}
+	cr.Spec.ForProvider.Org = org
+	for _, fn := range o {
+		fn(&cr.Spec.ForProvider)
+	}
+	return cr
+}
+
+func withMember(user, role string) func(*v1alpha1.OrganizationMembershipSetParameters) {
+	return func(p *v1alpha1.OrganizationMembershipSetParameters) {
+		p.Members = append(p.Members, v1alpha1.OrganizationMember{User: user, Role: role})
+	}
+}
+
+func withPrune(p *v1alpha1.OrganizationMembershipSetParameters) { p.Prune = true }
+
+func withAllowRemovals(p *v1alpha1.OrganizationMembershipSetParameters) { p.AllowRemovals = true }
+
+func TestSafety(t *testing.T) {
+	type want struct {
+		err       error
+		members   map[string]string
+		protected []string
+	}
+	cases := map[string]struct {
+		reason  string
+		members map[string]string
+		cr      *v1alpha1.OrganizationMembershipSet
+		// delete is whether the set is deleted rather than synced.
+		delete bool
+		want   want
+	}{
+		"PruneRemovalsOffByDefault": {
+			reason:  "Pruning should not remove members unless removals are explicitly allowed.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("alice", roleAdmin), withPrune),
+			want: want{
+				err:     errors.New(errRemovalsNotAllowed),
+				members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			},
+		},
+		"Prune": {
+			reason:  "Pruning should remove members that are not part of the set once removals are allowed.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("alice", roleAdmin), withPrune, withAllowRemovals),
+			want:    want{members: map[string]string{"alice": roleAdmin}},
+		},
+		"PruneKeepsAuthenticatedUser": {
+			reason:  "Pruning should never remove the authenticated user.",
+			members: map[string]string{ghserver.Login: roleAdmin, "alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("bob", roleMember), withPrune, withAllowRemovals),
+			want: want{
+				members:   map[string]string{ghserver.Login: roleAdmin, "bob": roleMember},
+				protected: []string{ghserver.Login},
+			},
+		},
+		"PruneKeepsLastAdmin": {
+			reason:  "Pruning should never remove the last admin.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("bob", roleMember), withPrune, withAllowRemovals),
+			want: want{
+				members:   map[string]string{"alice": roleAdmin, "bob": roleMember},
+				protected: []string{"alice"},
+			},
+		},
+		"PruneKeepsOneOfSeveralAdmins": {
+			reason:  "Pruning should remove all but one of the admins that are not part of the set.",
+			members: map[string]string{"alice": roleAdmin, "carol": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("bob", roleMember), withPrune, withAllowRemovals),
+			want: want{
+				members:   map[string]string{"carol": roleAdmin, "bob": roleMember},
+				protected: []string{"carol"},
+			},
+		},
+		"PrunePromotedAdmin": {
+			reason:  "An admin may be removed if the set promotes another member to admin.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("bob", roleAdmin), withPrune, withAllowRemovals),
+			want:    want{members: map[string]string{"bob": roleAdmin}},
+		},
+		"NeverDemoteLastAdmin": {
+			reason:  "The last admin should never be demoted.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("alice", roleMember)),
+			want: want{
+				members:   map[string]string{"alice": roleAdmin, "bob": roleMember},
+				protected: []string{"alice"},
+			},
+		},
+		"NeverDemoteAuthenticatedUser": {
+			reason:  "The authenticated user should never be demoted.",
+			members: map[string]string{ghserver.Login: roleAdmin, "alice": roleAdmin},
+			cr:      set(withMember(ghserver.Login, roleMember)),
+			want: want{
+				members:   map[string]string{ghserver.Login: roleAdmin, "alice": roleAdmin},
+				protected: []string{ghserver.Login},
+			},
+		},
+		"DeleteRemovalsOffByDefault": {
+			reason:  "Deleting should not remove members unless removals are explicitly allowed.",
+			members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember("bob", roleMember)),
+			delete:  true,
+			want: want{
+				err:     errors.New(errDeleteNotAllowed),
+				members: map[string]string{"alice": roleAdmin, "bob": roleMember},
+			},
+		},
+		"DeleteKeepsAuthenticatedUserAndLastAdmin": {
+			reason:  "Deleting should never remove the authenticated user or the last admin.",
+			members: map[string]string{ghserver.Login: roleMember, "alice": roleAdmin, "bob": roleMember},
+			cr:      set(withMember(ghserver.Login, roleMember), withMember("alice", roleAdmin), withMember("bob", roleMember), withAllowRemovals),
+			delete:  true,
+			want:    want{members: map[string]string{ghserver.Login: roleMember, "alice": roleAdmin}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			for login, role := range tc.members {
+				s.AddMember(org, login, role)
+			}
+			e := &external{service: s.GitHubClient()}
+			ctx := context.Background()
+
+			var err error
+			if tc.delete {
+				err = e.Delete(ctx, tc.cr)
+			} else {
+				_, err = e.Update(ctx, tc.cr)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nsync(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			got := map[string]string{}
+			for login := range tc.members {
+				if role := s.Member(org, login); role != "" {
+					got[login] = role
+				}
+			}
+			if diff := cmp.Diff(tc.want.members, got); diff != "" {
+				t.Errorf("\n%s\nsync(...): -want members, +got members:\n%s", tc.reason, diff)
+			}
+
+			if tc.delete {
+				return
+			}
+
+			// Protected members are reported when the set is observed.
+			if _, err := e.Observe(ctx, tc.cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.protected, tc.cr.Status.AtProvider.Protected); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want protected, +got protected:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationroleassignment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationRoleAssignment = "managed resource is not an OrganizationRoleAssignment custom resource"
+	errCreateService                 = "failed to create client service"
+	errListRoles                     = "cannot list organization roles"
+	errListAssignees                 = "cannot list assignees of organization role"
+	errAssignRole                    = "cannot assign organization role"
+	errRevokeRole                    = "cannot revoke organization role"
+	errNoRole                        = "either roleID or roleName must be set"
+	errRoleNotFound                  = "organization role does not exist"
+	errNoAssignee                    = "exactly one of user and team must be set"
+	errRolesUnavailable              = "organization roles are unavailable for this organization; they require a GitHub Enterprise plan"
+)
+
+// SetupOrganizationRoleAssignment adds a controller that reconciles
+// OrganizationRoleAssignment managed resources.
+func SetupOrganizationRoleAssignment(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationRoleAssignmentGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationRoleAssignmentKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationRoleAssignmentGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationRoleAssignment{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationRoleAssignmentGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationRoleAssignmentKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationRoleAssignment); !ok {
+		return nil, errors.New(errNotOrganizationRoleAssignment)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then assigns an organization role to or revokes it from
+// a user or a team.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationRoleAssignment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationRoleAssignment)
+	}
+	p := cr.Spec.ForProvider
+	if (p.User == nil) == (p.Team == nil) {
+		return managed.ExternalObservation{}, errors.New(errNoAssignee)
+	}
+
+	roles, _, err := c.service.Organizations.ListRoles(ctx, p.Org)
+	if kcgitclient.IsNotFound(err) {
+		// Retrying cannot succeed until the organization's plan changes, so
+		// we report the resource as unavailable but otherwise settled and
+		// let the poll interval pick up a plan change.
+		cr.SetConditions(xpv1.Unavailable().WithMessage(errRolesUnavailable))
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListRoles)
+	}
+
+	role, err := find(p, roles.CustomRepoRoles)
+	if err != nil {
+		// A role that no longer exists is not assigned to anyone.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider.RoleID = role.GetID()
+	cr.Status.AtProvider.RoleName = role.GetName()
+
+	assigned, err := c.isAssigned(ctx, p, role.GetID())
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListAssignees)
+	}
+	if assigned {
+		cr.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   assigned,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationRoleAssignment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationRoleAssignment)
+	}
+	p := cr.Spec.ForProvider
+	id := cr.Status.AtProvider.RoleID
+
+	var err error
+	if p.Team != nil {
+		_, err = c.service.Organizations.AssignOrgRoleToTeam(ctx, p.Org, *p.Team, id)
+	} else {
+		_, err = c.service.Organizations.AssignOrgRoleToUser(ctx, p.Org, *p.User, id)
+	}
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errAssignRole)
+}
+
+// Update is a no-op. An assignment has no properties besides the role and the
+// assignee, which identify it.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationRoleAssignment); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationRoleAssignment)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrganizationRoleAssignment)
+	if !ok {
+		return errors.New(errNotOrganizationRoleAssignment)
+	}
+	p := cr.Spec.ForProvider
+	id := cr.Status.AtProvider.RoleID
+
+	var err error
+	if p.Team != nil {
+		_, err = c.service.Organizations.RemoveOrgRoleFromTeam(ctx, p.Org, *p.Team, id)
+	} else {
+		_, err = c.service.Organizations.RemoveOrgRoleFromUser(ctx, p.Org, *p.User, id)
+	}
+	return kcgitclient.DeleteError(ctx, cr, err, errRevokeRole)
+}
+
+// isAssigned reports whether the role with the supplied ID is assigned to the
+// user or team of the supplied parameters.
+func (c *external) isAssigned(ctx context.Context, p v1alpha1.OrganizationRoleAssignmentParameters, id int64) (bool, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var names []string
+		var res *github.Response
+		var err error
+		if p.Team != nil {
+			var teams []*github.Team
+			teams, res, err = c.service.Organizations.ListTeamsAssignedToOrgRole(ctx, p.Org, id, opts)
+			for _, t := range teams {
+				names = append(names, t.GetSlug())
+			}
+		} else {
+			var users []*github.User
+			users, res, err = c.service.Organizations.ListUsersAssignedToOrgRole(ctx, p.Org, id, opts)
+			for _, u := range users {
+				names = append(names, u.GetLogin())
+			}
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, n := range names {
+			if (p.Team != nil && n == *p.Team) || (p.User != nil && strings.EqualFold(n, *p.User)) {
+				return true, nil
+			}
+		}
+		if res.NextPage == 0 {
+			return false, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+// find returns the role of the supplied parameters, matching it by ID if one
+// is set and by name otherwise.
+func find(p v1alpha1.OrganizationRoleAssignmentParameters, roles []*github.CustomOrgRoles) (*github.CustomOrgRoles, error) {
+	if p.RoleID == nil && p.RoleName == nil {
+		return nil, errors.New(errNoRole)
+	}
+	for _, r := range roles {
+		if (p.RoleID != nil && r.GetID() == *p.RoleID) || (p.RoleID == nil && r.GetName() == *p.RoleName) {
+			return r, nil
+		}
+	}
+	return nil, errors.New(errRoleNotFound)
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationsettings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationSettings = "managed resource is not an OrganizationSettings custom resource"
+	errCreateService           = "failed to create client service"
+	errGetOrg                  = "cannot get organization"
+	errEditOrg                 = "cannot edit organization"
+
+	errFmtInvalidBranch = "defaultRepositoryBranch %q is not a valid branch name: %s"
+	errFmtInternalPlan  = "membersCanCreateInternalRepositories requires an enterprise organization, but the plan of this organization is %q"
+
+	reasonSecurityDefaultDrift event.Reason = "SecurityDefaultDrift"
+
+	planEnterprise = "enterprise"
+)
+
+// SetupOrganizationSettings adds a controller that reconciles
+// OrganizationSettings managed resources.
+func SetupOrganizationSettings(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationSettingsGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationSettingsKind, "admin:org")
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationSettingsGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient(), recorder: recorder})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(recorder))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationSettings{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationSettingsGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationSettingsKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube     client.Client
+	recorder event.Recorder
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationSettings); !ok {
+		return nil, errors.New(errNotOrganizationSettings)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, recorder: c.recorder}, nil
+}
+
+// An external observes, then updates the settings of an organization.
+type external struct {
+	service  *github.Client
+	recorder event.Recorder
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationSettings)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationSettings)
+	}
+
+	// An organization cannot be deleted through its settings, so there is
+	// nothing to wait for once the managed resource is being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	org, err := c.getOrg(ctx, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetOrg)
+	}
+
+	cr.Status.AtProvider.ID = org.GetID()
+	cr.Status.AtProvider.NodeID = org.GetNodeID()
+	cr.Status.AtProvider.Plan = org.GetPlan().GetName()
+
+	li := lateInitialize(&cr.Spec.ForProvider, org)
+	d := diff(cr.Spec.ForProvider, org)
+	upToDate := d.UpToDate()
+	if !upToDate {
+		c.reportSecurityDefaultDrift(ctx, cr, &org.Organization)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: li,
+		Diff:                    d.String(),
+	}, nil
+}
+
+// Create applies the settings. Every organization already has settings, so
+// there is nothing to create.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationSettings)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationSettings)
+	}
+
+	if err := validate(cr.Spec.ForProvider, cr.Status.AtProvider.Plan); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	err := c.editOrg(ctx, cr.Spec.ForProvider.Org, generate(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errEditOrg)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationSettings)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationSettings)
+	}
+
+	if err := validate(cr.Spec.ForProvider, cr.Status.AtProvider.Plan); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	err := c.editOrg(ctx, cr.Spec.ForProvider.Org, generate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditOrg)
+}
+
+// Delete is a no-op. The organization keeps its current settings.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.OrganizationSettings); !ok {
+		return errors.New(errNotOrganizationSettings)
+	}
+	return nil
+}
+
+// reportSecurityDefaultDrift records a warning event for every security
+// default for new repositories that no longer matches the parameters. These
+// are commonly changed through the UI, so the event names who changed them
+// and when if the audit log of the organization is available.
+func (c *external) reportSecurityDefaultDrift(ctx context.Context, cr *v1alpha1.OrganizationSettings, org *github.Organization) {
+	p := cr.Spec.ForProvider
+	defaults := []struct {
+		field    string
+		category string
+		want     *bool
+		got      *bool
+	}{
+		{"dependencyGraphEnabledForNewRepositories", "dependency_graph_new_repos", p.DependencyGraphEnabledForNewRepositories, org.DependencyGraphEnabledForNewRepos},
+		{"dependabotAlertsEnabledForNewRepositories", "dependabot_alerts_new_repos", p.DependabotAlertsEnabledForNewRepositories, org.DependabotAlertsEnabledForNewRepos},
+		{"dependabotSecurityUpdatesEnabledForNewRepositories", "dependabot_security_updates_new_repos", p.DependabotSecurityUpdatesEnabledForNewRepositories, org.DependabotSecurityUpdatesEnabledForNewRepos},
+		{"secretScanningEnabledForNewRepositories", "secret_scanning_new_repos", p.SecretScanningEnabledForNewRepositories, org.SecretScanningEnabledForNewRepos},
+		{"secretScanningPushProtectionEnabledForNewRepositories", "secret_scanning_push_protection_new_repos", p.SecretScanningPushProtectionEnabledForNewRepositories, org.SecretScanningPushProtectionEnabledForNewRepos},
+	}
+	for _, d := range defaults {
+		if boolUpToDate(d.want, d.got) {
+			continue
+		}
+		msg := fmt.Sprintf("%s was changed to %t outside of the provider", d.field, pointer.BoolDeref(d.got, false))
+		if e := c.lastChange(ctx, p.Org, d.category); e != nil {
+			msg += fmt.Sprintf(" by %s at %s", e.GetActor(), e.GetCreatedAt().Format(time.RFC3339))
+		}
+		c.recorder.Event(cr, event.Warning(reasonSecurityDefaultDrift, errors.New(msg)))
+	}
+}
+
+// lastChange returns the most recent audit log entry of the supplied action
+// category, or nil if there is none or the audit log is unavailable. Only
+// enterprise organizations have an audit log API.
+func (c *external) lastChange(ctx context.Context, org, category string) *github.AuditEntry {
+	entries, _, err := c.service.Organizations.GetAuditLog(ctx, org, &github.GetAuditLogOptions{
+		Phrase:            pointer.String("action:" + category),
+		ListCursorOptions: github.ListCursorOptions{PerPage: 1},
+	})
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+	return entries[0]
+}
+
+// defaultRepositoryPermission returns the default repository permission of
+// the supplied organization. GitHub reports it under a different field than
+// the one it is edited through.
+func defaultRepositoryPermission(org *githubOrganization) *string {
+	if org.DefaultRepoPermission != nil {
+		return org.DefaultRepoPermission
+	}
+	return org.DefaultRepoSettings
+}
+
+// generate returns the edit payload for the supplied parameters. Only fields
+// that are set are sent, so the edit never touches unmanaged settings.
+func generate(p v1alpha1.OrganizationSettingsParameters) *githubOrganization {
+	return &githubOrganization{DefaultRepositoryBranch: p.DefaultRepositoryBranch, Organization: github.Organization{
+		BillingEmail:                                   p.BillingEmail,
+		Company:                                        p.Company,
+		Description:                                    p.Description,
+		DefaultRepoPermission:                          p.DefaultRepositoryPermission,
+		MembersCanCreateRepos:                          p.MembersCanCreateRepositories,
+		MembersCanCreatePublicRepos:                    p.MembersCanCreatePublicRepositories,
+		MembersCanCreatePrivateRepos:                   p.MembersCanCreatePrivateRepositories,
+		MembersCanCreateInternalRepos:                  p.MembersCanCreateInternalRepositories,
+		MembersCanCreatePages:                          p.MembersCanCreatePages,
+		MembersCanForkPrivateRepos:                     p.MembersCanForkPrivateRepositories,
+		WebCommitSignoffRequired:                       p.WebCommitSignoffRequired,
+		DependencyGraphEnabledForNewRepos:              p.DependencyGraphEnabledForNewRepositories,
+		DependabotAlertsEnabledForNewRepos:             p.DependabotAlertsEnabledForNewRepositories,
+		DependabotSecurityUpdatesEnabledForNewRepos:    p.DependabotSecurityUpdatesEnabledForNewRepositories,
+		SecretScanningEnabledForNewRepos:               p.SecretScanningEnabledForNewRepositories,
+		SecretScanningPushProtectionEnabledForNewRepos: p.SecretScanningPushProtectionEnabledForNewRepositories,
+	}}
+}
+
+// lateInitialize fills unset parameters from the supplied organization and
+// reports whether any were filled.
+func lateInitialize(p *v1alpha1.OrganizationSettingsParameters, org *githubOrganization) bool {
+	li := resource.NewLateInitializer()
+	p.BillingEmail = li.LateInitializeStringPtr(p.BillingEmail, org.BillingEmail)
+	p.Company = li.LateInitializeStringPtr(p.Company, org.Company)
+	p.Description = li.LateInitializeStringPtr(p.Description, org.Description)
+	p.DefaultRepositoryPermission = li.LateInitializeStringPtr(p.DefaultRepositoryPermission, defaultRepositoryPermission(org))
+	p.DefaultRepositoryBranch = li.LateInitializeStringPtr(p.DefaultRepositoryBranch, org.DefaultRepositoryBranch)
+	p.MembersCanCreateRepositories = li.LateInitializeBoolPtr(p.MembersCanCreateRepositories, org.MembersCanCreateRepos)
+	p.MembersCanCreatePublicRepositories = li.LateInitializeBoolPtr(p.MembersCanCreatePublicRepositories, org.MembersCanCreatePublicRepos)
+	p.MembersCanCreatePrivateRepositories = li.LateInitializeBoolPtr(p.MembersCanCreatePrivateRepositories, org.MembersCanCreatePrivateRepos)
+	p.MembersCanCreateInternalRepositories = li.LateInitializeBoolPtr(p.MembersCanCreateInternalRepositories, org.MembersCanCreateInternalRepos)
+	p.MembersCanCreatePages = li.LateInitializeBoolPtr(p.MembersCanCreatePages, org.MembersCanCreatePages)
+	p.MembersCanForkPrivateRepositories = li.LateInitializeBoolPtr(p.MembersCanForkPrivateRepositories, org.MembersCanForkPrivateRepos)
+	p.WebCommitSignoffRequired = li.LateInitializeBoolPtr(p.WebCommitSignoffRequired, org.WebCommitSignoffRequired)
+	p.DependencyGraphEnabledForNewRepositories = li.LateInitializeBoolPtr(p.DependencyGraphEnabledForNewRepositories, org.DependencyGraphEnabledForNewRepos)
+	p.DependabotAlertsEnabledForNewRepositories = li.LateInitializeBoolPtr(p.DependabotAlertsEnabledForNewRepositories, org.DependabotAlertsEnabledForNewRepos)
+	p.DependabotSecurityUpdatesEnabledForNewRepositories = li.LateInitializeBoolPtr(p.DependabotSecurityUpdatesEnabledForNewRepositories, org.DependabotSecurityUpdatesEnabledForNewRepos)
+	p.SecretScanningEnabledForNewRepositories = li.LateInitializeBoolPtr(p.SecretScanningEnabledForNewRepositories, org.SecretScanningEnabledForNewRepos)
+	p.SecretScanningPushProtectionEnabledForNewRepositories = li.LateInitializeBoolPtr(p.SecretScanningPushProtectionEnabledForNewRepositories, org.SecretScanningPushProtectionEnabledForNewRepos)
+	return li.IsChanged()
+}
+
+// diff returns the parameters that are set and differ from the supplied
+// organization. Each setting is a separate field of the organization and
+// drifts on its own, so each is compared on its own.
+func diff(p v1alpha1.OrganizationSettingsParameters, org *githubOrganization) *compare.Diff {
+	d := &compare.Diff{}
+	compare.DiffOptional(d, "billingEmail", p.BillingEmail, org.BillingEmail)
+	compare.DiffOptional(d, "company", p.Company, org.Company)
+	compare.DiffOptional(d, "description", p.Description, org.Description)
+	compare.DiffOptional(d, "defaultRepositoryPermission", p.DefaultRepositoryPermission, defaultRepositoryPermission(org))
+	compare.DiffOptional(d, "defaultRepositoryBranch", p.DefaultRepositoryBranch, org.DefaultRepositoryBranch)
+	compare.DiffOptional(d, "membersCanCreateRepositories", p.MembersCanCreateRepositories, org.MembersCanCreateRepos)
+	compare.DiffOptional(d, "membersCanCreatePublicRepositories", p.MembersCanCreatePublicRepositories, org.MembersCanCreatePublicRepos)
+	compare.DiffOptional(d, "membersCanCreatePrivateRepositories", p.MembersCanCreatePrivateRepositories, org.MembersCanCreatePrivateRepos)
+	compare.DiffOptional(d, "membersCanCreateInternalRepositories", p.MembersCanCreateInternalRepositories, org.MembersCanCreateInternalRepos)
+	compare.DiffOptional(d, "membersCanCreatePages", p.MembersCanCreatePages, org.MembersCanCreatePages)
+	compare.DiffOptional(d, "membersCanForkPrivateRepositories", p.MembersCanForkPrivateRepositories, org.MembersCanForkPrivateRepos)
+	compare.DiffOptional(d, "webCommitSignoffRequired", p.WebCommitSignoffRequired, org.WebCommitSignoffRequired)
+	compare.DiffOptional(d, "dependencyGraphEnabledForNewRepositories", p.DependencyGraphEnabledForNewRepositories, org.DependencyGraphEnabledForNewRepos)
+	compare.DiffOptional(d, "dependabotAlertsEnabledForNewRepositories", p.DependabotAlertsEnabledForNewRepositories, org.DependabotAlertsEnabledForNewRepos)
+	compare.DiffOptional(d, "dependabotSecurityUpdatesEnabledForNewRepositories", p.DependabotSecurityUpdatesEnabledForNewRepositories, org.DependabotSecurityUpdatesEnabledForNewRepos)
+	compare.DiffOptional(d, "secretScanningEnabledForNewRepositories", p.SecretScanningEnabledForNewRepositories, org.SecretScanningEnabledForNewRepos)
+	compare.DiffOptional(d, "secretScanningPushProtectionEnabledForNewRepositories", p.SecretScanningPushProtectionEnabledForNewRepositories, org.SecretScanningPushProtectionEnabledForNewRepos)
+	return d
+}
+
+func boolUpToDate(want, got *bool) bool {
+	return want == nil || *want == pointer.BoolDeref(got, false)
+}
+
+// A githubOrganization is an organization as returned by the GitHub API, which
+// includes the default branch of new repositories the go-github Organization
+// does not.
+type githubOrganization struct {
+	github.Organization
+	DefaultRepositoryBranch *string `json:"default_repository_branch,omitempty"`
+}
+
+// getOrg returns the supplied organization.
+func (c *external) getOrg(ctx context.Context, org string) (*githubOrganization, error) {
+	req, err := c.service.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v", org), nil)
+	if err != nil {
+		return nil, err
+	}
+	o := &githubOrganization{}
+	if _, err := c.service.Do(ctx, req, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// editOrg applies the supplied edit payload to the supplied organization.
+func (c *external) editOrg(ctx context.Context, org string, o *githubOrganization) error {
+	req, err := c.service.NewRequest(http.MethodPatch, fmt.Sprintf("orgs/%v", org), o)
+	if err != nil {
+		return err
+	}
+	_, err = c.service.Do(ctx, req, nil)
+	return err
+}
+
+// validate rejects parameters GitHub would reject with an error that does not
+// name the offending field. Internal repositories only exist in enterprise
+// organizations. The plan is only visible to owners, so an unknown plan is
+// left for GitHub to judge.
+func validate(p v1alpha1.OrganizationSettingsParameters, plan string) error {
+	if p.DefaultRepositoryBranch != nil {
+		if reason := invalidBranchName(*p.DefaultRepositoryBranch); reason != "" {
+			return errors.Errorf(errFmtInvalidBranch, *p.DefaultRepositoryBranch, reason)
+		}
+	}
+	if pointer.BoolDeref(p.MembersCanCreateInternalRepositories, false) && plan != "" && plan != planEnterprise {
+		return errors.Errorf(errFmtInternalPlan, plan)
+	}
+	return nil
+}
+
+// invalidBranchName returns why the supplied name is not a valid Git branch
+// name, or an empty string if it is. It follows the rules of
+// git check-ref-format --branch.
+func invalidBranchName(name string) string {
+	switch {
+	case name == "", name == "@":
+		return "it must not be empty or @"
+	case strings.HasPrefix(name, "-"):
+		return "it must not begin with -"
+	case strings.HasPrefix(name, "/"), strings.HasSuffix(name, "/"), strings.Contains(name, "//"):
+		return "it must not begin or end with / or contain //"
+	case strings.HasSuffix(name, "."):
+		return "it must not end with ."
+	case strings.Contains(name, ".."):
+		return "it must not contain .."
+	case strings.Contains(name, "@{"):
+		return "it must not contain @{"
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Sprintf("it must not contain %q", r)
+		}
+	}
+	for _, c := range strings.Split(name, "/") {
+		if strings.HasPrefix(c, ".") || strings.HasSuffix(c, ".lock") {
+			return "no component may begin with . or end with .lock"
+		}
+	}
+	return ""
+}
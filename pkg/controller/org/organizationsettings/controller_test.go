@@ -0,0 +1,243 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationsettings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+)
+
+// fields returns the names of the fields the supplied diff reports.
+func fields(d string) []string {
+	var f []string
+	for _, m := range regexp.MustCompile(`(?m)^(\w+): `).FindAllStringSubmatch(d, -1) {
+		f = append(f, m[1])
+	}
+	return f
+}
+
+func TestDiff(t *testing.T) {
+	type want struct {
+		upToDate bool
+		fields   []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.OrganizationSettingsParameters
+		org    *githubOrganization
+		want   want
+	}{
+		"Unmanaged": {
+			reason: "Settings that are not set should not be compared.",
+			org: &githubOrganization{Organization: github.Organization{
+				MembersCanForkPrivateRepos:    github.Bool(true),
+				MembersCanCreateInternalRepos: github.Bool(true),
+			}},
+			want: want{upToDate: true},
+		},
+		"InSync": {
+			reason: "Settings that match the organization should be up to date.",
+			p: v1alpha1.OrganizationSettingsParameters{
+				MembersCanForkPrivateRepositories:    pointer.Bool(true),
+				MembersCanCreatePublicRepositories:   pointer.Bool(false),
+				MembersCanCreatePrivateRepositories:  pointer.Bool(true),
+				MembersCanCreateInternalRepositories: pointer.Bool(true),
+			},
+			org: &githubOrganization{Organization: github.Organization{
+				MembersCanForkPrivateRepos:    github.Bool(true),
+				MembersCanCreatePublicRepos:   github.Bool(false),
+				MembersCanCreatePrivateRepos:  github.Bool(true),
+				MembersCanCreateInternalRepos: github.Bool(true),
+			}},
+			want: want{upToDate: true},
+		},
+		"ForkingDrifted": {
+			reason: "Forking private repositories should drift on its own.",
+			p: v1alpha1.OrganizationSettingsParameters{
+				MembersCanForkPrivateRepositories:   pointer.Bool(false),
+				MembersCanCreatePrivateRepositories: pointer.Bool(true),
+			},
+			org: &githubOrganization{Organization: github.Organization{
+				MembersCanForkPrivateRepos:   github.Bool(true),
+				MembersCanCreatePrivateRepos: github.Bool(true),
+			}},
+			want: want{fields: []string{"membersCanForkPrivateRepositories"}},
+		},
+		"VisibilitiesDrifted": {
+			reason: "Each visibility members may create should be reported on its own.",
+			p: v1alpha1.OrganizationSettingsParameters{
+				MembersCanCreatePublicRepositories:   pointer.Bool(true),
+				MembersCanCreatePrivateRepositories:  pointer.Bool(false),
+				MembersCanCreateInternalRepositories: pointer.Bool(true),
+			},
+			org: &githubOrganization{Organization: github.Organization{
+				MembersCanCreatePublicRepos:   github.Bool(true),
+				MembersCanCreatePrivateRepos:  github.Bool(true),
+				MembersCanCreateInternalRepos: github.Bool(false),
+			}},
+			want: want{fields: []string{"membersCanCreatePrivateRepositories", "membersCanCreateInternalRepositories"}},
+		},
+		"InternalUnobserved": {
+			reason: "Organizations without internal repositories omit the setting, which should be treated as disabled.",
+			p: v1alpha1.OrganizationSettingsParameters{
+				MembersCanCreateInternalRepositories: pointer.Bool(false),
+			},
+			org:  &githubOrganization{},
+			want: want{upToDate: true},
+		},
+		"DefaultRepoSettings": {
+			reason: "The default repository permission should be read from default_repository_settings if default_repository_permission is omitted.",
+			p: v1alpha1.OrganizationSettingsParameters{
+				DefaultRepositoryPermission: pointer.String("write"),
+			},
+			org: &githubOrganization{Organization: github.Organization{
+				DefaultRepoSettings: github.String("read"),
+			}},
+			want: want{fields: []string{"defaultRepositoryPermission"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := diff(tc.p, tc.org)
+			got := want{upToDate: d.UpToDate(), fields: fields(d.String())}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ndiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.OrganizationSettingsParameters
+		plan   string
+		want   error
+	}{
+		"Enterprise": {
+			reason: "Enterprise organizations should allow members to create internal repositories.",
+			p:      v1alpha1.OrganizationSettingsParameters{MembersCanCreateInternalRepositories: pointer.Bool(true)},
+			plan:   planEnterprise,
+		},
+		"InternalOnFreePlan": {
+			reason: "Internal repositories should be rejected with the plan of organizations that are not enterprise.",
+			p:      v1alpha1.OrganizationSettingsParameters{MembersCanCreateInternalRepositories: pointer.Bool(true)},
+			plan:   "free",
+			want:   errors.Errorf(errFmtInternalPlan, "free"),
+		},
+		"InternalDisabledOnFreePlan": {
+			reason: "Disabling internal repositories should be allowed on any plan.",
+			p:      v1alpha1.OrganizationSettingsParameters{MembersCanCreateInternalRepositories: pointer.Bool(false)},
+			plan:   "free",
+		},
+		"UnknownPlan": {
+			reason: "Only owners can see the plan, so an unknown plan should be left for GitHub to judge.",
+			p:      v1alpha1.OrganizationSettingsParameters{MembersCanCreateInternalRepositories: pointer.Bool(true)},
+		},
+		"InvalidBranch": {
+			reason: "An invalid default branch should be rejected with the reason.",
+			p:      v1alpha1.OrganizationSettingsParameters{DefaultRepositoryBranch: pointer.String("main..next")},
+			want:   errors.Errorf(errFmtInvalidBranch, "main..next", invalidBranchName("main..next")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := validate(tc.p, tc.plan)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvalidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateInternalRepositories(t *testing.T) {
+	type want struct {
+		err    error
+		edited bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		plan   string
+		want   want
+	}{
+		"Enterprise": {
+			reason: "Enterprise organizations should be edited to allow internal repositories.",
+			plan:   planEnterprise,
+			want:   want{edited: true},
+		},
+		"Team": {
+			reason: "The plan of the organization payload should be detected, and internal repositories rejected without editing the organization.",
+			plan:   "team",
+			want:   want{err: errors.Errorf(errFmtInternalPlan, "team")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			edited := false
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					_ = json.NewEncoder(w).Encode(&github.Organization{
+						Login:                         github.String("acme"),
+						Plan:                          &github.Plan{Name: github.String(tc.plan)},
+						MembersCanCreateInternalRepos: github.Bool(false),
+					})
+				case http.MethodPatch:
+					edited = true
+					_, _ = w.Write([]byte("{}"))
+				}
+			}))
+			defer srv.Close()
+			c := github.NewClient(srv.Client())
+			c.BaseURL, _ = url.Parse(srv.URL + "/")
+			e := &external{service: c, recorder: event.NewNopRecorder()}
+
+			cr := &v1alpha1.OrganizationSettings{}
+			cr.Spec.ForProvider.Org = "acme"
+			cr.Spec.ForProvider.MembersCanCreateInternalRepositories = pointer.Bool(true)
+			ctx := context.Background()
+			if _, err := e.Observe(ctx, cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			_, err := e.Update(ctx, cr)
+			got := want{err: err, edited: edited}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
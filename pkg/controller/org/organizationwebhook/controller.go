@@ -0,0 +1,455 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizationwebhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotOrganizationWebhook = "managed resource is not an OrganizationWebhook custom resource"
+	errCreateService          = "failed to create client service"
+	errGetWebhook             = "cannot get webhook"
+	errCreateWebhook          = "cannot create webhook"
+	errUpdateWebhook          = "cannot update webhook"
+	errDeleteWebhook          = "cannot delete webhook"
+	errPingWebhook            = "cannot ping webhook"
+	errListDeliveries         = "cannot list webhook deliveries"
+	errGetSecret              = "cannot get webhook secret"
+	errInvalidID              = "external name is not a webhook ID"
+	errFmtNoSecretKey         = "secret %s/%s has no key %s"
+	errGenerateSecret         = "cannot generate webhook secret"
+	errSecretRefAndGenerate   = "secretRef and generateSecret are mutually exclusive"
+	errNoConnectionSecret     = "generateSecret requires writeConnectionSecretToRef to be set"
+)
+
+// SetupOrganizationWebhook adds a controller that reconciles
+// OrganizationWebhook managed resources.
+func SetupOrganizationWebhook(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrganizationWebhookGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.OrganizationWebhookKind, "admin:org_hook")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OrganizationWebhookGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient(), record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OrganizationWebhook{}, builder.WithPredicates(kcgitclient.DesiredStateChanged())).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(referencing(mgr.GetClient())))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.OrganizationWebhookGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.OrganizationWebhookKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	record event.Recorder
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.OrganizationWebhook); !ok {
+		return nil, errors.New(errNotOrganizationWebhook)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube, record: c.record}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a webhook of
+// an organization, and rotates its secret.
+type external struct {
+	service *github.Client
+	kube    client.Client
+	record  event.Recorder
+}
+
+// Connection details of an OrganizationWebhook. The secret is only published
+// if it was generated by the provider.
+const (
+	ConnectionDetailID     = "id"
+	ConnectionDetailURL    = "url"
+	ConnectionDetailSecret = "secret"
+)
+
+// generatedSecretBytes is how many random bytes a generated secret consists
+// of. GitHub signs deliveries with HMAC-SHA256, whose key should be at least
+// as long as its output.
+const generatedSecretBytes = 32
+
+// pingTimeout is how long the ping sent after a rotation may take to be
+// delivered before the rotation is considered failed.
+const pingTimeout = 10 * time.Minute
+
+// reasonRotatedSecret is the reason of the event emitted when the secret of
+// a webhook was rotated.
+const reasonRotatedSecret event.Reason = "RotatedSecret"
+
+// referencing returns a function that maps a Secret to the requests of the
+// OrganizationWebhooks whose secret it contains, so that they are updated
+// as soon as it changes. The connection secret of a webhook with a generated
+// secret contains its secret too, so that a new one is generated as soon as
+// it is deleted.
+func referencing(c client.Reader) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		l := &v1alpha1.OrganizationWebhookList{}
+		if err := c.List(context.Background(), l); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, wh := range l.Items {
+			ref := wh.Spec.ForProvider.SecretRef
+			if ref != nil && ref.Namespace == o.GetNamespace() && ref.Name == o.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: wh.GetName()}})
+				continue
+			}
+			cs := wh.GetWriteConnectionSecretToReference()
+			if wh.Spec.ForProvider.GenerateSecret && cs != nil && cs.Namespace == o.GetNamespace() && cs.Name == o.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: wh.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationWebhook)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationWebhook)
+	}
+
+	// GitHub assigns the ID of a webhook when it is created.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInvalidID)
+	}
+
+	p := cr.Spec.ForProvider
+	h, _, err := c.service.Organizations.GetHook(ctx, p.Org, id)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetWebhook)
+	}
+	cr.Status.AtProvider.ID = h.GetID()
+	if cr.Status.AtProvider.Rotation == v1alpha1.RotationPending {
+		if err := c.pinged(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	cr.SetConditions(xpv1.Available())
+
+	secret, err := c.secret(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	d := diff(p, h)
+	// GitHub never returns the secret of a webhook, so the hash of the
+	// secret last applied tells whether it changed.
+	if hash(secret) != cr.Status.AtProvider.SecretHash {
+		d.Add("secretHash", hash(secret), cr.Status.AtProvider.SecretHash)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  d.UpToDate(),
+		Diff:              d.String(),
+		ConnectionDetails: connectionDetails(cr, h.GetID(), secret),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationWebhook)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrganizationWebhook)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	secret, err := c.secret(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if cr.Spec.ForProvider.GenerateSecret {
+		if secret, err = generateSecret(); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+	h, _, err := c.service.Organizations.CreateHook(ctx, cr.Spec.ForProvider.Org, generate(cr.Spec.ForProvider, secret))
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateWebhook)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(h.GetID(), 10))
+	return managed.ExternalCreation{ConnectionDetails: connectionDetails(cr, h.GetID(), secret)}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationWebhook)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationWebhook)
+	}
+
+	secret, err := c.secret(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	p := cr.Spec.ForProvider
+	// A generated secret that is no longer in the connection secret cannot
+	// be recovered, so a new one is generated and applied.
+	if p.GenerateSecret && secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	id := cr.Status.AtProvider.ID
+	if _, _, err := c.service.Organizations.EditHook(ctx, p.Org, id, generate(p, secret)); err != nil {
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateWebhook)
+	}
+
+	o := &cr.Status.AtProvider
+	u := managed.ExternalUpdate{ConnectionDetails: connectionDetails(cr, id, secret)}
+	if hash(secret) == o.SecretHash {
+		return u, nil
+	}
+	// The secret a webhook is created with cannot be recorded as it is
+	// created, so it is applied again and recorded here. That is not a
+	// rotation, as no deliveries were signed with another secret yet.
+	rotation := o.SecretRotatedAt != nil
+	now := metav1.Now()
+	o.SecretHash, o.SecretRotatedAt, o.PingDelivery = hash(secret), &now, nil
+	o.Rotation = v1alpha1.RotationComplete
+	if !rotation {
+		return u, nil
+	}
+	c.record.Event(cr, event.Normal(reasonRotatedSecret, "Applied new webhook secret"))
+	if cr.GetAnnotations()[v1alpha1.AnnotationKeyPingOnRotation] != "true" {
+		return u, nil
+	}
+	if _, err := c.service.Organizations.PingHook(ctx, p.Org, id); err != nil {
+		o.Rotation = v1alpha1.RotationFailed
+		return u, kcgitclient.WrapAPIError(err, errPingWebhook)
+	}
+	o.Rotation = v1alpha1.RotationPending
+	return u, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrganizationWebhook)
+	if !ok {
+		return errors.New(errNotOrganizationWebhook)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	_, err := c.service.Organizations.DeleteHook(ctx, cr.Spec.ForProvider.Org, cr.Status.AtProvider.ID)
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteWebhook)
+}
+
+// pinged completes the pending rotation of the supplied webhook once the
+// ping sent after it was delivered. A rotation whose ping was delivered
+// unsuccessfully, or not at all within the pingTimeout, failed.
+func (c *external) pinged(ctx context.Context, cr *v1alpha1.OrganizationWebhook) error {
+	o := &cr.Status.AtProvider
+	if cr.GetAnnotations()[v1alpha1.AnnotationKeyPingOnRotation] != "true" || o.SecretRotatedAt == nil {
+		o.Rotation = v1alpha1.RotationComplete
+		return nil
+	}
+
+	// Deliveries are listed newest first, and the ping was sent only
+	// moments ago.
+	deliveries, _, err := c.service.Organizations.ListHookDeliveries(ctx, cr.Spec.ForProvider.Org, o.ID, &github.ListCursorOptions{PerPage: 30})
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListDeliveries)
+	}
+	for _, dl := range deliveries {
+		if dl.GetEvent() != "ping" || dl.GetDeliveredAt().Time.Before(o.SecretRotatedAt.Time) {
+			continue
+		}
+		at := metav1.NewTime(dl.GetDeliveredAt().Time)
+		o.PingDelivery = &v1alpha1.WebhookDelivery{
+			ID:          dl.GetID(),
+			StatusCode:  dl.GetStatusCode(),
+			Status:      dl.GetStatus(),
+			DeliveredAt: &at,
+		}
+		o.Rotation = v1alpha1.RotationComplete
+		if dl.GetStatusCode() < 200 || dl.GetStatusCode() > 299 {
+			o.Rotation = v1alpha1.RotationFailed
+		}
+		return nil
+	}
+	if time.Since(o.SecretRotatedAt.Time) > pingTimeout {
+		o.Rotation = v1alpha1.RotationFailed
+	}
+	return nil
+}
+
+// secret returns the secret the deliveries of the supplied webhook are to
+// be signed with. It is empty if the webhook references no secret, or if its
+// secret is generated but has not been published as a connection detail.
+func (c *external) secret(ctx context.Context, cr *v1alpha1.OrganizationWebhook) (string, error) {
+	if cr.Spec.ForProvider.GenerateSecret {
+		return c.generatedSecret(ctx, cr)
+	}
+	ref := cr.Spec.ForProvider.SecretRef
+	if ref == nil {
+		return "", nil
+	}
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtNoSecretKey, ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(v), nil
+}
+
+// generatedSecret returns the generated secret of the supplied webhook from
+// its connection secret. It is empty if the connection secret or its secret
+// key does not exist.
+func (c *external) generatedSecret(ctx context.Context, cr *v1alpha1.OrganizationWebhook) (string, error) {
+	if cr.Spec.ForProvider.SecretRef != nil {
+		return "", errors.New(errSecretRefAndGenerate)
+	}
+	ref := cr.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return "", errors.New(errNoConnectionSecret)
+	}
+	s := &corev1.Secret{}
+	err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, errGetSecret)
+	}
+	return string(s.Data[ConnectionDetailSecret]), nil
+}
+
+// generateSecret returns a new random secret to sign deliveries with.
+func generateSecret() (string, error) {
+	b := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, errGenerateSecret)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// connectionDetails returns the connection details of the supplied webhook.
+// The secret is only included if it was generated, as a referenced secret is
+// already available to whoever referenced it.
+func connectionDetails(cr *v1alpha1.OrganizationWebhook, id int64, secret string) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		ConnectionDetailID:  []byte(strconv.FormatInt(id, 10)),
+		ConnectionDetailURL: []byte(cr.Spec.ForProvider.URL),
+	}
+	if cr.Spec.ForProvider.GenerateSecret && secret != "" {
+		cd[ConnectionDetailSecret] = []byte(secret)
+	}
+	return cd
+}
+
+// hash returns the hex encoded SHA-256 hash of the supplied secret, or an
+// empty string if there is none.
+func hash(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// generate returns the webhook described by the supplied parameters. The
+// secret is always sent, as GitHub would otherwise keep the current one.
+func generate(p v1alpha1.OrganizationWebhookParameters, secret string) *github.Hook {
+	return &github.Hook{
+		Name: github.String("web"),
+		Config: &github.HookConfig{
+			URL:         github.String(p.URL),
+			ContentType: github.String(p.ContentType),
+			InsecureSSL: github.String(insecureSSL(p.InsecureSSL)),
+			Secret:      github.String(secret),
+		},
+		Events: p.Events,
+		Active: p.Active,
+	}
+}
+
+// insecureSSL returns how GitHub represents whether a webhook verifies TLS
+// certificates.
+func insecureSSL(insecure bool) string {
+	if insecure {
+		return "1"
+	}
+	return "0"
+}
+
+func diff(p v1alpha1.OrganizationWebhookParameters, h *github.Hook) *compare.Diff {
+	d := &compare.Diff{}
+	cfg := h.GetConfig()
+	compare.DiffOptional(d, "url", &p.URL, cfg.URL)
+	compare.DiffOptional(d, "contentType", &p.ContentType, cfg.ContentType)
+	compare.DiffOptional(d, "insecureSSL", github.String(insecureSSL(p.InsecureSSL)), cfg.InsecureSSL)
+	compare.DiffSet(d, "events", p.Events, h.Events)
+	compare.DiffOptional(d, "active", p.Active, h.Active)
+	return d
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotProjectV2  = "managed resource is not a ProjectV2 custom resource"
+	errCreateService = "failed to create client service"
+	errGetProject    = "cannot get project"
+	errGetOrg        = "cannot get organization"
+	errCreateProject = "cannot create project"
+	errUpdateProject = "cannot update project"
+	errDeleteProject = "cannot delete project"
+)
+
+// SetupProjectV2 adds a controller that reconciles ProjectV2 managed resources.
+func SetupProjectV2(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ProjectV2GroupKind)
+	kcgitclient.RequireScopes(v1alpha1.ProjectV2Kind, "project")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ProjectV2GroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProjectV2{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.ProjectV2GroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.ProjectV2Kind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ProjectV2); !ok {
+		return nil, errors.New(errNotProjectV2)
+	}
+	svc, err := kcgitclient.UseProviderConfigGraphQL(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a project.
+type external struct {
+	service *githubv4.Client
+}
+
+// project is the subset of the ProjectV2 GraphQL object this controller
+// observes.
+type project struct {
+	ID               githubv4.ID
+	Number           int
+	URL              string
+	Title            string
+	ShortDescription string
+	Readme           string
+	Public           bool
+	Closed           bool
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProjectV2)
+	}
+
+	var q struct {
+		Node struct {
+			ProjectV2 project `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+	// Until the project has been created the external name is not a node ID,
+	// which the API reports like any other unknown node.
+	err := c.service.Query(ctx, &q, map[string]interface{}{
+		"id": githubv4.ID(meta.GetExternalName(cr)),
+	})
+	if kcgitclient.IsGraphQLNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetProject)
+	}
+	pr := q.Node.ProjectV2
+
+	cr.Status.AtProvider.NodeID = fmt.Sprint(pr.ID)
+	cr.Status.AtProvider.Number = pr.Number
+	cr.Status.AtProvider.URL = pr.URL
+
+	p := cr.Spec.ForProvider
+	upToDate := pr.Title == p.Title &&
+		(p.ShortDescription == nil || pr.ShortDescription == *p.ShortDescription) &&
+		(p.Readme == nil || pr.Readme == *p.Readme) &&
+		(p.Public == nil || pr.Public == *p.Public) &&
+		(p.Closed == nil || pr.Closed == *p.Closed)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create creates the project and then applies the fields the create mutation
+// does not accept.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProjectV2)
+	}
+
+	var q struct {
+		Organization struct {
+			ID githubv4.ID
+		} `graphql:"organization(login: $login)"`
+	}
+	if err := c.service.Query(ctx, &q, map[string]interface{}{
+		"login": githubv4.String(cr.Spec.ForProvider.Org),
+	}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetOrg)
+	}
+
+	var m struct {
+		CreateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"createProjectV2(input: $input)"`
+	}
+	if err := c.service.Mutate(ctx, &m, githubv4.CreateProjectV2Input{
+		OwnerID: q.Organization.ID,
+		Title:   githubv4.String(cr.Spec.ForProvider.Title),
+	}, nil); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateProject)
+	}
+
+	id := m.CreateProjectV2.ProjectV2.ID
+	meta.SetExternalName(cr, fmt.Sprint(id))
+	return managed.ExternalCreation{}, errors.Wrap(c.update(ctx, cr, id), errUpdateProject)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProjectV2)
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(c.update(ctx, cr, githubv4.ID(meta.GetExternalName(cr))), errUpdateProject)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProjectV2)
+	if !ok {
+		return errors.New(errNotProjectV2)
+	}
+
+	var m struct {
+		DeleteProjectV2 struct {
+			ClientMutationID githubv4.String
+		} `graphql:"deleteProjectV2(input: $input)"`
+	}
+	err := c.service.Mutate(ctx, &m, githubv4.DeleteProjectV2Input{
+		ProjectID: githubv4.ID(meta.GetExternalName(cr)),
+	}, nil)
+	if kcgitclient.IsGraphQLNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errDeleteProject)
+}
+
+func (c *external) update(ctx context.Context, cr *v1alpha1.ProjectV2, id githubv4.ID) error {
+	p := cr.Spec.ForProvider
+	in := githubv4.UpdateProjectV2Input{
+		ProjectID: id,
+		Title:     githubv4.NewString(githubv4.String(p.Title)),
+	}
+	if p.ShortDescription != nil {
+		in.ShortDescription = githubv4.NewString(githubv4.String(*p.ShortDescription))
+	}
+	if p.Readme != nil {
+		in.Readme = githubv4.NewString(githubv4.String(*p.Readme))
+	}
+	if p.Public != nil {
+		in.Public = githubv4.NewBoolean(githubv4.Boolean(*p.Public))
+	}
+	if p.Closed != nil {
+		in.Closed = githubv4.NewBoolean(githubv4.Boolean(*p.Closed))
+	}
+
+	var m struct {
+		UpdateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"updateProjectV2(input: $input)"`
+	}
+	return c.service.Mutate(ctx, &m, in, nil)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectv2field
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotProjectV2Field = "managed resource is not a ProjectV2Field custom resource"
+	errCreateService     = "failed to create client service"
+	errGetField          = "cannot get project field"
+	errCreateField       = "cannot create project field"
+	errUpdateField       = "cannot update project field"
+	errDeleteField       = "cannot delete project field"
+	errFmtDataType       = "the data type of the field is %s and cannot be changed to %s; delete the ProjectV2Field to recreate the field"
+)
+
+// SetupProjectV2Field adds a controller that reconciles ProjectV2Field managed
+// resources.
+func SetupProjectV2Field(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ProjectV2FieldGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.ProjectV2FieldKind, "project")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ProjectV2FieldGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProjectV2Field{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.ProjectV2FieldGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.ProjectV2FieldKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ProjectV2Field); !ok {
+		return nil, errors.New(errNotProjectV2Field)
+	}
+	svc, err := kcgitclient.UseProviderConfigGraphQL(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a custom field
+// of a project.
+type external struct {
+	service *githubv4.Client
+}
+
+// field is the subset of the ProjectV2FieldConfiguration GraphQL union this
+// controller observes. Only the member that matches the type of the node is
+// filled in.
+type field struct {
+	Field struct {
+		ID       githubv4.ID
+		Name     string
+		DataType string
+	} `graphql:"... on ProjectV2Field"`
+	SingleSelect struct {
+		ID       githubv4.ID
+		Name     string
+		DataType string
+		Options  []struct {
+			ID          string
+			Name        string
+			Color       string
+			Description string
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	Iteration struct {
+		ID       githubv4.ID
+		Name     string
+		DataType string
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+// An option is a single select option as it is compared. Options of a field
+// have IDs assigned by GitHub, so they are compared by everything but their
+// ID.
+type option struct {
+	Name        string
+	Color       string
+	Description string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2Field)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProjectV2Field)
+	}
+
+	var q struct {
+		Node field `graphql:"node(id: $id)"`
+	}
+	// Until the field has been created the external name is not a node ID,
+	// which the API reports like any other unknown node.
+	err := c.service.Query(ctx, &q, map[string]interface{}{
+		"id": githubv4.ID(meta.GetExternalName(cr)),
+	})
+	if kcgitclient.IsGraphQLNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetField)
+	}
+
+	f := q.Node
+	var name, dataType string
+	var observed []option
+	obs := v1alpha1.ProjectV2FieldObservation{NodeID: meta.GetExternalName(cr)}
+	switch {
+	case f.SingleSelect.ID != nil:
+		name, dataType = f.SingleSelect.Name, f.SingleSelect.DataType
+		for _, o := range f.SingleSelect.Options {
+			observed = append(observed, option{Name: o.Name, Color: o.Color, Description: o.Description})
+			obs.Options = append(obs.Options, v1alpha1.ProjectV2FieldOptionObservation{ID: o.ID, Name: o.Name})
+		}
+	case f.Iteration.ID != nil:
+		name, dataType = f.Iteration.Name, f.Iteration.DataType
+	case f.Field.ID != nil:
+		name, dataType = f.Field.Name, f.Field.DataType
+	default:
+		// The node exists but is not a field.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	cr.Status.AtProvider = obs
+
+	p := cr.Spec.ForProvider
+	if dataType != p.DataType {
+		return managed.ExternalObservation{}, errors.Errorf(errFmtDataType, dataType, p.DataType)
+	}
+	d := &compare.Diff{}
+	if name != p.Name {
+		d.Add("name", p.Name, name)
+	}
+	if p.DataType == string(githubv4.ProjectV2CustomFieldTypeSingleSelect) {
+		compare.DiffSet(d, "options", options(p.Options), observed)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: d.UpToDate(),
+		Diff:             d.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2Field)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProjectV2Field)
+	}
+
+	p := cr.Spec.ForProvider
+	in := githubv4.CreateProjectV2FieldInput{
+		ProjectID: githubv4.ID(p.ProjectID),
+		DataType:  githubv4.ProjectV2CustomFieldType(p.DataType),
+		Name:      githubv4.String(p.Name),
+	}
+	if in.DataType == githubv4.ProjectV2CustomFieldTypeSingleSelect {
+		in.SingleSelectOptions = optionsInput(p.Options)
+	}
+
+	var m struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field field
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+	if err := c.service.Mutate(ctx, &m, in, nil); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateField)
+	}
+
+	f := m.CreateProjectV2Field.ProjectV2Field
+	id := f.Field.ID
+	switch {
+	case f.SingleSelect.ID != nil:
+		id = f.SingleSelect.ID
+	case f.Iteration.ID != nil:
+		id = f.Iteration.ID
+	}
+	meta.SetExternalName(cr, fmt.Sprint(id))
+	return managed.ExternalCreation{}, nil
+}
+
+// Update renames the field and replaces the options of a single select field.
+// The API does not update options one by one, so all of them are replaced.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2Field)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProjectV2Field)
+	}
+
+	p := cr.Spec.ForProvider
+	in := githubv4.UpdateProjectV2FieldInput{
+		FieldID: githubv4.ID(meta.GetExternalName(cr)),
+		Name:    githubv4.NewString(githubv4.String(p.Name)),
+	}
+	if p.DataType == string(githubv4.ProjectV2CustomFieldTypeSingleSelect) && p.Options != nil {
+		in.SingleSelectOptions = optionsInput(p.Options)
+	}
+
+	var m struct {
+		UpdateProjectV2Field struct {
+			ClientMutationID githubv4.String
+		} `graphql:"updateProjectV2Field(input: $input)"`
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(c.service.Mutate(ctx, &m, in, nil), errUpdateField)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProjectV2Field)
+	if !ok {
+		return errors.New(errNotProjectV2Field)
+	}
+
+	var m struct {
+		DeleteProjectV2Field struct {
+			ClientMutationID githubv4.String
+		} `graphql:"deleteProjectV2Field(input: $input)"`
+	}
+	err := c.service.Mutate(ctx, &m, githubv4.DeleteProjectV2FieldInput{
+		FieldID: githubv4.ID(meta.GetExternalName(cr)),
+	}, nil)
+	if kcgitclient.IsGraphQLNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errDeleteField)
+}
+
+// options returns the supplied desired options as they are compared with the
+// observed options. Options without a color are gray, as GitHub defaults
+// them.
+func options(opts []v1alpha1.ProjectV2FieldOption) []option {
+	if opts == nil {
+		return nil
+	}
+	out := make([]option, 0, len(opts))
+	for _, o := range opts {
+		out = append(out, option{Name: o.Name, Color: color(o.Color), Description: o.Description})
+	}
+	return out
+}
+
+// optionsInput returns the supplied desired options as the input of a
+// mutation.
+func optionsInput(opts []v1alpha1.ProjectV2FieldOption) *[]githubv4.ProjectV2SingleSelectFieldOptionInput {
+	in := make([]githubv4.ProjectV2SingleSelectFieldOptionInput, 0, len(opts))
+	for _, o := range opts {
+		in = append(in, githubv4.ProjectV2SingleSelectFieldOptionInput{
+			Name:        githubv4.String(o.Name),
+			Color:       githubv4.ProjectV2SingleSelectFieldOptionColor(color(o.Color)),
+			Description: githubv4.String(o.Description),
+		})
+	}
+	return &in
+}
+
+func color(c string) string {
+	if c == "" {
+		return string(githubv4.ProjectV2SingleSelectFieldOptionColorGray)
+	}
+	return c
+}
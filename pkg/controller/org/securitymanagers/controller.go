@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitymanagers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotSecurityManagers = "managed resource is not a SecurityManagers custom resource"
+	errCreateService       = "failed to create client service"
+	errListManagers        = "cannot list security manager teams"
+	errAddManager          = "cannot add security manager team %q"
+	errRemoveManager       = "cannot remove security manager team %q"
+)
+
+// SetupSecurityManagers adds a controller that reconciles SecurityManagers
+// managed resources.
+func SetupSecurityManagers(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SecurityManagersGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.SecurityManagersKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SecurityManagersGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.SecurityManagers{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.SecurityManagersGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.SecurityManagersKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.SecurityManagers); !ok {
+		return nil, errors.New(errNotSecurityManagers)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then grants or revokes the security manager role of
+// teams.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SecurityManagers)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecurityManagers)
+	}
+
+	current, err := c.list(ctx, cr.Spec.ForProvider.Org)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListManagers)
+	}
+	cr.Status.AtProvider.Teams = current
+
+	add, remove := diff(cr.Spec.ForProvider, current)
+
+	// The grants exist for as long as any of the listed teams is a security
+	// manager.
+	exists := len(add) < len(cr.Spec.ForProvider.Teams)
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: exists}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: len(add) == 0 && len(remove) == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SecurityManagers)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSecurityManagers)
+	}
+
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SecurityManagers)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSecurityManagers)
+	}
+
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// Delete revokes the security manager role from the listed teams. Unlisted
+// teams are left untouched regardless of prune.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SecurityManagers)
+	if !ok {
+		return errors.New(errNotSecurityManagers)
+	}
+
+	org := cr.Spec.ForProvider.Org
+	for _, t := range cr.Spec.ForProvider.Teams {
+		_, err := c.service.Organizations.RemoveSecurityManagerTeam(ctx, org, t)
+		if err := kcgitclient.DeleteError(ctx, cr, err, fmt.Sprintf(errRemoveManager, t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *external) sync(ctx context.Context, cr *v1alpha1.SecurityManagers) error {
+	org := cr.Spec.ForProvider.Org
+	current, err := c.list(ctx, org)
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListManagers)
+	}
+
+	add, remove := diff(cr.Spec.ForProvider, current)
+	for _, t := range add {
+		// GitHub answers with a conflict when the team already is a security
+		// manager, which is the state we want.
+		_, err := c.service.Organizations.AddSecurityManagerTeam(ctx, org, t)
+		if err != nil && !kcgitclient.IsConflict(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errAddManager, t))
+		}
+	}
+	for _, t := range remove {
+		_, err := c.service.Organizations.RemoveSecurityManagerTeam(ctx, org, t)
+		if err != nil && !kcgitclient.IsNotFound(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errRemoveManager, t))
+		}
+	}
+	return nil
+}
+
+// list returns the slugs of the teams that are security managers of the
+// supplied organization.
+func (c *external) list(ctx context.Context, org string) ([]string, error) {
+	teams, _, err := c.service.Organizations.ListSecurityManagerTeams(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make([]string, 0, len(teams))
+	for _, t := range teams {
+		slugs = append(slugs, t.GetSlug())
+	}
+	return slugs, nil
+}
+
+// diff returns the teams that must be granted and, when pruning, the teams
+// that must be revoked for the current security managers to match the
+// supplied parameters.
+func diff(p v1alpha1.SecurityManagersParameters, current []string) (add, remove []string) {
+	have := map[string]bool{}
+	for _, t := range current {
+		have[t] = true
+	}
+	want := map[string]bool{}
+	for _, t := range p.Teams {
+		want[t] = true
+		if !have[t] {
+			add = append(add, t)
+		}
+	}
+	if !p.Prune {
+		return add, nil
+	}
+	for _, t := range current {
+		if !want[t] {
+			remove = append(remove, t)
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+)
+
+// DefaultCacheTTL is the default age after which the teams of an
+// organization are listed again.
+const DefaultCacheTTL = 5 * time.Minute
+
+var cacheTTL = DefaultCacheTTL
+
+// SetCacheTTL sets the age after which the teams of an organization are
+// listed again, if the observation cache is enabled.
+func SetCacheTTL(d time.Duration) {
+	cacheTTL = d
+}
+
+// An observationCache caches the teams of organizations, so that a team can
+// be observed without a request of its own. Listing the teams of an
+// organization takes a request per hundred teams.
+type observationCache struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	orgs map[orgKey]*orgTeams
+}
+
+// An orgKey identifies an organization as seen with the credentials of a
+// ProviderConfig, since different credentials may see different teams.
+type orgKey struct {
+	providerConfig string
+	org            string
+}
+
+// The orgTeams are the cached teams of an organization.
+type orgTeams struct {
+	// mu is held while the teams are listed, so that the teams of an
+	// organization are only listed once when many are observed at once.
+	mu      sync.Mutex
+	fetched time.Time
+	teams   map[string]*githubTeam
+}
+
+func newObservationCache(ttl time.Duration) *observationCache {
+	return &observationCache{ttl: ttl, orgs: map[orgKey]*orgTeams{}}
+}
+
+func (c *observationCache) entry(k orgKey) *orgTeams {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.orgs[k]
+	if !ok {
+		e = &orgTeams{}
+		c.orgs[k] = e
+	}
+	return e
+}
+
+// Get returns the team of the supplied organization with the supplied slug
+// and the age of the cached teams, listing the teams of the organization if
+// they are older than the TTL. It returns false if the team is not cached.
+func (c *observationCache) Get(ctx context.Context, svc *github.Client, k orgKey, slug string) (*githubTeam, time.Duration, bool, error) {
+	e := c.entry(k)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.teams == nil || time.Since(e.fetched) > c.ttl {
+		teams, err := listTeams(ctx, svc, k.org)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		e.teams = make(map[string]*githubTeam, len(teams))
+		for _, t := range teams {
+			e.teams[t.GetSlug()] = t
+		}
+		e.fetched = time.Now()
+	}
+	t, ok := e.teams[slug]
+	return t, time.Since(e.fetched), ok, nil
+}
+
+// Set caches the supplied team of the supplied organization, for example
+// once it was observed directly.
+func (c *observationCache) Set(k orgKey, t *githubTeam) {
+	e := c.entry(k)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.teams != nil {
+		e.teams[t.GetSlug()] = t
+	}
+}
+
+// Forget removes the team of the supplied organization with the supplied
+// slug from the cache, for example once it was changed.
+func (c *observationCache) Forget(k orgKey, slug string) {
+	e := c.entry(k)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.teams, slug)
+}
+
+// listTeams returns all teams of the supplied organization.
+func listTeams(ctx context.Context, svc *github.Client, org string) ([]*githubTeam, error) {
+	return kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*githubTeam, *github.Response, error) {
+		req, err := svc.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v/teams?per_page=%d&page=%d", org, opts.PerPage, opts.Page), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var teams []*githubTeam
+		res, err := svc.Do(ctx, req, &teams)
+		return teams, res, err
+	})
+}
@@ -19,51 +19,99 @@ package team
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
-	"github.com/google/go-github/v45/github"
+	"github.com/google/go-github/v66/github"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
 	apisv1alpha1 "github.com/hasheddan/kc-provider-github/apis/v1alpha1"
 	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
 )
 
 const (
-	errNotTeam       = "managed resource is not a Team custom resource"
-	errCreateService = "failed to create client service"
+	errNotTeam         = "managed resource is not a Team custom resource"
+	errCreateService   = "failed to create client service"
+	errGetParentTeam   = "cannot get parent team"
+	errFmtSecretNested = "team %s cannot be secret because it is nested under team %s, and nested teams must be closed"
+	errGetTeam         = "cannot get team"
+	errListTeams       = "cannot list teams"
+	errListMembers     = "cannot list team members"
+	errCreateTeam      = "cannot create team"
+	errUpdateTeam      = "cannot update team"
+	errDeleteTeam      = "cannot delete team"
+)
+
+// maxRecordedMembers is the maximum number of members, and of maintainers,
+// recorded in the status of a team, which must not grow without bound.
+const maxRecordedMembers = 100
+
+// Event reasons.
+const (
+	reasonStaleCache event.Reason = "StaleObservationCache"
 )
 
 // Setup adds a controller that reconciles MyType managed resources.
-func SetupTeam(mgr ctrl.Manager, l logging.Logger) error {
-	name := managed.ControllerName(v1alpha1.TeamGroupKind)
+func SetupTeam(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.TeamGroupKind)
+	kcgitclient.RequireScopes(v1beta1.TeamKind, "admin:org")
+
+	var cache *observationCache
+	if o.Features.Enabled(features.EnableAlphaTeamObservationCache) {
+		cache = newObservationCache(cacheTTL)
+	}
 
 	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.TeamGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:  mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})}),
-		managed.WithLogger(l.WithValues("controller", name)),
+		resource.ManagedKind(v1beta1.TeamGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{
+			kube:   mgr.GetClient(),
+			usage:  resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			audit:  kcgitclient.NewAuditor(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger.WithValues("controller", name)),
+			record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+			cache:  cache})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithInitializers(externalname.NewDefaulter(mgr.GetClient(), externalname.Unchanged, externalname.ValidateTeamName)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Team{}).
-		Complete(r)
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.Team{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1beta1.TeamGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1beta1.TeamKind, r))), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube  client.Client
-	usage resource.Tracker
+	kube   client.Client
+	usage  resource.Tracker
+	audit  *kcgitclient.Auditor
+	record event.Recorder
+
+	// cache is shared by the clients of all teams. It is nil unless the
+	// observation cache is enabled.
+	cache *observationCache
 }
 
 // Connect typically produces an ExternalClient by:
@@ -72,7 +120,7 @@ type connector struct {
 // 3. Getting the ProviderConfig's credentials secret.
 // 4. Using the credentials secret to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	_, ok := mg.(*v1alpha1.Team)
+	_, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return nil, errors.New(errNotTeam)
 	}
@@ -80,7 +128,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errCreateService)
 	}
-	return &external{service: svc}, nil
+	return &external{service: svc, audit: c.audit, record: c.record, cache: c.cache}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -89,38 +137,53 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service *github.Client
+	audit   *kcgitclient.Auditor
+	record  event.Recorder
+	cache   *observationCache
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotTeam)
 	}
 
-	team, _, err := c.service.Teams.GetTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
-	if err != nil {
-		return managed.ExternalObservation{
-			ResourceExists: false,
-		}, nil
-	}
-
-	if team.NodeID != nil {
-		cr.Status.AtProvider.NodeID = *team.NodeID
-	}
-
-	upToDate := true
-	if team != nil {
-		if cr.Spec.ForProvider.Description != nil {
-			if team.Description == nil || *team.Description != *cr.Spec.ForProvider.Description {
-				upToDate = false
-			}
+	k := key(cr)
+	if c.cache != nil {
+		team, age, ok, err := c.cache.Get(ctx, c.service, k, slug(cr))
+		if err != nil {
+			return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListTeams)
 		}
-		if cr.Spec.ForProvider.Privacy != nil {
-			if team.Privacy == nil || *team.Privacy != *cr.Spec.ForProvider.Privacy {
-				upToDate = false
+		if ok {
+			observe(cr, team)
+			if d := diff(cr, team); d.UpToDate() {
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, c.observeMembers(ctx, cr)
 			}
+			// The cached team may be stale, so it is observed directly
+			// before it is changed.
+			c.record.Event(cr, event.Normal(reasonStaleCache, fmt.Sprintf("Observing team directly since the observation cached %s ago is not up to date", age.Round(time.Second))))
 		}
 	}
+
+	team, err := c.getTeam(ctx, cr.Spec.ForProvider.Org, slug(cr))
+	if kcgitclient.IsNotFound(err) {
+		// The observed state is only forgotten once GitHub reports that the
+		// team is gone. Any other error leaves it as it was last observed.
+		cr.Status.AtProvider = v1beta1.TeamObservation{}
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetTeam)
+	}
+	if c.cache != nil {
+		c.cache.Set(k, team)
+	}
+	observe(cr, team)
+	if err := c.observeMembers(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	d := diff(cr, team)
+
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -130,53 +193,272 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: upToDate,
+		ResourceUpToDate: d.UpToDate(),
+		Diff:             d.String(),
 	}, nil
 }
 
+// observe sets the observed state of the supplied team. Listed teams do not
+// include the numbers of their members and repositories, and a response may
+// omit other fields, so fields are only set if they are known. A team without
+// a parent is known to have none.
+func observe(cr *v1beta1.Team, team *githubTeam) {
+	o := &cr.Status.AtProvider
+	if team.ID != nil {
+		o.ID = team.GetID()
+	}
+	if team.NodeID != nil {
+		o.NodeID = team.GetNodeID()
+	}
+	if team.Slug != nil {
+		o.Slug = team.GetSlug()
+	}
+	if team.HTMLURL != nil {
+		o.HTMLURL = team.GetHTMLURL()
+	}
+	o.ParentTeam = team.GetParent().GetSlug()
+	if team.NotificationSetting != nil {
+		o.NotificationSetting = *team.NotificationSetting
+	}
+	if team.MembersCount != nil {
+		o.MembersCount = team.GetMembersCount()
+	}
+	if team.ReposCount != nil {
+		o.ReposCount = team.GetReposCount()
+	}
+}
+
+// observeMembers sets the observed members and maintainers of the supplied
+// team, if they are to be observed. They are left as they were last observed
+// if they cannot be listed.
+func (c *external) observeMembers(ctx context.Context, cr *v1beta1.Team) error {
+	o := &cr.Status.AtProvider
+	if !cr.Spec.ForProvider.ObserveMembers {
+		o.Members, o.Maintainers, o.MaintainersCount, o.MembersTruncated = nil, nil, 0, false
+		return nil
+	}
+
+	list := func(role string) ([]string, error) {
+		users, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+			return c.service.Teams.ListTeamMembersBySlug(ctx, cr.Spec.ForProvider.Org, slug(cr), &github.TeamListTeamMembersOptions{Role: role, ListOptions: *opts})
+		})
+		if err != nil {
+			return nil, kcgitclient.WrapAPIError(err, errListMembers)
+		}
+		logins := make([]string, 0, len(users))
+		for _, u := range users {
+			logins = append(logins, u.GetLogin())
+		}
+		return logins, nil
+	}
+
+	members, err := list("member")
+	if err != nil {
+		return err
+	}
+	maintainers, err := list("maintainer")
+	if err != nil {
+		return err
+	}
+	o.MaintainersCount = len(maintainers)
+	o.MembersTruncated = len(members) > maxRecordedMembers || len(maintainers) > maxRecordedMembers
+	o.Members = truncate(members, maxRecordedMembers)
+	o.Maintainers = truncate(maintainers, maxRecordedMembers)
+	return nil
+}
+
+// truncate returns at most the first n of the supplied logins.
+func truncate(logins []string, n int) []string {
+	if len(logins) > n {
+		return logins[:n]
+	}
+	return logins
+}
+
+// diff returns the differences between the desired and the observed state of
+// the supplied team.
+func diff(cr *v1beta1.Team, team *githubTeam) *compare.Diff {
+	p := cr.Spec.ForProvider
+	d := &compare.Diff{}
+	compare.DiffOptional(d, "displayName", github.String(name(cr)), team.Name)
+	compare.DiffOptional(d, "description", p.Description, team.Description)
+	compare.DiffOptional(d, "privacy", p.Privacy, team.Privacy)
+	compare.DiffOptional(d, "notificationSetting", p.NotificationSetting, team.NotificationSetting)
+	compare.DiffOptional(d, "parentTeam", p.ParentTeam, github.String(team.GetParent().GetSlug()))
+	return d
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotTeam)
 	}
 
-	fmt.Printf("Creating: %+v", cr)
+	ctx = c.audit.Context(ctx, cr)
+
+	// GitHub finds teams by their slug, so a team whose name differs but
+	// has the same slug would be created as a near-duplicate.
+	if err := externalname.PreventNearDuplicates(ctx, c.record, cr, name(cr), externalname.Slug, c.names(cr.Spec.ForProvider.Org)); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	t, err := c.newTeam(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, _, err = c.service.Teams.CreateTeam(ctx, cr.Spec.ForProvider.Org, t)
+	if kcgitclient.IsUnprocessable(err) && c.created(ctx, cr) {
+		return managed.ExternalCreation{}, nil
+	}
+
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateTeam)
+}
 
-	_, _, err := c.service.Teams.CreateTeam(ctx, cr.Spec.ForProvider.Org, github.NewTeam{
-		Name:        meta.GetExternalName(cr),
-		Description: cr.Spec.ForProvider.Description,
-		Privacy:     cr.Spec.ForProvider.Privacy,
-	})
+// names returns a function that lists the names of the teams of the
+// supplied organization.
+func (c *external) names(org string) externalname.ListFn {
+	return func(ctx context.Context) ([]string, error) {
+		teams, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+			return c.service.Teams.ListTeams(ctx, org, opts)
+		})
+		if err != nil {
+			return nil, kcgitclient.WrapAPIError(err, errListTeams)
+		}
+		names := make([]string, 0, len(teams))
+		for _, t := range teams {
+			names = append(names, t.GetName())
+		}
+		return names, nil
+	}
+}
 
-	return managed.ExternalCreation{}, err
+// created returns true if the supplied team exists. GitHub refuses to create
+// a team whose name is taken, which is the case if an earlier creation
+// succeeded but its result was lost, for example because the request timed
+// out. The external name of a team is set before it is created, so the team
+// can be found and creating it again is a no-op.
+func (c *external) created(ctx context.Context, cr *v1beta1.Team) bool {
+	team, err := c.getTeam(ctx, cr.Spec.ForProvider.Org, externalname.Slug(name(cr)))
+	return err == nil && team.GetName() == name(cr)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotTeam)
 	}
 
-	fmt.Printf("Updating: %+v", cr)
+	ctx = c.audit.Context(ctx, cr)
 
-	_, _, err := c.service.Teams.EditTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr), github.NewTeam{
-		Name:        meta.GetExternalName(cr),
-		Description: cr.Spec.ForProvider.Description,
-		Privacy:     cr.Spec.ForProvider.Privacy,
-	}, false)
+	t, err := c.newTeam(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	// An empty parent team un-nests the team, which GitHub only does if the
+	// parent is removed explicitly.
+	removeParent := pointer.StringDeref(cr.Spec.ForProvider.ParentTeam, "-") == ""
+	team, _, err := c.service.Teams.EditTeamBySlug(ctx, cr.Spec.ForProvider.Org, slug(cr), t, removeParent)
+	if err != nil {
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateTeam)
+	}
+	c.forget(cr)
+	// GitHub derives the slug from the name, so renaming the team changes it.
+	cr.Status.AtProvider.Slug = team.GetSlug()
 
-	return managed.ExternalUpdate{}, err
+	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	cr, ok := mg.(*v1alpha1.Team)
+	cr, ok := mg.(*v1beta1.Team)
 	if !ok {
 		return errors.New(errNotTeam)
 	}
 
-	fmt.Printf("Deleting: %+v", cr)
+	ctx = c.audit.Context(ctx, cr)
+
+	_, err := c.service.Teams.DeleteTeamBySlug(ctx, cr.Spec.ForProvider.Org, slug(cr))
+	if err := kcgitclient.DeleteError(ctx, cr, err, errDeleteTeam); err != nil {
+		return err
+	}
+	c.forget(cr)
+	return nil
+}
+
+// forget removes the supplied team from the observation cache once it was
+// changed, so that it is observed directly next time.
+func (c *external) forget(cr *v1beta1.Team) {
+	if c.cache != nil {
+		c.cache.Forget(key(cr), slug(cr))
+	}
+}
+
+// A githubTeam is a team as returned by the GitHub API, which includes the
+// notification setting the go-github Team does not.
+type githubTeam struct {
+	github.Team
+	NotificationSetting *string `json:"notification_setting,omitempty"`
+}
+
+// getTeam returns the team with the supplied slug.
+func (c *external) getTeam(ctx context.Context, org, slug string) (*githubTeam, error) {
+	req, err := c.service.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v/teams/%v", org, slug), nil)
+	if err != nil {
+		return nil, err
+	}
+	t := &githubTeam{}
+	if _, err := c.service.Do(ctx, req, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// newTeam returns the desired state of the supplied team. The parent team is
+// looked up by its slug, since GitHub expects its ID. An empty parent team
+// means the team is not nested, so there is nothing to look up.
+func (c *external) newTeam(ctx context.Context, cr *v1beta1.Team) (github.NewTeam, error) {
+	t := github.NewTeam{
+		Name:                name(cr),
+		Description:         cr.Spec.ForProvider.Description,
+		Privacy:             cr.Spec.ForProvider.Privacy,
+		NotificationSetting: cr.Spec.ForProvider.NotificationSetting,
+	}
+	if pointer.StringDeref(cr.Spec.ForProvider.ParentTeam, "") != "" {
+		// GitHub rejects this with an error that names neither field.
+		if pointer.StringDeref(t.Privacy, "") == "secret" {
+			return github.NewTeam{}, errors.Errorf(errFmtSecretNested, t.Name, *cr.Spec.ForProvider.ParentTeam)
+		}
+		parent, _, err := c.service.Teams.GetTeamBySlug(ctx, cr.Spec.ForProvider.Org, *cr.Spec.ForProvider.ParentTeam)
+		if err != nil {
+			return github.NewTeam{}, kcgitclient.WrapAPIError(err, errGetParentTeam)
+		}
+		t.ParentTeamID = parent.ID
+	}
+	return t, nil
+}
 
-	_, err := c.service.Teams.DeleteTeamBySlug(ctx, cr.Spec.ForProvider.Org, meta.GetExternalName(cr))
+// key returns the key of the organization of the supplied team in the
+// observation cache.
+func key(cr *v1beta1.Team) orgKey {
+	k := orgKey{org: cr.Spec.ForProvider.Org}
+	if ref := cr.GetProviderConfigReference(); ref != nil {
+		k.providerConfig = ref.Name
+	}
+	return k
+}
 
-	return err
+// name returns the name of the supplied team, which is its display name or,
+// if that is unset, its external name.
+func name(cr *v1beta1.Team) string {
+	if cr.Spec.ForProvider.DisplayName != nil {
+		return *cr.Spec.ForProvider.DisplayName
+	}
+	return meta.GetExternalName(cr)
+}
+
+// slug returns the slug of the supplied team, which GitHub derives from its
+// name.
+func slug(cr *v1beta1.Team) string {
+	if cr.Status.AtProvider.Slug != "" {
+		return cr.Status.AtProvider.Slug
+	}
+	return externalname.Slug(name(cr))
 }
@@ -0,0 +1,439 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package team
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+const org = "acme"
+
+// newTeam returns a Team with the supplied external name in the acme
+// organization.
+func newTeam(name string, o ...func(*v1beta1.Team)) *v1beta1.Team {
+	cr := &v1beta1.Team{}
+	meta.SetExternalName(cr, name)
+	cr.Spec.ForProvider.Org = org
+	for _, fn := range o {
+		fn(cr)
+	}
+	return cr
+}
+
+func withDescription(d string) func(*v1beta1.Team) {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Description = pointer.String(d) }
+}
+
+func withPrivacy(p string) func(*v1beta1.Team) {
+	return func(cr *v1beta1.Team) { cr.Spec.ForProvider.Privacy = pointer.String(p) }
+}
+
+// newExternal returns a client of the supplied server.
+func newExternal(s *ghserver.Server, cache *observationCache) *external {
+	return &external{
+		service: s.GitHubClient(),
+		audit:   kcgitclient.NewAuditor(event.NewNopRecorder(), logging.NewNopLogger()),
+		record:  event.NewNopRecorder(),
+		cache:   cache,
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cache  *observationCache
+	}{
+		"Uncached": {
+			reason: "A team should be created, updated, and deleted when observed directly.",
+		},
+		"Cached": {
+			reason: "A team should be created, updated, and deleted when observed using the observation cache.",
+			cache:  newObservationCache(time.Hour),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			e := newExternal(s, tc.cache)
+			ctx := context.Background()
+
+			cr := newTeam("Platform Team", withDescription("Builds the platform"), withPrivacy("closed"))
+			observe := func(want managed.ExternalObservation) {
+				t.Helper()
+				got, err := e.Observe(ctx, cr)
+				if err != nil {
+					t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+				}
+				// The diff is only a description of the differences.
+				got.Diff = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Fatalf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+				}
+			}
+
+			// The team does not exist yet.
+			observe(managed.ExternalObservation{ResourceExists: false})
+
+			if _, err := e.Create(ctx, cr); err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v", tc.reason, err)
+			}
+			team := s.Team(org, "platform-team")
+			if team == nil {
+				t.Fatalf("\n%s\ne.Create(...): want team platform-team on the server", tc.reason)
+			}
+
+			// The created team is up to date, and observed.
+			observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+			wantStatus := v1beta1.TeamObservation{
+				ID:      team.GetID(),
+				NodeID:  team.GetNodeID(),
+				Slug:    "platform-team",
+				HTMLURL: team.GetHTMLURL(),
+			}
+			if diff := cmp.Diff(wantStatus, cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+
+			// A changed description makes the team outdated until it is
+			// updated.
+			cr.Spec.ForProvider.Description = pointer.String("Runs the platform")
+			observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false})
+			if _, err := e.Update(ctx, cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if got := s.Team(org, "platform-team").GetDescription(); got != "Runs the platform" {
+				t.Errorf("\n%s\ne.Update(...): want description %q on the server, got %q", tc.reason, "Runs the platform", got)
+			}
+			observe(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true})
+
+			if err := e.Delete(ctx, cr); err != nil {
+				t.Fatalf("\n%s\ne.Delete(...): %v", tc.reason, err)
+			}
+			if s.Team(org, "platform-team") != nil {
+				t.Errorf("\n%s\ne.Delete(...): want team platform-team to be deleted from the server", tc.reason)
+			}
+
+			// The deleted team is gone, and its observed state forgotten.
+			observe(managed.ExternalObservation{ResourceExists: false})
+			if diff := cmp.Diff(v1beta1.TeamObservation{}, cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateIdempotent(t *testing.T) {
+	type want struct {
+		o     managed.ExternalObservation
+		teams int
+	}
+	cases := map[string]struct {
+		reason string
+		// created is called with a Team as it was before it was created, and
+		// returns it as the next reconcile sees it.
+		created func(t *testing.T, s *ghserver.Server, e *external, cr *v1beta1.Team) *v1beta1.Team
+		want    want
+	}{
+		"AnnotationUpdateLost": {
+			reason: "A team whose creation could not be recorded should be observed to exist, rather than be created again.",
+			created: func(t *testing.T, _ *ghserver.Server, e *external, cr *v1beta1.Team) *v1beta1.Team {
+				if _, err := e.Create(context.Background(), cr.DeepCopy()); err != nil {
+					t.Fatalf("e.Create(...): %v", err)
+				}
+				// Neither the annotations nor the status written after the
+				// team was created were persisted.
+				return cr
+			},
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, teams: 1},
+		},
+		"ResponseLost": {
+			reason: "Creating a team again whose creation succeeded but whose response was lost should be a no-op.",
+			created: func(t *testing.T, s *ghserver.Server, e *external, cr *v1beta1.Team) *v1beta1.Team {
+				s.AddTeam(org, meta.GetExternalName(cr))
+				if _, err := e.Create(context.Background(), cr); err != nil {
+					t.Fatalf("e.Create(...): %v", err)
+				}
+				return cr
+			},
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, teams: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			e := newExternal(s, nil)
+
+			cr := tc.created(t, s, e, newTeam("Platform Team", withPrivacy("secret")))
+			o, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			o.Diff = ""
+			teams, _, err := s.GitHubClient().Teams.ListTeams(context.Background(), org, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, want{o: o, teams: len(teams)}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		forbidden bool
+		reason    xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason string
+		setup  func(s *ghserver.Server)
+		want   want
+	}{
+		"AlreadyDeleted": {
+			reason: "Deleting a team that no longer exists should succeed, so that its finalizer is removed.",
+		},
+		"Forbidden": {
+			reason: "A forbidden deletion should fail and set the DeletionForbidden condition.",
+			setup: func(s *ghserver.Server) {
+				s.AddTeam(org, "Platform Team")
+				s.Fail(http.MethodDelete, "/orgs/acme/teams/platform-team", http.StatusForbidden, 1)
+			},
+			want: want{forbidden: true, reason: common.ReasonDeletionForbidden},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			if tc.setup != nil {
+				tc.setup(s)
+			}
+
+			cr := newTeam("Platform Team")
+			err := newExternal(s, nil).Delete(context.Background(), cr)
+			if err != nil && !tc.want.forbidden {
+				t.Fatalf("\n%s\ne.Delete(...): %v", tc.reason, err)
+			}
+			got := want{forbidden: kcgitclient.IsForbidden(err), reason: cr.GetCondition(xpv1.TypeReady).Reason}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservePreservesStatus(t *testing.T) {
+	observed := v1beta1.TeamObservation{
+		ID:           42,
+		NodeID:       "T_42",
+		Slug:         "platform-team",
+		HTMLURL:      "https://github.com/orgs/acme/teams/platform-team",
+		MembersCount: 7,
+		ReposCount:   3,
+	}
+
+	type want struct {
+		err    bool
+		status v1beta1.TeamObservation
+	}
+	cases := map[string]struct {
+		reason string
+		setup  func(s *ghserver.Server)
+		want   want
+	}{
+		"ServerError": {
+			reason: "A failed observation should leave the observed state as it was.",
+			setup: func(s *ghserver.Server) {
+				s.AddTeam(org, "Platform Team")
+				s.Fail(http.MethodGet, "/orgs/acme/teams/platform-team", http.StatusInternalServerError, 1)
+			},
+			want: want{err: true, status: observed},
+		},
+		"RateLimited": {
+			reason: "A rate limited observation should leave the observed state as it was.",
+			setup: func(s *ghserver.Server) {
+				s.AddTeam(org, "Platform Team")
+				s.SetRateLimit(ghserver.DefaultRateLimit, 0, time.Now().Add(time.Hour))
+			},
+			want: want{err: true, status: observed},
+		},
+		"Gone": {
+			reason: "The observed state should be forgotten once GitHub reports the team is gone.",
+			want:   want{status: v1beta1.TeamObservation{}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			if tc.setup != nil {
+				tc.setup(s)
+			}
+
+			cr := newTeam("Platform Team")
+			cr.Status.AtProvider = observed
+			_, err := newExternal(s, nil).Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, want{err: err != nil, status: cr.Status.AtProvider}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParentTeam(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		// nested is the slug of the team the team is nested under at first.
+		nested string
+		parent string
+	}{
+		"Nest": {
+			reason: "A team should be nested under its parent team.",
+			parent: "platform",
+		},
+		"Unnest": {
+			reason: "An empty parent team should remove the team from its parent, instead of looking up a team without a slug.",
+			nested: "platform",
+			parent: "",
+		},
+		"Move": {
+			reason: "A team should be moved from one parent team to another.",
+			nested: "platform",
+			parent: "infra",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			e := newExternal(s, nil)
+			ctx := context.Background()
+
+			parents := map[string]*github.Team{"platform": s.AddTeam(org, "Platform"), "infra": s.AddTeam(org, "Infra")}
+			s.AddTeam(org, "Child")
+			if tc.nested != "" {
+				nt := github.NewTeam{Name: "Child", ParentTeamID: parents[tc.nested].ID}
+				if _, _, err := s.GitHubClient().Teams.EditTeamBySlug(ctx, org, "child", nt, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cr := newTeam("Child", withPrivacy("closed"))
+			cr.Spec.ForProvider.ParentTeam = pointer.String(tc.parent)
+			observe := func(want bool) {
+				t.Helper()
+				got, err := e.Observe(ctx, cr)
+				if err != nil {
+					t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+				}
+				if got.ResourceUpToDate != want {
+					t.Fatalf("\n%s\ne.Observe(...): want up to date %t, got %t: %s", tc.reason, want, got.ResourceUpToDate, got.Diff)
+				}
+			}
+
+			observe(false)
+			if _, err := e.Update(ctx, cr); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if got := s.Team(org, "child").GetParent().GetSlug(); got != tc.parent {
+				t.Errorf("\n%s\ne.Update(...): want parent team %q on the server, got %q", tc.reason, tc.parent, got)
+			}
+			observe(true)
+		})
+	}
+}
+
+func TestSecretNested(t *testing.T) {
+	type want struct {
+		err     string
+		created bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		privacy string
+		parent  *string
+		want    want
+	}{
+		"SecretUnderParent": {
+			reason:  "A secret team under a parent team should be rejected with an error that names both teams, before it is created.",
+			privacy: "secret",
+			parent:  pointer.String("platform"),
+			want:    want{err: "team Child cannot be secret because it is nested under team platform, and nested teams must be closed"},
+		},
+		"ClosedUnderParent": {
+			reason:  "A closed team under a parent team should be created.",
+			privacy: "closed",
+			parent:  pointer.String("platform"),
+			want:    want{created: true},
+		},
+		"SecretWithoutParent": {
+			reason:  "A secret team with an empty parent team is not nested, and should be created.",
+			privacy: "secret",
+			parent:  pointer.String(""),
+			want:    want{created: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			s.AddTeam(org, "Platform")
+			cr := newTeam("Child", withPrivacy(tc.privacy))
+			cr.Spec.ForProvider.ParentTeam = tc.parent
+
+			_, err := newExternal(s, nil).Create(context.Background(), cr)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, s.Team(org, "child") != nil); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want created, +got created:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamexternalgroup
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotTeamExternalGroup = "managed resource is not a TeamExternalGroup custom resource"
+	errCreateService        = "failed to create client service"
+	errListGroups           = "cannot list external groups of team"
+	errConnectGroup         = "cannot connect team to external group"
+	errDisconnectGroup      = "cannot disconnect team from external group"
+	errNotEMU               = "external groups are only available to organizations that use Enterprise Managed Users"
+)
+
+// SetupTeamExternalGroup adds a controller that reconciles TeamExternalGroup
+// managed resources.
+func SetupTeamExternalGroup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TeamExternalGroupGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.TeamExternalGroupKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TeamExternalGroupGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TeamExternalGroup{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.TeamExternalGroupGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.TeamExternalGroupKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.TeamExternalGroup); !ok {
+		return nil, errors.New(errNotTeamExternalGroup)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then connects a team to or disconnects it from an
+// external group.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TeamExternalGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamExternalGroup)
+	}
+	p := cr.Spec.ForProvider
+
+	list, _, err := c.service.Teams.ListExternalGroupsForTeamBySlug(ctx, p.Org, pointer.StringDeref(p.Team, ""))
+	if err != nil {
+		return managed.ExternalObservation{}, wrapNotEMU(err, errListGroups)
+	}
+
+	var connected *github.ExternalGroup
+	for _, g := range list.Groups {
+		if g.GetGroupID() == p.ExternalGroupID {
+			connected = g
+		}
+	}
+	if connected == nil {
+		// The team is either not connected or connected to another
+		// group. Connecting replaces the other group.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.GroupName = connected.GetGroupName()
+	if connected.UpdatedAt != nil {
+		t := metav1.NewTime(connected.UpdatedAt.Time)
+		cr.Status.AtProvider.UpdatedAt = &t
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TeamExternalGroup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamExternalGroup)
+	}
+	p := cr.Spec.ForProvider
+
+	_, _, err := c.service.Teams.UpdateConnectedExternalGroup(ctx, p.Org, pointer.StringDeref(p.Team, ""), &github.ExternalGroup{GroupID: &p.ExternalGroupID})
+	if err != nil {
+		return managed.ExternalCreation{}, wrapNotEMU(err, errConnectGroup)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update is a no-op. A connection has no properties besides the team and the
+// group, which identify it.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.TeamExternalGroup); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamExternalGroup)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TeamExternalGroup)
+	if !ok {
+		return errors.New(errNotTeamExternalGroup)
+	}
+	p := cr.Spec.ForProvider
+
+	_, err := c.service.Teams.RemoveConnectedExternalGroup(ctx, p.Org, pointer.StringDeref(p.Team, ""))
+	if err != nil && !kcgitclient.IsNotFound(err) {
+		return wrapNotEMU(err, errDisconnectGroup)
+	}
+	return nil
+}
+
+// wrapNotEMU wraps the supplied error with the supplied message, and explains
+// it if GitHub refused the request because the organization does not use
+// Enterprise Managed Users.
+func wrapNotEMU(err error, msg string) error {
+	err = kcgitclient.WrapAPIError(err, msg)
+	if kcgitclient.IsForbidden(err) {
+		return errors.Wrap(err, errNotEMU)
+	}
+	return err
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamrepositoryset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotTeamRepositorySet = "managed resource is not a TeamRepositorySet custom resource"
+	errCreateService        = "failed to create client service"
+	errListRepositories     = "cannot list repositories of team"
+	errCheckRepository      = "cannot check permission of team on repository %q"
+	errGrantRepository      = "cannot grant team access to repository %q"
+	errRevokeRepository     = "cannot revoke access of team to repository %q"
+	errFmtFailed            = "cannot sync access of team to %d repositories"
+)
+
+// SetupTeamRepositorySet adds a controller that reconciles TeamRepositorySet
+// managed resources.
+func SetupTeamRepositorySet(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TeamRepositorySetGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.TeamRepositorySetKind, "admin:org")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TeamRepositorySetGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TeamRepositorySet{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.TeamRepositorySetGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.TeamRepositorySetKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.TeamRepositorySet); !ok {
+		return nil, errors.New(errNotTeamRepositorySet)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then syncs the repositories a team has access to with a
+// TeamRepositorySet.
+type external struct {
+	service *github.Client
+}
+
+// builtinPermissions are the permissions of built-in repository roles, from
+// the most to the least privileged, as reported by GitHub.
+var builtinPermissions = []string{"admin", "maintain", "push", "triage", "pull"}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TeamRepositorySet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamRepositorySet)
+	}
+
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	grant, revoke := diff(cr, existing)
+
+	cr.Status.AtProvider.Total = len(existing)
+	cr.Status.AtProvider.OutOfSync = len(grant) + len(revoke)
+	if cr.Status.AtProvider.OutOfSync == 0 {
+		cr.Status.AtProvider.Failed = nil
+	}
+
+	// The set exists for as long as the team has access to any of its
+	// repositories.
+	exists := false
+	for _, g := range cr.Spec.ForProvider.Repositories {
+		if _, ok := existing[strings.ToLower(g.Repository)]; ok {
+			exists = true
+			break
+		}
+	}
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: exists}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: cr.Status.AtProvider.OutOfSync == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TeamRepositorySet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamRepositorySet)
+	}
+
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TeamRepositorySet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamRepositorySet)
+	}
+
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// Delete revokes the access of the team to the repositories of the set.
+// Access to repositories that are not part of the set is left untouched
+// regardless of prune.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TeamRepositorySet)
+	if !ok {
+		return errors.New(errNotTeamRepositorySet)
+	}
+
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return err
+	}
+	p := cr.Spec.ForProvider
+	for _, g := range p.Repositories {
+		r, ok := existing[strings.ToLower(g.Repository)]
+		if !ok {
+			continue
+		}
+		_, err := c.service.Teams.RemoveTeamRepoBySlug(ctx, p.Org, pointer.StringDeref(p.Team, ""), p.Org, r.GetName())
+		if err := kcgitclient.DeleteError(ctx, cr, err, fmt.Sprintf(errRevokeRepository, r.GetName())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync grants and optionally revokes access until the team has access to
+// exactly the repositories of the set. A repository that cannot be synced
+// is recorded and skipped, so that one bad entry, such as a repository that
+// does not exist, does not hold up the others. A rate limit or an exhausted
+// rate budget stops the sync, which later picks up where it left off.
+// Mutations are paced by the client to stay clear of the secondary rate
+// limit.
+func (c *external) sync(ctx context.Context, cr *v1alpha1.TeamRepositorySet) error {
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return err
+	}
+	grant, revoke := diff(cr, existing)
+
+	p := cr.Spec.ForProvider
+	slug := pointer.StringDeref(p.Team, "")
+	var failed []v1alpha1.TeamRepositoryFailure
+	for _, g := range grant {
+		_, err := c.service.Teams.AddTeamRepoBySlug(ctx, p.Org, slug, p.Org, g.Repository, &github.TeamAddTeamRepoOptions{Permission: g.Permission})
+		if kcgitclient.IsRateLimit(err) || kcgitclient.IsBudgetExceeded(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errGrantRepository, g.Repository))
+		}
+		if err != nil {
+			failed = append(failed, v1alpha1.TeamRepositoryFailure{Repository: g.Repository, Message: kcgitclient.WrapAPIError(err, fmt.Sprintf(errGrantRepository, g.Repository)).Error()})
+		}
+	}
+	for _, name := range revoke {
+		_, err := c.service.Teams.RemoveTeamRepoBySlug(ctx, p.Org, slug, p.Org, name)
+		if kcgitclient.IsRateLimit(err) || kcgitclient.IsBudgetExceeded(err) {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errRevokeRepository, name))
+		}
+		if err != nil && !kcgitclient.IsNotFound(err) {
+			failed = append(failed, v1alpha1.TeamRepositoryFailure{Repository: name, Message: kcgitclient.WrapAPIError(err, fmt.Sprintf(errRevokeRepository, name)).Error()})
+		}
+	}
+
+	cr.Status.AtProvider.Failed = failed
+	if len(failed) > 0 {
+		return errors.Errorf(errFmtFailed, len(failed))
+	}
+	return nil
+}
+
+// list returns the repositories of the organization the team has access to,
+// keyed by their lowercase name. The permission of the team on repositories
+// the set grants a custom role on is checked individually, as only that
+// reports the role.
+func (c *external) list(ctx context.Context, cr *v1alpha1.TeamRepositorySet) (map[string]*github.Repository, error) {
+	p := cr.Spec.ForProvider
+	slug := pointer.StringDeref(p.Team, "")
+	all, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.service.Teams.ListTeamReposBySlug(ctx, p.Org, slug, opts)
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListRepositories)
+	}
+	repos := make(map[string]*github.Repository, len(all))
+	for _, r := range all {
+		if strings.EqualFold(r.GetOwner().GetLogin(), p.Org) {
+			repos[strings.ToLower(r.GetName())] = r
+		}
+	}
+
+	for _, g := range p.Repositories {
+		r, ok := repos[strings.ToLower(g.Repository)]
+		if !ok || builtin(g.Permission) {
+			continue
+		}
+		checked, _, err := c.service.Teams.IsTeamRepoBySlug(ctx, p.Org, slug, p.Org, r.GetName())
+		if err != nil {
+			return nil, kcgitclient.WrapAPIError(err, fmt.Sprintf(errCheckRepository, r.GetName()))
+		}
+		r.RoleName = checked.RoleName
+	}
+	return repos, nil
+}
+
+// diff returns the grants of the set that must be made, and the names of
+// the repositories access to which must be revoked.
+func diff(cr *v1alpha1.TeamRepositorySet, existing map[string]*github.Repository) (grant []v1alpha1.TeamRepositoryGrant, revoke []string) {
+	desired := map[string]bool{}
+	for _, g := range cr.Spec.ForProvider.Repositories {
+		key := strings.ToLower(g.Repository)
+		desired[key] = true
+		r, ok := existing[key]
+		if !ok || !strings.EqualFold(permission(r), g.Permission) {
+			grant = append(grant, g)
+		}
+	}
+	if !cr.Spec.ForProvider.Prune {
+		return grant, nil
+	}
+	for key, r := range existing {
+		if !desired[key] {
+			revoke = append(revoke, r.GetName())
+		}
+	}
+	return grant, revoke
+}
+
+// permission returns the permission the team has on the supplied repository:
+// the name of its custom role if it was checked, or else its most privileged
+// built-in permission.
+func permission(r *github.Repository) string {
+	if r.RoleName != nil && !builtin(r.GetRoleName()) {
+		return r.GetRoleName()
+	}
+	for _, p := range builtinPermissions {
+		if r.GetPermissions()[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// builtin reports whether the supplied permission is that of a built-in
+// repository role.
+func builtin(permission string) bool {
+	for _, p := range builtinPermissions {
+		if strings.EqualFold(p, permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package branchconflict detects BranchProtections and Rulesets that protect
+// the same branch of a repository. GitHub enforces both, so the stricter of
+// their settings wins and changes to either are surprising.
+package branchconflict
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+)
+
+// indexRepository indexes BranchProtections and Rulesets by the owner and
+// name of their repository.
+const indexRepository = "spec.forProvider.ownerRepository"
+
+const reasonConflict event.Reason = "ConflictingBranchProtection"
+
+const (
+	refPrefix     = "refs/heads/"
+	allBranches   = "~ALL"
+	defaultBranch = "~DEFAULT_BRANCH"
+)
+
+// Setup indexes BranchProtections and Rulesets by their repository, so that
+// their controllers can find the ones that protect the same branches.
+func Setup(mgr ctrl.Manager, _ controller.Options) error {
+	ctx := context.Background()
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.BranchProtection{}, indexRepository, func(o client.Object) []string {
+		p := o.(*v1alpha1.BranchProtection).Spec.ForProvider
+		return []string{repository(p.Owner, p.Repository)}
+	}); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Ruleset{}, indexRepository, func(o client.Object) []string {
+		p := o.(*v1alpha1.Ruleset).Spec.ForProvider
+		return []string{repository(p.Owner, p.Repository)}
+	})
+}
+
+// ForBranchProtection returns the names of the Rulesets that apply to the
+// branch of the supplied BranchProtection.
+func ForBranchProtection(ctx context.Context, kube client.Reader, cr *v1alpha1.BranchProtection) ([]string, error) {
+	p := cr.Spec.ForProvider
+	l := &v1alpha1.RulesetList{}
+	if err := kube.List(ctx, l, client.MatchingFields{indexRepository: repository(p.Owner, p.Repository)}); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, rs := range l.Items {
+		if Matches(rs.Spec.ForProvider.IncludeBranches, rs.Spec.ForProvider.ExcludeBranches, p.Branch) {
+			names = append(names, rs.GetName())
+		}
+	}
+	return names, nil
+}
+
+// ForRuleset returns the names of the BranchProtections whose branch the
+// supplied Ruleset applies to.
+func ForRuleset(ctx context.Context, kube client.Reader, cr *v1alpha1.Ruleset) ([]string, error) {
+	p := cr.Spec.ForProvider
+	l := &v1alpha1.BranchProtectionList{}
+	if err := kube.List(ctx, l, client.MatchingFields{indexRepository: repository(p.Owner, p.Repository)}); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, bp := range l.Items {
+		if Matches(p.IncludeBranches, p.ExcludeBranches, bp.Spec.ForProvider.Branch) {
+			names = append(names, bp.GetName())
+		}
+	}
+	return names, nil
+}
+
+// Report sets the Conflict condition of the supplied managed resource
+// depending on whether any resource of the other kind protects the same
+// branches. A Warning event names the other resources whenever they change.
+func Report(record event.Recorder, mg resource.Managed, kind string, others []string, branches string) {
+	if len(others) == 0 {
+		mg.SetConditions(common.NotConflicting())
+		return
+	}
+	msg := fmt.Sprintf("%s %s also protect %s", kind, strings.Join(others, ", "), branches)
+	if c := mg.GetCondition(common.TypeConflict); c.Reason != common.ReasonConflicting || c.Message != msg {
+		record.Event(mg, event.Warning(reasonConflict, fmt.Errorf("%s", msg)))
+	}
+	mg.SetConditions(common.Conflicting(msg))
+}
+
+// Matches returns true if the supplied branch is included, and not excluded,
+// by the supplied ruleset patterns. Patterns are matched like fnmatch, where *
+// does not match a slash and ** does. The default branch of the repository is
+// unknown here, so ~DEFAULT_BRANCH matches no branch.
+func Matches(include, exclude []string, branch string) bool {
+	return matchesAny(include, branch) && !matchesAny(exclude, branch)
+}
+
+func matchesAny(patterns []string, branch string) bool {
+	for _, p := range patterns {
+		if match(strings.TrimPrefix(p, refPrefix), branch) {
+			return true
+		}
+	}
+	return false
+}
+
+func match(pattern, branch string) bool {
+	switch pattern {
+	case allBranches:
+		return true
+	case defaultBranch:
+		return false
+	}
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == branch
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()).MatchString(branch)
+}
+
+func repository(owner, name string) string {
+	return owner + "/" + name
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchprotection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/branchconflict"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/statuschecks"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotBranchProtection = "managed resource is not a BranchProtection custom resource"
+	errCreateService       = "failed to create client service"
+	errGetProtection       = "cannot get branch protection"
+	errUpdateProtection    = "cannot update branch protection"
+	errRemoveProtection    = "cannot remove branch protection"
+	errListRulesets        = "cannot list rulesets"
+	errResolveChecks       = "cannot resolve required status checks"
+)
+
+// SetupBranchProtection adds a controller that reconciles BranchProtection
+// managed resources.
+func SetupBranchProtection(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.BranchProtectionGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.BranchProtectionKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.BranchProtectionGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient(), record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.BranchProtection{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.BranchProtectionGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.BranchProtectionKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	record event.Recorder
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.BranchProtection); !ok {
+		return nil, errors.New(errNotBranchProtection)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube, record: c.record, checks: statuschecks.NewResolver(svc)}, nil
+}
+
+// An external observes, then either creates, updates, or removes the protection
+// of a branch.
+type external struct {
+	service *github.Client
+	kube    client.Client
+	record  event.Recorder
+	checks  *statuschecks.Resolver
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	prot, _, err := c.service.Repositories.GetBranchProtection(ctx, p.Owner, p.Repository, p.Branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if kcgitclient.IsNotFound(err) {
+		// The repository or the branch does not exist, which is expected
+		// for a while after a repository was created together with its
+		// protection. Reporting the protection as up to date waits for them
+		// until the next poll instead of backing off on errors.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.SetConditions(common.Waiting(fmt.Sprintf("waiting for branch %s of repository %s/%s to exist", p.Branch, p.Owner, p.Repository)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetProtection)
+	}
+
+	cr.Status.AtProvider = v1alpha1.BranchProtectionObservation{URL: prot.GetURL()}
+	cr.SetConditions(xpv1.Available())
+
+	others, err := branchconflict.ForBranchProtection(ctx, c.kube, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListRulesets)
+	}
+	branchconflict.Report(c.record, cr, v1alpha1.RulesetKind, others, fmt.Sprintf("branch %s of repository %s/%s", p.Branch, p.Owner, p.Repository))
+
+	got := observed(prot)
+	sc, err := c.checks.Resolve(ctx, p.RequiredStatusChecks)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveChecks)
+	}
+	p.RequiredStatusChecks = statuschecks.Match(sc, got.RequiredStatusChecks)
+
+	d := diff(p, got)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: d.UpToDate(),
+		Diff:             d.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBranchProtection)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	return managed.ExternalCreation{}, c.update(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBranchProtection)
+	}
+
+	return managed.ExternalUpdate{}, c.update(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BranchProtection)
+	if !ok {
+		return errors.New(errNotBranchProtection)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	p := cr.Spec.ForProvider
+	_, err := c.service.Repositories.RemoveBranchProtection(ctx, p.Owner, p.Repository, p.Branch)
+	return kcgitclient.DeleteError(ctx, cr, err, errRemoveProtection)
+}
+
+// update replaces the protection of the branch with the desired one.
+func (c *external) update(ctx context.Context, cr *v1alpha1.BranchProtection) error {
+	p := cr.Spec.ForProvider
+	sc, err := c.checks.Resolve(ctx, p.RequiredStatusChecks)
+	if err != nil {
+		return errors.Wrap(err, errResolveChecks)
+	}
+	p.RequiredStatusChecks = sc
+	_, _, err = c.service.Repositories.UpdateBranchProtection(ctx, p.Owner, p.Repository, p.Branch, generate(p))
+	return kcgitclient.WrapAPIError(err, errUpdateProtection)
+}
+
+// generate returns a request for the desired protection, whose required
+// status checks were resolved. GitHub replaces the whole protection of a
+// branch, so unset settings are disabled.
+func generate(p v1alpha1.BranchProtectionParameters) *github.ProtectionRequest {
+	r := &github.ProtectionRequest{
+		EnforceAdmins:                  p.EnforceAdmins,
+		RequireLinearHistory:           github.Bool(p.RequireLinearHistory),
+		RequiredConversationResolution: github.Bool(p.RequiredConversationResolution),
+		AllowForcePushes:               github.Bool(p.AllowForcePushes),
+		AllowDeletions:                 github.Bool(p.AllowDeletions),
+	}
+	if sc := p.RequiredStatusChecks; sc != nil {
+		r.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: sc.Strict}
+		checks(r.RequiredStatusChecks, sc.Checks)
+	}
+	if rv := p.RequiredPullRequestReviews; rv != nil {
+		r.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: rv.RequiredApprovingReviewCount,
+			DismissStaleReviews:          rv.DismissStaleReviews,
+			RequireCodeOwnerReviews:      rv.RequireCodeOwnerReviews,
+			RequireLastPushApproval:      github.Bool(rv.RequireLastPushApproval),
+		}
+		if a := rv.DismissalRestrictions; a != nil {
+			users, teams, apps := nonNil(a.Users), nonNil(a.Teams), nonNil(a.Apps)
+			r.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{Users: &users, Teams: &teams, Apps: &apps}
+		}
+		if a := rv.BypassPullRequestAllowances; a != nil {
+			r.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{Users: nonNil(a.Users), Teams: nonNil(a.Teams), Apps: nonNil(a.Apps)}
+		}
+	}
+	if a := p.Restrictions; a != nil {
+		r.Restrictions = &github.BranchRestrictionsRequest{Users: nonNil(a.Users), Teams: nonNil(a.Teams), Apps: nonNil(a.Apps)}
+	}
+	return r
+}
+
+// observed returns the parameters of the supplied protection.
+func observed(o *github.Protection) v1alpha1.BranchProtectionParameters {
+	p := v1alpha1.BranchProtectionParameters{}
+	if o.EnforceAdmins != nil {
+		p.EnforceAdmins = o.EnforceAdmins.Enabled
+	}
+	if o.RequireLinearHistory != nil {
+		p.RequireLinearHistory = o.RequireLinearHistory.Enabled
+	}
+	if o.RequiredConversationResolution != nil {
+		p.RequiredConversationResolution = o.RequiredConversationResolution.Enabled
+	}
+	if o.AllowForcePushes != nil {
+		p.AllowForcePushes = o.AllowForcePushes.Enabled
+	}
+	if o.AllowDeletions != nil {
+		p.AllowDeletions = o.AllowDeletions.Enabled
+	}
+	if sc := o.RequiredStatusChecks; sc != nil {
+		p.RequiredStatusChecks = &v1alpha1.RequiredStatusChecks{Strict: sc.Strict, Checks: observedChecks(sc)}
+	}
+	if rv := o.RequiredPullRequestReviews; rv != nil {
+		p.RequiredPullRequestReviews = &v1alpha1.RequiredPullRequestReviews{
+			RequiredApprovingReviewCount: rv.RequiredApprovingReviewCount,
+			DismissStaleReviews:          rv.DismissStaleReviews,
+			RequireCodeOwnerReviews:      rv.RequireCodeOwnerReviews,
+			RequireLastPushApproval:      rv.RequireLastPushApproval,
+		}
+		if a := rv.DismissalRestrictions; a != nil {
+			p.RequiredPullRequestReviews.DismissalRestrictions = actors(a.Users, a.Teams, a.Apps)
+		}
+		if a := rv.BypassPullRequestAllowances; a != nil {
+			p.RequiredPullRequestReviews.BypassPullRequestAllowances = actors(a.Users, a.Teams, a.Apps)
+		}
+	}
+	if a := o.Restrictions; a != nil {
+		p.Restrictions = actors(a.Users, a.Teams, a.Apps)
+	}
+	return p
+}
+
+// diff returns the differences between the desired and the observed
+// protection. The order of checks, users, teams, and apps does not matter, and
+// no actors are the same as unset actors.
+func diff(desired, got v1alpha1.BranchProtectionParameters) *compare.Diff {
+	opts := []cmp.Option{cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }), statuschecks.Order}
+	d := &compare.Diff{}
+	for _, f := range []struct {
+		name          string
+		desired, have interface{}
+	}{
+		{"enforceAdmins", desired.EnforceAdmins, got.EnforceAdmins},
+		{"requireLinearHistory", desired.RequireLinearHistory, got.RequireLinearHistory},
+		{"requiredConversationResolution", desired.RequiredConversationResolution, got.RequiredConversationResolution},
+		{"allowForcePushes", desired.AllowForcePushes, got.AllowForcePushes},
+		{"allowDeletions", desired.AllowDeletions, got.AllowDeletions},
+		{"requiredStatusChecks", desired.RequiredStatusChecks, got.RequiredStatusChecks},
+		{"requiredPullRequestReviews", desired.RequiredPullRequestReviews, got.RequiredPullRequestReviews},
+		{"restrictions", desired.Restrictions, got.Restrictions},
+	} {
+		if !cmp.Equal(f.desired, f.have, opts...) {
+			d.Add(f.name, f.desired, f.have)
+		}
+	}
+	return d
+}
+
+// checks sets the supplied resolved checks on the supplied request. Checks
+// that require no app are sent as contexts, as they were before apps could be
+// required, unless another check requires an app. GitHub then takes an app ID
+// of -1 to allow any app, and would otherwise pick the app that recently
+// provided the check.
+func checks(r *github.RequiredStatusChecks, resolved []v1alpha1.RequiredStatusCheck) {
+	contexts := []string{}
+	withApps := []*github.RequiredStatusCheck{}
+	requireApp := false
+	for _, c := range resolved {
+		contexts = append(contexts, c.Context)
+		id := int64(-1)
+		if c.AppID != nil {
+			id, requireApp = *c.AppID, true
+		}
+		withApps = append(withApps, &github.RequiredStatusCheck{Context: c.Context, AppID: github.Int64(id)})
+	}
+	if requireApp {
+		r.Checks = &withApps
+		return
+	}
+	r.Contexts = &contexts
+}
+
+// observedChecks returns the required checks of the supplied status checks.
+// GitHub reports checks any app may provide without an app, or with -1.
+func observedChecks(sc *github.RequiredStatusChecks) []v1alpha1.RequiredStatusCheck {
+	out := []v1alpha1.RequiredStatusCheck{}
+	if sc.Checks == nil {
+		for _, c := range sc.GetContexts() {
+			out = append(out, v1alpha1.RequiredStatusCheck{Context: c})
+		}
+		return out
+	}
+	for _, c := range *sc.Checks {
+		check := v1alpha1.RequiredStatusCheck{Context: c.Context}
+		if c.GetAppID() > 0 {
+			check.AppID = c.AppID
+		}
+		out = append(out, check)
+	}
+	return out
+}
+
+// actors returns the logins and slugs of the supplied users, teams, and apps.
+// GitHub returns whole apps, which are identified by their slug.
+func actors(users []*github.User, teams []*github.Team, apps []*github.App) *v1alpha1.BranchActors {
+	a := &v1alpha1.BranchActors{}
+	for _, u := range users {
+		a.Users = append(a.Users, u.GetLogin())
+	}
+	for _, t := range teams {
+		a.Teams = append(a.Teams, t.GetSlug())
+	}
+	for _, app := range apps {
+		a.Apps = append(a.Apps, app.GetSlug())
+	}
+	sort.Strings(a.Users)
+	sort.Strings(a.Teams)
+	sort.Strings(a.Apps)
+	return a
+}
+
+// nonNil returns the supplied slice, or an empty one if it is nil. GitHub
+// rejects null where it expects a list.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchprotection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/statuschecks"
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+// newExternal returns a client of the supplied server.
+func newExternal(s *ghserver.Server) *external {
+	c := s.GitHubClient()
+	return &external{
+		service: c,
+		kube:    &test.MockClient{MockList: test.NewMockListFn(nil)},
+		record:  event.NewNopRecorder(),
+		checks:  statuschecks.NewResolver(c),
+	}
+}
+
+func newBranchProtection() *v1alpha1.BranchProtection {
+	cr := &v1alpha1.BranchProtection{}
+	cr.Spec.ForProvider = v1alpha1.BranchProtectionParameters{
+		Owner:         "acme",
+		Repository:    "platform",
+		Branch:        "main",
+		EnforceAdmins: true,
+	}
+	return cr
+}
+
+func condition(c xpv1.Condition) *xpv1.Condition { return &c }
+
+func TestRepositoryAppearsLater(t *testing.T) {
+	s := ghserver.New()
+	defer s.Close()
+	e := newExternal(s)
+	ctx := context.Background()
+	cr := newBranchProtection()
+
+	observe := func(reason string, want managed.ExternalObservation, cond *xpv1.Condition) {
+		t.Helper()
+		got, err := e.Observe(ctx, cr)
+		if err != nil {
+			t.Fatalf("\n%s\ne.Observe(...): %v", reason, err)
+		}
+		got.Diff = ""
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", reason, diff)
+		}
+		if cond == nil {
+			return
+		}
+		if diff := cmp.Diff(*cond, cr.GetCondition(xpv1.TypeReady), test.EquateConditions(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+			t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s", reason, diff)
+		}
+	}
+
+	// Nothing is created while the repository does not exist, without an
+	// error to back off on.
+	observe("A protection of a repository that does not exist yet should wait for it.",
+		managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		condition(common.Waiting("waiting for branch main of repository acme/platform to exist")))
+
+	if _, _, err := s.GitHubClient().Repositories.Create(ctx, "acme", &github.Repository{Name: github.String("platform")}); err != nil {
+		t.Fatal(err)
+	}
+	observe("A protection should be created once its repository exists.",
+		managed.ExternalObservation{ResourceExists: false},
+		nil)
+
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	observe("A created protection should be up to date and available.",
+		managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		condition(xpv1.Available()))
+}
+
+// mixed returns actors of every kind, in no particular order.
+func mixed() *v1alpha1.BranchActors {
+	return &v1alpha1.BranchActors{
+		Users: []string{"octocat", "hubot"},
+		Teams: []string{"platform", "maintainers"},
+		Apps:  []string{"merge-bot", "dependabot"},
+	}
+}
+
+func TestGenerateActors(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.BranchProtectionParameters
+		want   *github.ProtectionRequest
+	}{
+		"Mixed": {
+			reason: "Users, teams, and apps should each be sent in their own list.",
+			p: v1alpha1.BranchProtectionParameters{
+				RequiredPullRequestReviews: &v1alpha1.RequiredPullRequestReviews{
+					DismissalRestrictions:       mixed(),
+					BypassPullRequestAllowances: mixed(),
+				},
+				Restrictions: mixed(),
+			},
+			want: &github.ProtectionRequest{
+				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+					DismissalRestrictionsRequest: &github.DismissalRestrictionsRequest{
+						Users: &[]string{"octocat", "hubot"},
+						Teams: &[]string{"platform", "maintainers"},
+						Apps:  &[]string{"merge-bot", "dependabot"},
+					},
+					BypassPullRequestAllowancesRequest: &github.BypassPullRequestAllowancesRequest{
+						Users: []string{"octocat", "hubot"},
+						Teams: []string{"platform", "maintainers"},
+						Apps:  []string{"merge-bot", "dependabot"},
+					},
+				},
+				Restrictions: &github.BranchRestrictionsRequest{
+					Users: []string{"octocat", "hubot"},
+					Teams: []string{"platform", "maintainers"},
+					Apps:  []string{"merge-bot", "dependabot"},
+				},
+			},
+		},
+		"OnlyApps": {
+			reason: "Unset users and teams should be sent as empty lists, which GitHub requires.",
+			p: v1alpha1.BranchProtectionParameters{
+				RequiredPullRequestReviews: &v1alpha1.RequiredPullRequestReviews{
+					DismissalRestrictions:       &v1alpha1.BranchActors{Apps: []string{"merge-bot"}},
+					BypassPullRequestAllowances: &v1alpha1.BranchActors{Apps: []string{"merge-bot"}},
+				},
+			},
+			want: &github.ProtectionRequest{
+				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+					DismissalRestrictionsRequest: &github.DismissalRestrictionsRequest{
+						Users: &[]string{},
+						Teams: &[]string{},
+						Apps:  &[]string{"merge-bot"},
+					},
+					BypassPullRequestAllowancesRequest: &github.BypassPullRequestAllowancesRequest{
+						Users: []string{},
+						Teams: []string{},
+						Apps:  []string{"merge-bot"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generate(tc.p)
+			// Only the actors are of interest.
+			ignore := cmpopts.IgnoreFields(github.ProtectionRequest{}, "EnforceAdmins", "RequireLinearHistory", "RequiredConversationResolution", "AllowForcePushes", "AllowDeletions")
+			ignoreReviews := cmpopts.IgnoreFields(github.PullRequestReviewsEnforcementRequest{}, "RequireLastPushApproval")
+			if diff := cmp.Diff(tc.want, got, ignore, ignoreReviews); diff != "" {
+				t.Errorf("\n%s\ngenerate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservedActors(t *testing.T) {
+	users := []*github.User{{Login: github.String("octocat")}, {Login: github.String("hubot")}}
+	teams := []*github.Team{{Slug: github.String("platform"), Name: github.String("Platform")}, {Slug: github.String("maintainers"), Name: github.String("Maintainers")}}
+	apps := []*github.App{{Slug: github.String("merge-bot"), Name: github.String("Merge Bot")}, {Slug: github.String("dependabot"), Name: github.String("Dependabot")}}
+	sorted := &v1alpha1.BranchActors{
+		Users: []string{"hubot", "octocat"},
+		Teams: []string{"maintainers", "platform"},
+		Apps:  []string{"dependabot", "merge-bot"},
+	}
+
+	got := observed(&github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			DismissalRestrictions:       &github.DismissalRestrictions{Users: users, Teams: teams, Apps: apps},
+			BypassPullRequestAllowances: &github.BypassPullRequestAllowances{Users: users, Teams: teams, Apps: apps},
+		},
+		Restrictions: &github.BranchRestrictions{Users: users, Teams: teams, Apps: apps},
+	})
+	want := v1alpha1.BranchProtectionParameters{
+		RequiredPullRequestReviews: &v1alpha1.RequiredPullRequestReviews{
+			DismissalRestrictions:       sorted,
+			BypassPullRequestAllowances: sorted,
+		},
+		Restrictions: sorted,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nActors should be observed by their logins and slugs, not their names.\nobserved(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestActorsRoundTrip(t *testing.T) {
+	s := ghserver.New()
+	defer s.Close()
+	e := newExternal(s)
+	ctx := context.Background()
+	if _, _, err := s.GitHubClient().Repositories.Create(ctx, "acme", &github.Repository{Name: github.String("platform")}); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := newBranchProtection()
+	cr.Spec.ForProvider.RequiredPullRequestReviews = &v1alpha1.RequiredPullRequestReviews{
+		DismissalRestrictions:       mixed(),
+		BypassPullRequestAllowances: mixed(),
+	}
+	cr.Spec.ForProvider.Restrictions = mixed()
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+
+	o, err := e.Observe(ctx, cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if !o.ResourceUpToDate {
+		t.Errorf("\nA protection with mixed users, teams, and apps should be up to date once created.\ne.Observe(...): %s", o.Diff)
+	}
+
+	cr.Spec.ForProvider.RequiredPullRequestReviews.BypassPullRequestAllowances.Apps = []string{"merge-bot"}
+	o, err = e.Observe(ctx, cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Error("\nA protection should be outdated once an app may no longer bypass its required reviews.\ne.Observe(...): want not up to date")
+	}
+}
+
+func TestRepositoryRenamed(t *testing.T) {
+	s := ghserver.New()
+	defer s.Close()
+	e := newExternal(s)
+	ctx := context.Background()
+	gh := s.GitHubClient()
+	if _, _, err := gh.Repositories.Create(ctx, "acme", &github.Repository{Name: github.String("platform")}); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &v1alpha1.Repository{}
+	repo.SetName("platform")
+	meta.SetExternalName(repo, "platform")
+	repo.Spec.ForProvider.Owner = "acme"
+	repo.SetConditions(xpv1.Available())
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			repo.DeepCopyInto(obj.(*v1alpha1.Repository))
+			return nil
+		}),
+	}
+
+	cr := newBranchProtection()
+	cr.Spec.ForProvider.Owner, cr.Spec.ForProvider.Repository = "", ""
+	cr.Spec.ForProvider.RepositoryRef = &xpv1.Reference{Name: "platform"}
+	resolve := func() {
+		t.Helper()
+		if err := cr.ResolveReferences(ctx, kube); err != nil {
+			t.Fatalf("cr.ResolveReferences(...): %v", err)
+		}
+	}
+	observe := func(reason string) {
+		t.Helper()
+		o, err := e.Observe(ctx, cr)
+		if err != nil {
+			t.Fatalf("\n%s\ne.Observe(...): %v", reason, err)
+		}
+		if !o.ResourceExists || !o.ResourceUpToDate || cr.GetCondition(xpv1.TypeReady).Reason != xpv1.ReasonAvailable {
+			t.Errorf("\n%s\ne.Observe(...): want an existing, up to date, available protection, got %+v and condition %s", reason, o, cr.GetCondition(xpv1.TypeReady).Reason)
+		}
+	}
+
+	resolve()
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	observe("A protection of a referenced repository should be created.")
+
+	// The Repository is renamed, which its controller applies on GitHub.
+	if _, _, err := gh.Repositories.Edit(ctx, "acme", "platform", &github.Repository{Name: github.String("platform-v2")}); err != nil {
+		t.Fatal(err)
+	}
+	meta.SetExternalName(repo, "platform-v2")
+
+	resolve()
+	if diff := cmp.Diff("platform-v2", cr.Spec.ForProvider.Repository); diff != "" {
+		t.Errorf("\nA reference should be resolved again once the repository was renamed.\ncr.ResolveReferences(...): -want, +got:\n%s", diff)
+	}
+	observe("A protection should follow its repository when it is renamed.")
+}
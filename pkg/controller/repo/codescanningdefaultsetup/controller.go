@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codescanningdefaultsetup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotCodeScanningDefaultSetup = "managed resource is not a CodeScanningDefaultSetup custom resource"
+	errCreateService               = "failed to create client service"
+	errGetSetup                    = "cannot get code scanning default setup"
+	errUpdateSetup                 = "cannot update code scanning default setup"
+	errGetRun                      = "cannot get default setup workflow run"
+	errAdvancedSetup               = "the repository uses advanced setup for code scanning, which must be disabled before default setup can be configured"
+)
+
+// SetupCodeScanningDefaultSetup adds a controller that reconciles
+// CodeScanningDefaultSetup managed resources.
+func SetupCodeScanningDefaultSetup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.CodeScanningDefaultSetupGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.CodeScanningDefaultSetupKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CodeScanningDefaultSetupGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CodeScanningDefaultSetup{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.CodeScanningDefaultSetupGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.CodeScanningDefaultSetupKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.CodeScanningDefaultSetup); !ok {
+		return nil, errors.New(errNotCodeScanningDefaultSetup)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then updates the code scanning default setup of a
+// repository.
+type external struct {
+	service *github.Client
+}
+
+const (
+	stateConfigured    = "configured"
+	stateNotConfigured = "not-configured"
+)
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CodeScanningDefaultSetup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCodeScanningDefaultSetup)
+	}
+	p := cr.Spec.ForProvider
+
+	cfg, _, err := c.service.CodeScanning.GetDefaultSetupConfiguration(ctx, p.Owner, p.Repository)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetSetup)
+	}
+
+	cr.Status.AtProvider.State = cfg.GetState()
+	cr.Status.AtProvider.QuerySuite = cfg.GetQuerySuite()
+	cr.Status.AtProvider.Languages = cfg.Languages
+	if cfg.UpdatedAt != nil {
+		t := metav1.NewTime(cfg.UpdatedAt.Time)
+		cr.Status.AtProvider.UpdatedAt = &t
+	}
+
+	// Default setup is a property of the repository, so there is nothing
+	// left to wait for once it is no longer configured.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: cfg.GetState() == stateConfigured}, nil
+	}
+
+	// GitHub applies a configuration asynchronously through a workflow run.
+	// The configuration only reflects the request once the run completed,
+	// so we wait for it instead of requesting the configuration again.
+	if id := cr.Status.AtProvider.RunID; id != 0 {
+		run, _, err := c.service.Actions.GetWorkflowRunByID(ctx, p.Owner, p.Repository, id)
+		if err != nil {
+			return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetRun)
+		}
+		if run.GetStatus() != "completed" {
+			cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("waiting for default setup run %s", cr.Status.AtProvider.RunURL)))
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		cr.Status.AtProvider.RunID = 0
+	}
+
+	upToDate := isUpToDate(p, cfg)
+	if upToDate {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create is never called. Every repository has a default setup configuration,
+// so it always exists.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.CodeScanningDefaultSetup); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCodeScanningDefaultSetup)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CodeScanningDefaultSetup)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCodeScanningDefaultSetup)
+	}
+
+	return managed.ExternalUpdate{}, c.update(ctx, cr, generate(cr.Spec.ForProvider))
+}
+
+// Delete disables default setup.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CodeScanningDefaultSetup)
+	if !ok {
+		return errors.New(errNotCodeScanningDefaultSetup)
+	}
+
+	return c.update(ctx, cr, &github.UpdateDefaultSetupConfigurationOptions{State: stateNotConfigured})
+}
+
+// update requests the supplied configuration and records the workflow run
+// that applies it. A conflict means the repository uses advanced setup, which
+// we report through a condition because retrying cannot resolve it.
+func (c *external) update(ctx context.Context, cr *v1alpha1.CodeScanningDefaultSetup, o *github.UpdateDefaultSetupConfigurationOptions) error {
+	p := cr.Spec.ForProvider
+	run, _, err := c.service.CodeScanning.UpdateDefaultSetupConfiguration(ctx, p.Owner, p.Repository, o)
+	if kcgitclient.IsConflict(err) {
+		cr.SetConditions(common.Conflicting(errAdvancedSetup))
+		return nil
+	}
+	// GitHub accepts the configuration and applies it asynchronously.
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		return kcgitclient.WrapAPIError(err, errUpdateSetup)
+	}
+	cr.SetConditions(common.NotConflicting())
+	if run != nil {
+		cr.Status.AtProvider.RunID = run.GetRunID()
+		cr.Status.AtProvider.RunURL = run.GetRunURL()
+	}
+	return nil
+}
+
+func isUpToDate(p v1alpha1.CodeScanningDefaultSetupParameters, cfg *github.DefaultSetupConfiguration) bool {
+	if p.State != cfg.GetState() {
+		return false
+	}
+	if p.State == stateNotConfigured {
+		return true
+	}
+	if p.QuerySuite != nil && *p.QuerySuite != cfg.GetQuerySuite() {
+		return false
+	}
+	return p.Languages == nil || cmp.Equal(p.Languages, cfg.Languages,
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func generate(p v1alpha1.CodeScanningDefaultSetupParameters) *github.UpdateDefaultSetupConfigurationOptions {
+	o := &github.UpdateDefaultSetupConfigurationOptions{State: p.State}
+	if p.State == stateConfigured {
+		o.QuerySuite = p.QuerySuite
+		o.Languages = p.Languages
+	}
+	return o
+}
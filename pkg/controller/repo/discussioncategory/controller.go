@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discussioncategory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotDiscussionCategory = "managed resource is not a DiscussionCategory custom resource"
+	errCreateService         = "failed to create client service"
+	errGetCategories         = "cannot get discussion categories"
+	errDiscussionsDisabled   = "discussions are not enabled on the repository"
+	errCreateUnsupported     = "GitHub does not support creating discussion categories through its API; create the category in the repository's discussion settings and set its slug as the external name"
+	errUpdateUnsupported     = "GitHub does not support updating discussion categories through its API; update the category in the repository's discussion settings"
+)
+
+// SetupDiscussionCategory adds a controller that reconciles DiscussionCategory
+// managed resources.
+func SetupDiscussionCategory(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.DiscussionCategoryGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.DiscussionCategoryKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.DiscussionCategoryGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.DiscussionCategory{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.DiscussionCategoryGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.DiscussionCategoryKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.DiscussionCategory); !ok {
+		return nil, errors.New(errNotDiscussionCategory)
+	}
+	svc, err := kcgitclient.UseProviderConfigGraphQL(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes discussion categories. The GitHub API only allows
+// discussion categories to be read, so drift is reported but cannot be
+// corrected.
+type external struct {
+	service *githubv4.Client
+}
+
+// category is the subset of the DiscussionCategory GraphQL object this
+// controller observes.
+type category struct {
+	ID           githubv4.ID
+	Name         string
+	Slug         string
+	Emoji        string
+	Description  string
+	IsAnswerable bool
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DiscussionCategory)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDiscussionCategory)
+	}
+
+	// The category is not owned by the managed resource, so there is nothing
+	// to wait for once it is being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// A repository has at most 25 discussion categories, so a single page
+	// always holds all of them.
+	var q struct {
+		Repository struct {
+			HasDiscussionsEnabled bool
+			DiscussionCategories  struct {
+				Nodes []category
+			} `graphql:"discussionCategories(first: 100)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	p := cr.Spec.ForProvider
+	if err := c.service.Query(ctx, &q, map[string]interface{}{
+		"owner": githubv4.String(p.Owner),
+		"name":  githubv4.String(p.Repository),
+	}); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCategories)
+	}
+	if !q.Repository.HasDiscussionsEnabled {
+		return managed.ExternalObservation{}, errors.New(errDiscussionsDisabled)
+	}
+
+	var dc *category
+	for i := range q.Repository.DiscussionCategories.Nodes {
+		if q.Repository.DiscussionCategories.Nodes[i].Slug == meta.GetExternalName(cr) {
+			dc = &q.Repository.DiscussionCategories.Nodes[i]
+			break
+		}
+	}
+	if dc == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = v1alpha1.DiscussionCategoryObservation{
+		NodeID:       fmt.Sprint(dc.ID),
+		Name:         dc.Name,
+		Emoji:        dc.Emoji,
+		Description:  dc.Description,
+		IsAnswerable: dc.IsAnswerable,
+	}
+
+	upToDate := (p.Name == nil || dc.Name == *p.Name) &&
+		(p.Emoji == nil || dc.Emoji == *p.Emoji) &&
+		(p.Description == nil || dc.Description == *p.Description) &&
+		(p.IsAnswerable == nil || dc.IsAnswerable == *p.IsAnswerable)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.DiscussionCategory); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDiscussionCategory)
+	}
+	return managed.ExternalCreation{}, errors.New(errCreateUnsupported)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.DiscussionCategory); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDiscussionCategory)
+	}
+	return managed.ExternalUpdate{}, errors.New(errUpdateUnsupported)
+}
+
+// Delete is a no-op. The category is left in place.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.DiscussionCategory); !ok {
+		return errors.New(errNotDiscussionCategory)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issue
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotIssue      = "managed resource is not an Issue custom resource"
+	errCreateService = "failed to create client service"
+	errGetIssue      = "cannot get issue"
+	errCreateIssue   = "cannot create issue"
+	errEditIssue     = "cannot edit issue"
+	errCloseIssue    = "cannot close issue"
+)
+
+// SetupIssue adds a controller that reconciles Issue managed resources.
+func SetupIssue(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.IssueGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.IssueKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.IssueGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Issue{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.IssueGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.IssueKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Issue); !ok {
+		return nil, errors.New(errNotIssue)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, edits, or closes an issue.
+type external struct {
+	service *github.Client
+}
+
+const (
+	stateClosed    = "closed"
+	onDeleteOrphan = "Orphan"
+)
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Issue)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotIssue)
+	}
+
+	// The external name only becomes an issue number once the issue has
+	// been created.
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	i, _, err := c.service.Issues.Get(ctx, p.Owner, p.Repository, number)
+	if kcgitclient.IsNotFound(err) || kcgitclient.IsGone(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetIssue)
+	}
+
+	cr.Status.AtProvider = v1alpha1.IssueObservation{
+		ID:      i.GetID(),
+		NodeID:  i.GetNodeID(),
+		HTMLURL: i.GetHTMLURL(),
+		State:   i.GetState(),
+	}
+
+	// An issue cannot be deleted, so it is gone as far as we are concerned
+	// once it is closed or should be left alone.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: p.OnDelete != onDeleteOrphan && i.GetState() != stateClosed}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isUpToDate(p, i),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Issue)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotIssue)
+	}
+
+	p := cr.Spec.ForProvider
+	req := generate(p)
+	req.Body = p.Body
+	// New issues are always open.
+	req.State = nil
+	i, _, err := c.service.Issues.Create(ctx, p.Owner, p.Repository, req)
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateIssue)
+	}
+
+	meta.SetExternalName(cr, strconv.Itoa(i.GetNumber()))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Issue)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotIssue)
+	}
+
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errEditIssue)
+	}
+
+	p := cr.Spec.ForProvider
+	req := generate(p)
+	if p.EnforceBody {
+		req.Body = p.Body
+	}
+	_, _, err = c.service.Issues.Edit(ctx, p.Owner, p.Repository, number, req)
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditIssue)
+}
+
+// Delete closes the issue unless it should be left as it is. Issues cannot be
+// deleted through the API.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Issue)
+	if !ok {
+		return errors.New(errNotIssue)
+	}
+
+	p := cr.Spec.ForProvider
+	if p.OnDelete == onDeleteOrphan {
+		return nil
+	}
+
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, errCloseIssue)
+	}
+
+	_, _, err = c.service.Issues.Edit(ctx, p.Owner, p.Repository, number, &github.IssueRequest{State: github.String(stateClosed)})
+	return kcgitclient.DeleteError(ctx, cr, err, errCloseIssue)
+}
+
+// isUpToDate compares the parameters that are set with the supplied issue.
+// The body is only compared if it is enforced, and labels and assignees are
+// compared regardless of their order.
+func isUpToDate(p v1alpha1.IssueParameters, i *github.Issue) bool {
+	if p.Title != i.GetTitle() {
+		return false
+	}
+	if p.State != nil && *p.State != i.GetState() {
+		return false
+	}
+	if p.EnforceBody && p.Body != nil && *p.Body != i.GetBody() {
+		return false
+	}
+	labels := make([]string, 0, len(i.Labels))
+	for _, l := range i.Labels {
+		labels = append(labels, l.GetName())
+	}
+	assignees := make([]string, 0, len(i.Assignees))
+	for _, a := range i.Assignees {
+		assignees = append(assignees, a.GetLogin())
+	}
+	unordered := []cmp.Option{cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })}
+	return (p.Labels == nil || cmp.Equal(p.Labels, labels, unordered...)) &&
+		(p.Assignees == nil || cmp.Equal(p.Assignees, assignees, unordered...))
+}
+
+// generate returns the request for the parameters that are compared by
+// isUpToDate, except for the body, which callers set as required.
+func generate(p v1alpha1.IssueParameters) *github.IssueRequest {
+	req := &github.IssueRequest{
+		Title: github.String(p.Title),
+		State: p.State,
+	}
+	if p.Labels != nil {
+		req.Labels = &p.Labels
+	}
+	if p.Assignees != nil {
+		req.Assignees = &p.Assignees
+	}
+	return req
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotLabel           = "managed resource is not a Label custom resource"
+	errCreateService      = "failed to create client service"
+	errGetLabel           = "cannot get label"
+	errCreateLabel        = "cannot create label"
+	errEditLabel          = "cannot edit label"
+	errDeleteLabel        = "cannot delete label"
+	errUpdateExternalName = "cannot update external name of renamed label"
+)
+
+// SetupLabel adds a controller that reconciles Label managed resources.
+func SetupLabel(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.LabelGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.LabelKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LabelGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Label{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.LabelGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.LabelKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Label); !ok {
+		return nil, errors.New(errNotLabel)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a label.
+type external struct {
+	service *github.Client
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	label, _, err := c.service.Issues.GetLabel(ctx, p.Owner, p.Repository, meta.GetExternalName(cr))
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetLabel)
+	}
+
+	cr.Status.AtProvider = v1alpha1.LabelObservation{
+		ID:     label.GetID(),
+		NodeID: label.GetNodeID(),
+		URL:    label.GetURL(),
+	}
+
+	upToDate := label.GetName() == name(cr) &&
+		label.GetColor() == normalizeColor(p.Color) &&
+		(p.Description == nil || label.GetDescription() == *p.Description)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	label, _, err := c.service.Issues.CreateLabel(ctx, p.Owner, p.Repository, generate(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateLabel)
+	}
+
+	meta.SetExternalName(cr, label.GetName())
+	return managed.ExternalCreation{}, nil
+}
+
+// Update edits the label, renaming it when the desired name differs from the
+// external name. The external name tracks the label's current name, so it is
+// persisted immediately after a successful rename.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	current := meta.GetExternalName(cr)
+	label, _, err := c.service.Issues.EditLabel(ctx, p.Owner, p.Repository, current, generate(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditLabel)
+	}
+
+	if label.GetName() != current {
+		meta.SetExternalName(cr, label.GetName())
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateExternalName)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Label)
+	if !ok {
+		return errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := c.service.Issues.DeleteLabel(ctx, p.Owner, p.Repository, meta.GetExternalName(cr))
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteLabel)
+}
+
+// name returns the desired name of the label, which defaults to its external
+// name.
+func name(cr *v1alpha1.Label) string {
+	return pointer.StringDeref(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+}
+
+func generate(cr *v1alpha1.Label) *github.Label {
+	return &github.Label{
+		Name:        github.String(name(cr)),
+		Color:       github.String(normalizeColor(cr.Spec.ForProvider.Color)),
+		Description: cr.Spec.ForProvider.Description,
+	}
+}
+
+// normalizeColor returns the supplied color in the form GitHub reports it:
+// six lowercase digits without a leading #. Three digit colors are expanded
+// the way CSS does, so f00 becomes ff0000.
+func normalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimPrefix(color, "#"))
+	if len(color) == 3 {
+		color = string([]byte{color[0], color[0], color[1], color[1], color[2], color[2]})
+	}
+	return color
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labelset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotLabelSet        = "managed resource is not a LabelSet custom resource"
+	errCreateService      = "failed to create client service"
+	errListLabels         = "cannot list labels"
+	errCreateLabel        = "cannot create label %q"
+	errEditLabel          = "cannot edit label %q"
+	errDeleteLabel        = "cannot delete label %q"
+	errSecondaryRateLimit = "paused label sync after hitting the secondary rate limit"
+)
+
+// SetupLabelSet adds a controller that reconciles LabelSet managed resources.
+func SetupLabelSet(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.LabelSetGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.LabelSetKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LabelSetGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.LabelSet{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.LabelSetGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.LabelSetKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.LabelSet); !ok {
+		return nil, errors.New(errNotLabelSet)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then syncs the labels of a repository with a LabelSet.
+type external struct {
+	service *github.Client
+}
+
+// mutationInterval is how long to wait between mutating calls. GitHub
+// recommends at least a second between mutations to stay clear of the
+// secondary rate limit.
+var mutationInterval = time.Second
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LabelSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLabelSet)
+	}
+
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errListLabels)
+	}
+	create, edit, prune := diff(cr, existing)
+
+	cr.Status.AtProvider.Total = len(existing)
+	cr.Status.AtProvider.OutOfSync = len(create) + len(edit) + len(prune)
+
+	// The set exists for as long as any of its labels exist in the
+	// repository.
+	exists := len(create) < len(cr.Spec.ForProvider.Labels)
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: exists}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: cr.Status.AtProvider.OutOfSync == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LabelSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLabelSet)
+	}
+
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LabelSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLabelSet)
+	}
+
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// Delete deletes the labels of the set from the repository. Labels that are
+// not part of the set are left untouched regardless of prune.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LabelSet)
+	if !ok {
+		return errors.New(errNotLabelSet)
+	}
+
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListLabels)
+	}
+	p := cr.Spec.ForProvider
+	for _, l := range p.Labels {
+		e, ok := existing[strings.ToLower(l.Name)]
+		if !ok {
+			continue
+		}
+		err := c.mutate(ctx, func() (*github.Response, error) {
+			return c.service.Issues.DeleteLabel(ctx, p.Owner, p.Repository, e.GetName())
+		})
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errDeleteLabel, e.GetName()))
+		}
+	}
+	return nil
+}
+
+// sync creates, edits, and optionally prunes labels until the repository
+// matches the set. Each mutation is independent, so a sync that is cut short
+// picks up where it left off on the next reconcile.
+func (c *external) sync(ctx context.Context, cr *v1alpha1.LabelSet) error {
+	existing, err := c.list(ctx, cr)
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListLabels)
+	}
+	create, edit, prune := diff(cr, existing)
+
+	p := cr.Spec.ForProvider
+	for _, l := range create {
+		err := c.mutate(ctx, func() (*github.Response, error) {
+			_, res, err := c.service.Issues.CreateLabel(ctx, p.Owner, p.Repository, generate(l))
+			return res, err
+		})
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errCreateLabel, l.Name))
+		}
+	}
+	for current, l := range edit {
+		current := current
+		err := c.mutate(ctx, func() (*github.Response, error) {
+			_, res, err := c.service.Issues.EditLabel(ctx, p.Owner, p.Repository, current, generate(l))
+			return res, err
+		})
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errEditLabel, current))
+		}
+	}
+	for _, name := range prune {
+		name := name
+		err := c.mutate(ctx, func() (*github.Response, error) {
+			return c.service.Issues.DeleteLabel(ctx, p.Owner, p.Repository, name)
+		})
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, fmt.Sprintf(errDeleteLabel, name))
+		}
+	}
+	return nil
+}
+
+// mutate paces and performs a single mutating call. A 404 on a label that
+// has already gone away is not an error.
+func (c *external) mutate(ctx context.Context, fn func() (*github.Response, error)) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(mutationInterval):
+	}
+	_, err := fn()
+	if kcgitclient.IsNotFound(err) {
+		return nil
+	}
+	var rle *github.AbuseRateLimitError
+	if errors.As(err, &rle) {
+		return errors.Wrap(err, errSecondaryRateLimit)
+	}
+	return err
+}
+
+// list returns all labels of the repository, keyed by their lowercase name.
+func (c *external) list(ctx context.Context, cr *v1alpha1.LabelSet) (map[string]*github.Label, error) {
+	p := cr.Spec.ForProvider
+	all, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return c.service.Issues.ListLabels(ctx, p.Owner, p.Repository, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]*github.Label, len(all))
+	for _, l := range all {
+		labels[strings.ToLower(l.GetName())] = l
+	}
+	return labels, nil
+}
+
+// diff returns the labels of the set that must be created, the labels that
+// must be edited keyed by their current name, and the names of the labels that
+// must be pruned.
+func diff(cr *v1alpha1.LabelSet, existing map[string]*github.Label) (create []v1alpha1.LabelSetItem, edit map[string]v1alpha1.LabelSetItem, prune []string) {
+	edit = map[string]v1alpha1.LabelSetItem{}
+	desired := map[string]bool{}
+	for _, l := range cr.Spec.ForProvider.Labels {
+		key := strings.ToLower(l.Name)
+		desired[key] = true
+		e, ok := existing[key]
+		switch {
+		case !ok:
+			create = append(create, l)
+		case e.GetName() != l.Name,
+			e.GetColor() != normalizeColor(l.Color),
+			l.Description != nil && e.GetDescription() != *l.Description:
+			edit[e.GetName()] = l
+		}
+	}
+	if !cr.Spec.ForProvider.Prune {
+		return create, edit, nil
+	}
+	for key, e := range existing {
+		if !desired[key] {
+			prune = append(prune, e.GetName())
+		}
+	}
+	return create, edit, prune
+}
+
+func generate(l v1alpha1.LabelSetItem) *github.Label {
+	return &github.Label{
+		Name:        github.String(l.Name),
+		Color:       github.String(normalizeColor(l.Color)),
+		Description: l.Description,
+	}
+}
+
+// normalizeColor returns the supplied color in the form GitHub reports it:
+// six lowercase digits without a leading #. Three digit colors are expanded
+// the way CSS does, so f00 becomes ff0000.
+func normalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimPrefix(color, "#"))
+	if len(color) == 3 {
+		color = string([]byte{color[0], color[0], color[1], color[1], color[2], color[2]})
+	}
+	return color
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labelset
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeColor(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		color  string
+		want   string
+	}{
+		"Reported": {
+			reason: "A color in the form GitHub reports it should be unchanged.",
+			color:  "d73a4a",
+			want:   "d73a4a",
+		},
+		"LeadingHash": {
+			reason: "A leading # should be removed and the digits lowercased.",
+			color:  "#D73A4A",
+			want:   "d73a4a",
+		},
+		"Short": {
+			reason: "A three digit color should be expanded the way CSS does.",
+			color:  "#F0a",
+			want:   "ff00aa",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := normalizeColor(tc.color)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnormalizeColor(%q): -want, +got:\n%s", tc.reason, tc.color, diff)
+			}
+		})
+	}
+}
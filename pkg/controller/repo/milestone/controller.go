@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package milestone
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotMilestone    = "managed resource is not a Milestone custom resource"
+	errCreateService   = "failed to create client service"
+	errGetMilestone    = "cannot get milestone"
+	errCreateMilestone = "cannot create milestone"
+	errEditMilestone   = "cannot edit milestone"
+	errDeleteMilestone = "cannot delete milestone"
+)
+
+// SetupMilestone adds a controller that reconciles Milestone managed resources.
+func SetupMilestone(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.MilestoneGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.MilestoneKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.MilestoneGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Milestone{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.MilestoneGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.MilestoneKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Milestone); !ok {
+		return nil, errors.New(errNotMilestone)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a milestone.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Milestone)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMilestone)
+	}
+
+	// The external name only becomes a milestone number once the milestone
+	// has been created.
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	m, _, err := c.service.Issues.GetMilestone(ctx, p.Owner, p.Repository, number)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetMilestone)
+	}
+
+	cr.Status.AtProvider = v1alpha1.MilestoneObservation{
+		ID:           m.GetID(),
+		NodeID:       m.GetNodeID(),
+		HTMLURL:      m.GetHTMLURL(),
+		OpenIssues:   m.GetOpenIssues(),
+		ClosedIssues: m.GetClosedIssues(),
+	}
+
+	upToDate := m.GetTitle() == p.Title &&
+		(p.State == nil || m.GetState() == *p.State) &&
+		(p.Description == nil || m.GetDescription() == *p.Description) &&
+		(p.DueOn == nil || sameSecond(m.GetDueOn().Time, p.DueOn.Time))
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Milestone)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMilestone)
+	}
+
+	p := cr.Spec.ForProvider
+	m, _, err := c.service.Issues.CreateMilestone(ctx, p.Owner, p.Repository, generate(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateMilestone)
+	}
+
+	meta.SetExternalName(cr, strconv.Itoa(m.GetNumber()))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Milestone)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMilestone)
+	}
+
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errEditMilestone)
+	}
+
+	p := cr.Spec.ForProvider
+	_, _, err = c.service.Issues.EditMilestone(ctx, p.Owner, p.Repository, number, generate(cr))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditMilestone)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Milestone)
+	if !ok {
+		return errors.New(errNotMilestone)
+	}
+
+	number, err := strconv.Atoi(meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, errDeleteMilestone)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err = c.service.Issues.DeleteMilestone(ctx, p.Owner, p.Repository, number)
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteMilestone)
+}
+
+func generate(cr *v1alpha1.Milestone) *github.Milestone {
+	p := cr.Spec.ForProvider
+	m := &github.Milestone{
+		Title:       github.String(p.Title),
+		State:       p.State,
+		Description: p.Description,
+	}
+	if p.DueOn != nil {
+		m.DueOn = &github.Timestamp{Time: p.DueOn.Time}
+	}
+	return m
+}
+
+// sameSecond reports whether the supplied times are equal at second
+// precision, which is the precision GitHub stores due dates at.
+func sameSecond(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
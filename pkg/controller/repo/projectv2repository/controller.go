@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectv2repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotProjectV2Repository = "managed resource is not a ProjectV2Repository custom resource"
+	errCreateService          = "failed to create client service"
+	errGetLink                = "cannot get project repositories"
+	errGetRepo                = "cannot get repository"
+	errLink                   = "cannot link project to repository"
+	errUnlink                 = "cannot unlink project from repository"
+)
+
+// SetupProjectV2Repository adds a controller that reconciles
+// ProjectV2Repository managed resources.
+func SetupProjectV2Repository(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ProjectV2RepositoryGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.ProjectV2RepositoryKind, "repo", "project")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ProjectV2RepositoryGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProjectV2Repository{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.ProjectV2RepositoryGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.ProjectV2RepositoryKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ProjectV2Repository); !ok {
+		return nil, errors.New(errNotProjectV2Repository)
+	}
+	svc, err := kcgitclient.UseProviderConfigGraphQL(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either links or unlinks a project and a
+// repository.
+type external struct {
+	service *githubv4.Client
+}
+
+// repositoriesPerPage is how many linked repositories of a project are
+// queried at once.
+const repositoriesPerPage = 100
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2Repository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProjectV2Repository)
+	}
+
+	var q struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $name)"`
+		Node struct {
+			ProjectV2 struct {
+				Repositories struct {
+					Nodes []struct {
+						ID githubv4.ID
+					}
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"repositories(first: $first, after: $cursor)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+	p := cr.Spec.ForProvider
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(p.Owner),
+		"name":   githubv4.String(p.Repository),
+		"id":     githubv4.ID(p.ProjectID),
+		"first":  githubv4.Int(repositoriesPerPage),
+		"cursor": (*githubv4.String)(nil),
+	}
+	for {
+		err := c.service.Query(ctx, &q, vars)
+		// Neither side of a link to a deleted project or repository exists.
+		if kcgitclient.IsGraphQLNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetLink)
+		}
+		id := fmt.Sprint(q.Repository.ID)
+		for _, r := range q.Node.ProjectV2.Repositories.Nodes {
+			if fmt.Sprint(r.ID) == id {
+				cr.Status.AtProvider.RepositoryID = id
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			}
+		}
+		if !q.Node.ProjectV2.Repositories.PageInfo.HasNextPage {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		vars["cursor"] = githubv4.NewString(q.Node.ProjectV2.Repositories.PageInfo.EndCursor)
+	}
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectV2Repository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProjectV2Repository)
+	}
+
+	id, err := c.repositoryID(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	var m struct {
+		LinkProjectV2ToRepository struct {
+			ClientMutationID githubv4.String
+		} `graphql:"linkProjectV2ToRepository(input: $input)"`
+	}
+	return managed.ExternalCreation{}, errors.Wrap(c.service.Mutate(ctx, &m, githubv4.LinkProjectV2ToRepositoryInput{
+		ProjectID:    githubv4.ID(cr.Spec.ForProvider.ProjectID),
+		RepositoryID: id,
+	}, nil), errLink)
+}
+
+// Update is a no-op. A link has nothing to update; changing its project or
+// repository is observed as a link that does not exist yet.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.ProjectV2Repository); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProjectV2Repository)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProjectV2Repository)
+	if !ok {
+		return errors.New(errNotProjectV2Repository)
+	}
+
+	id, err := c.repositoryID(ctx, cr)
+	if kcgitclient.IsGraphQLNotFound(errors.Cause(err)) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var m struct {
+		UnlinkProjectV2FromRepository struct {
+			ClientMutationID githubv4.String
+		} `graphql:"unlinkProjectV2FromRepository(input: $input)"`
+	}
+	err = c.service.Mutate(ctx, &m, githubv4.UnlinkProjectV2FromRepositoryInput{
+		ProjectID:    githubv4.ID(cr.Spec.ForProvider.ProjectID),
+		RepositoryID: id,
+	}, nil)
+	if kcgitclient.IsGraphQLNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errUnlink)
+}
+
+// repositoryID returns the node ID of the repository of the supplied link.
+func (c *external) repositoryID(ctx context.Context, cr *v1alpha1.ProjectV2Repository) (githubv4.ID, error) {
+	var q struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	err := c.service.Query(ctx, &q, map[string]interface{}{
+		"owner": githubv4.String(cr.Spec.ForProvider.Owner),
+		"name":  githubv4.String(cr.Spec.ForProvider.Repository),
+	})
+	return q.Repository.ID, errors.Wrap(err, errGetRepo)
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repositorysettings"
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRepository    = "managed resource is not a Repository custom resource"
+	errCreateService    = "failed to create client service"
+	errGetUser          = "cannot get authenticated user"
+	errGetRepository    = "cannot get repository"
+	errCreateRepository = "cannot create repository"
+	errEditRepository   = "cannot edit repository"
+	errDeleteRepository = "cannot delete repository"
+	errListCollabs      = "cannot list collaborators"
+	errListEnvironments = "cannot list environments"
+	errListRepositories = "cannot list Repository managed resources"
+)
+
+const reasonGeneratedFromTemplate event.Reason = "GeneratedFromTemplate"
+
+// maxRecordedCollaborators is the maximum number of collaborators recorded in
+// the status of a repository, which must not grow without bound.
+const maxRecordedCollaborators = 100
+
+// maxRecordedEnvironments is the maximum number of environments recorded in
+// the status of a repository.
+const maxRecordedEnvironments = 100
+
+// SetupRepository adds a controller that reconciles Repository managed
+// resources.
+func SetupRepository(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RepositoryGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RepositoryKind, "repo")
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RepositoryGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient(), recorder: recorder})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithInitializers(externalname.NewDefaulter(mgr.GetClient(), externalname.RepositoryName, externalname.ValidateRepositoryName)),
+		managed.WithRecorder(recorder))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Repository{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RepositoryGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RepositoryKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube     client.Client
+	recorder event.Recorder
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Repository); !ok {
+		return nil, errors.New(errNotRepository)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube, recorder: c.recorder}, nil
+}
+
+// An external observes, then either creates, edits, or deletes a repository.
+type external struct {
+	service  *github.Client
+	kube     client.Client
+	recorder event.Recorder
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepository)
+	}
+
+	owner, err := c.owner(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetUser)
+	}
+
+	r, _, err := c.service.Repositories.Get(ctx, owner, meta.GetExternalName(cr))
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetRepository)
+	}
+
+	cr.Status.AtProvider = v1alpha1.RepositoryObservation{
+		ID:                 r.GetID(),
+		NodeID:             r.GetNodeID(),
+		HTMLURL:            r.GetHTMLURL(),
+		Owner:              r.GetOwner().GetLogin(),
+		FullName:           r.GetFullName(),
+		DefaultBranch:      r.GetDefaultBranch(),
+		Fork:               r.GetFork(),
+		Parent:             r.GetParent().GetFullName(),
+		Source:             r.GetSource().GetFullName(),
+		TemplateRepository: r.GetTemplateRepository().GetFullName(),
+	}
+	if cr.Spec.ForProvider.ObserveCollaborators {
+		if err := c.observeCollaborators(ctx, cr, r.GetOwner()); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	if cr.Spec.ForProvider.ObserveEnvironments {
+		if err := c.observeEnvironments(ctx, cr, r.GetOwner()); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	cr.SetConditions(xpv1.Available())
+
+	li := lateInitialize(&cr.Spec.ForProvider, r)
+	d := repositorysettings.Diff(settings(cr.Spec.ForProvider), r)
+	if r.GetIsTemplate() && !pointer.BoolDeref(cr.Spec.ForProvider.IsTemplate, true) {
+		if err := c.reportGenerated(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        d.UpToDate(),
+		ResourceLateInitialized: li,
+		Diff:                    d.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepository)
+	}
+
+	if err := repositorysettings.Validate(settings(cr.Spec.ForProvider)); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.SetConditions(xpv1.Creating())
+	r := generate(cr)
+	r.AutoInit = cr.Spec.ForProvider.AutoInit
+	// An empty owner creates the repository for the authenticated user.
+	_, _, err := c.service.Repositories.Create(ctx, cr.Spec.ForProvider.Owner, r)
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateRepository)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepository)
+	}
+
+	if err := repositorysettings.Validate(settings(cr.Spec.ForProvider)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	owner, err := c.owner(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errGetUser)
+	}
+
+	_, _, err = c.service.Repositories.Edit(ctx, owner, meta.GetExternalName(cr), generate(cr))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errEditRepository)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return errors.New(errNotRepository)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	owner, err := c.owner(ctx, cr)
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errGetUser)
+	}
+
+	_, err = c.service.Repositories.Delete(ctx, owner, meta.GetExternalName(cr))
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteRepository)
+}
+
+// owner returns the login of the account that owns the supplied repository,
+// which is the authenticated user if no owner is set.
+func (c *external) owner(ctx context.Context, cr *v1alpha1.Repository) (string, error) {
+	if cr.Spec.ForProvider.Owner != "" {
+		return cr.Spec.ForProvider.Owner, nil
+	}
+	if cr.Status.AtProvider.Owner != "" {
+		return cr.Status.AtProvider.Owner, nil
+	}
+	u, _, err := c.service.Users.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return u.GetLogin(), nil
+}
+
+// isUpToDate compares only the parameters that are set with the supplied
+// repository.
+// observeCollaborators records the direct collaborators of the supplied
+// repository, and how many of them are outside collaborators.
+func (c *external) observeCollaborators(ctx context.Context, cr *v1alpha1.Repository, owner *github.User) error {
+	list := func(affiliation string) ([]*github.User, error) {
+		users, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+			return c.service.Repositories.ListCollaborators(ctx, owner.GetLogin(), meta.GetExternalName(cr), &github.ListCollaboratorsOptions{Affiliation: affiliation, ListOptions: *opts})
+		})
+		return users, kcgitclient.WrapAPIError(err, errListCollabs)
+	}
+
+	direct, err := list("direct")
+	if err != nil {
+		return err
+	}
+	o := &cr.Status.AtProvider
+	o.CollaboratorsTruncated = len(direct) > maxRecordedCollaborators
+	if o.CollaboratorsTruncated {
+		direct = direct[:maxRecordedCollaborators]
+	}
+	o.Collaborators = make([]v1alpha1.CollaboratorObservation, 0, len(direct))
+	for _, u := range direct {
+		o.Collaborators = append(o.Collaborators, v1alpha1.CollaboratorObservation{Login: u.GetLogin(), Permission: u.GetRoleName()})
+	}
+
+	// Only repositories owned by organizations have outside collaborators.
+	if owner.GetType() != "Organization" {
+		return nil
+	}
+	outside, err := list("outside")
+	if err != nil {
+		return err
+	}
+	o.OutsideCollaboratorsCount = len(outside)
+	return nil
+}
+
+// observeEnvironments records the deployment environments of the supplied
+// repository.
+func (c *external) observeEnvironments(ctx context.Context, cr *v1alpha1.Repository, owner *github.User) error {
+	envs, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Environment, *github.Response, error) {
+		r, res, err := c.service.Repositories.ListEnvironments(ctx, owner.GetLogin(), meta.GetExternalName(cr), &github.EnvironmentListOptions{ListOptions: *opts})
+		if err != nil {
+			return nil, res, err
+		}
+		return r.Environments, res, nil
+	})
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListEnvironments)
+	}
+
+	o := &cr.Status.AtProvider
+	o.EnvironmentsTruncated = len(envs) > maxRecordedEnvironments
+	if o.EnvironmentsTruncated {
+		envs = envs[:maxRecordedEnvironments]
+	}
+	o.Environments = make([]v1alpha1.EnvironmentObservation, 0, len(envs))
+	for _, e := range envs {
+		o.Environments = append(o.Environments, environment(e))
+	}
+	return nil
+}
+
+// environment summarizes the protection rules of the supplied environment.
+func environment(e *github.Environment) v1alpha1.EnvironmentObservation {
+	eo := v1alpha1.EnvironmentObservation{
+		Name:                   e.GetName(),
+		CanAdminsBypass:        e.GetCanAdminsBypass(),
+		DeploymentBranchPolicy: "All",
+	}
+	for _, r := range e.ProtectionRules {
+		switch r.GetType() {
+		case "wait_timer":
+			eo.WaitTimer = r.GetWaitTimer()
+		case "required_reviewers":
+			eo.Reviewers = len(r.Reviewers)
+			eo.PreventSelfReview = r.GetPreventSelfReview()
+		}
+	}
+	switch p := e.DeploymentBranchPolicy; {
+	case p.GetProtectedBranches():
+		eo.DeploymentBranchPolicy = "ProtectedBranches"
+	case p.GetCustomBranchPolicies():
+		eo.DeploymentBranchPolicy = "CustomBranchPolicies"
+	}
+	return eo
+}
+
+// reportGenerated records an event listing the managed repositories that
+// were generated from the supplied repository, which is about to no longer be
+// a template. They are not affected, but new repositories can no longer be
+// generated from it.
+func (c *external) reportGenerated(ctx context.Context, cr *v1alpha1.Repository) error {
+	l := &v1alpha1.RepositoryList{}
+	if err := c.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListRepositories)
+	}
+	var generated []string
+	for _, r := range l.Items {
+		if r.Status.AtProvider.TemplateRepository != "" && r.Status.AtProvider.TemplateRepository == cr.Status.AtProvider.FullName {
+			generated = append(generated, r.GetName())
+		}
+	}
+	if len(generated) > 0 {
+		sort.Strings(generated)
+		c.recorder.Event(cr, event.Normal(reasonGeneratedFromTemplate, fmt.Sprintf("Repository is no longer marked as a template; Repositories %s were generated from it and are not affected", strings.Join(generated, ", "))))
+	}
+	return nil
+}
+
+// settings returns the settings of the repository the supplied parameters
+// manage.
+func settings(p v1alpha1.RepositoryParameters) repositorysettings.Settings {
+	return repositorysettings.Settings{
+		Description: p.Description,
+		Homepage:    p.Homepage,
+		Visibility:  p.Visibility,
+		HasIssues:   p.HasIssues,
+		HasProjects: p.HasProjects,
+		HasWiki:     p.HasWiki,
+		IsTemplate:  p.IsTemplate,
+
+		SquashMergeCommitTitle:   p.SquashMergeCommitTitle,
+		SquashMergeCommitMessage: p.SquashMergeCommitMessage,
+		MergeCommitTitle:         p.MergeCommitTitle,
+		MergeCommitMessage:       p.MergeCommitMessage,
+	}
+}
+
+func generate(cr *v1alpha1.Repository) *github.Repository {
+	r := repositorysettings.Edit(settings(cr.Spec.ForProvider))
+	r.Name = github.String(meta.GetExternalName(cr))
+	return r
+}
+
+// lateInitialize fills unset parameters from the supplied repository and
+// reports whether any were filled.
+func lateInitialize(p *v1alpha1.RepositoryParameters, r *github.Repository) bool {
+	li := resource.NewLateInitializer()
+	p.Description = li.LateInitializeStringPtr(p.Description, r.Description)
+	p.Homepage = li.LateInitializeStringPtr(p.Homepage, r.Homepage)
+	p.Visibility = li.LateInitializeStringPtr(p.Visibility, r.Visibility)
+	p.HasIssues = li.LateInitializeBoolPtr(p.HasIssues, r.HasIssues)
+	p.HasProjects = li.LateInitializeBoolPtr(p.HasProjects, r.HasProjects)
+	p.HasWiki = li.LateInitializeBoolPtr(p.HasWiki, r.HasWiki)
+	p.IsTemplate = li.LateInitializeBoolPtr(p.IsTemplate, r.IsTemplate)
+	p.SquashMergeCommitTitle = li.LateInitializeStringPtr(p.SquashMergeCommitTitle, r.SquashMergeCommitTitle)
+	p.SquashMergeCommitMessage = li.LateInitializeStringPtr(p.SquashMergeCommitMessage, r.SquashMergeCommitMessage)
+	p.MergeCommitTitle = li.LateInitializeStringPtr(p.MergeCommitTitle, r.MergeCommitTitle)
+	p.MergeCommitMessage = li.LateInitializeStringPtr(p.MergeCommitMessage, r.MergeCommitMessage)
+	return li.IsChanged()
+}
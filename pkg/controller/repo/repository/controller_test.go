@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/hasheddan/kc-provider-github/apis/common"
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	"github.com/hasheddan/kc-provider-github/pkg/fake/ghserver"
+)
+
+// newRepository returns a Repository with the supplied external name owned by
+// the supplied account, or by the authenticated user if owner is empty.
+func newRepository(owner, name string) *v1alpha1.Repository {
+	cr := &v1alpha1.Repository{}
+	meta.SetExternalName(cr, name)
+	cr.Spec.ForProvider.Owner = owner
+	return cr
+}
+
+// newExternal returns a client of the supplied server.
+func newExternal(s *ghserver.Server) *external {
+	return &external{service: s.GitHubClient(), recorder: event.NewNopRecorder()}
+}
+
+func TestLifecycle(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		owner  string
+		want   string
+	}{
+		"Organization": {
+			reason: "A repository with an owner should be created, updated, and deleted in that account.",
+			owner:  "acme",
+			want:   "acme",
+		},
+		"AuthenticatedUser": {
+			reason: "A repository without an owner should be created, updated, and deleted in the account of the authenticated user.",
+			want:   ghserver.Login,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			e := newExternal(s)
+			ctx := context.Background()
+			cr := newRepository(tc.owner, "platform")
+			cr.Spec.ForProvider.Description = pointer.String("Platform")
+
+			obs, err := e.Observe(ctx, cr)
+			if diff := cmp.Diff(managed.ExternalObservation{}, obs); diff != "" || err != nil {
+				t.Fatalf("\n%s\nObserve(...): -want, +got:\n%s\nerr: %v", tc.reason, diff, err)
+			}
+			if _, err := e.Create(ctx, cr); err != nil {
+				t.Fatalf("\n%s\nCreate(...): %v", tc.reason, err)
+			}
+			if got := s.Repository(tc.want, "platform"); got.GetDescription() != "Platform" {
+				t.Fatalf("\n%s\nCreate(...): want repository %s/platform with description Platform, got %v", tc.reason, tc.want, got)
+			}
+
+			obs, err = e.Observe(ctx, cr)
+			if err != nil || !obs.ResourceExists || !obs.ResourceUpToDate {
+				t.Fatalf("\n%s\nObserve(...): want an existing, up to date repository, got %+v, err: %v", tc.reason, obs, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.Status.AtProvider.Owner); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want owner, +got:\n%s", tc.reason, diff)
+			}
+
+			cr.Spec.ForProvider.Description = pointer.String("Platform services")
+			if obs, _ := e.Observe(ctx, cr); obs.ResourceUpToDate {
+				t.Fatalf("\n%s\nObserve(...): want a changed description to be out of date", tc.reason)
+			}
+			if _, err := e.Update(ctx, cr); err != nil {
+				t.Fatalf("\n%s\nUpdate(...): %v", tc.reason, err)
+			}
+			if got := s.Repository(tc.want, "platform").GetDescription(); got != "Platform services" {
+				t.Errorf("\n%s\nUpdate(...): want description Platform services, got %q", tc.reason, got)
+			}
+
+			if err := e.Delete(ctx, cr); err != nil {
+				t.Fatalf("\n%s\nDelete(...): %v", tc.reason, err)
+			}
+			if got := s.Repository(tc.want, "platform"); got != nil {
+				t.Errorf("\n%s\nDelete(...): want repository %s/platform to be deleted, got %v", tc.reason, tc.want, got)
+			}
+			obs, err = e.Observe(ctx, cr)
+			if diff := cmp.Diff(managed.ExternalObservation{}, obs); diff != "" || err != nil {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\nerr: %v", tc.reason, diff, err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		err    bool
+		reason xpv1.ConditionReason
+		kept   []string
+		gone   []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		setup  func(s *ghserver.Server)
+		cr     func() *v1alpha1.Repository
+		want   want
+	}{
+		"Deleted": {
+			reason: "Only the repository named by the external name in the account of the owner should be deleted.",
+			setup: func(s *ghserver.Server) {
+				create(t, s, "acme", "platform")
+				create(t, s, "acme", "infra")
+				create(t, s, ghserver.Login, "platform")
+			},
+			cr: func() *v1alpha1.Repository { return newRepository("acme", "platform") },
+			want: want{
+				reason: xpv1.ReasonDeleting,
+				kept:   []string{"acme/infra", ghserver.Login + "/platform"},
+				gone:   []string{"acme/platform"},
+			},
+		},
+		"ObservedOwner": {
+			reason: "A repository without an owner should be deleted from the account it was observed in, not from another account with a repository of the same name.",
+			setup: func(s *ghserver.Server) {
+				create(t, s, "acme", "platform")
+				create(t, s, "other", "platform")
+			},
+			cr: func() *v1alpha1.Repository {
+				cr := newRepository("", "platform")
+				cr.Status.AtProvider.Owner = "acme"
+				return cr
+			},
+			want: want{
+				reason: xpv1.ReasonDeleting,
+				kept:   []string{"other/platform"},
+				gone:   []string{"acme/platform"},
+			},
+		},
+		"AlreadyDeleted": {
+			reason: "Deleting a repository that no longer exists should succeed.",
+			cr:     func() *v1alpha1.Repository { return newRepository("acme", "platform") },
+			want:   want{reason: xpv1.ReasonDeleting},
+		},
+		"Forbidden": {
+			reason: "A forbidden deletion should return an error, leave the repository alone, and report that deletion is forbidden.",
+			setup: func(s *ghserver.Server) {
+				create(t, s, "acme", "platform")
+				s.Fail(http.MethodDelete, "/repos/acme/platform", http.StatusForbidden, 1)
+			},
+			cr: func() *v1alpha1.Repository { return newRepository("acme", "platform") },
+			want: want{
+				err:    true,
+				reason: common.ReasonDeletionForbidden,
+				kept:   []string{"acme/platform"},
+			},
+		},
+		"Failed": {
+			reason: "A failed deletion should return an error and leave the repository alone.",
+			setup: func(s *ghserver.Server) {
+				create(t, s, "acme", "platform")
+				s.Fail(http.MethodDelete, "/repos/acme/platform", http.StatusInternalServerError, 1)
+			},
+			cr: func() *v1alpha1.Repository { return newRepository("acme", "platform") },
+			want: want{
+				err:    true,
+				reason: xpv1.ReasonDeleting,
+				kept:   []string{"acme/platform"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := ghserver.New()
+			defer s.Close()
+			if tc.setup != nil {
+				tc.setup(s)
+			}
+			cr := tc.cr()
+
+			err := newExternal(s).Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\nerr: %v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.reason, cr.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want reason, +got:\n%s", tc.reason, diff)
+			}
+			for _, r := range tc.want.kept {
+				owner, name, _ := strings.Cut(r, "/")
+				if s.Repository(owner, name) == nil {
+					t.Errorf("\n%s\nDelete(...): want repository %s to be kept", tc.reason, r)
+				}
+			}
+			for _, r := range tc.want.gone {
+				owner, name, _ := strings.Cut(r, "/")
+				if s.Repository(owner, name) != nil {
+					t.Errorf("\n%s\nDelete(...): want repository %s to be deleted", tc.reason, r)
+				}
+			}
+		})
+	}
+}
+
+// create creates a repository with the supplied owner and name on the
+// supplied server.
+func create(t *testing.T, s *ghserver.Server, owner, name string) {
+	t.Helper()
+	if _, err := newExternal(s).Create(context.Background(), newRepository(owner, name)); err != nil {
+		t.Fatalf("Create(%s/%s): %v", owner, name, err)
+	}
+}
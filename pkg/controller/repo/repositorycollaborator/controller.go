@@ -0,0 +1,293 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositorycollaborator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRepositoryCollaborator = "managed resource is not a RepositoryCollaborator custom resource"
+	errCreateService             = "failed to create client service"
+	errListCollaborators         = "cannot list direct collaborators"
+	errListInvitations           = "cannot list repository invitations"
+	errAddCollaborator           = "cannot add collaborator"
+	errUpdateInvitation          = "cannot update invitation"
+	errRemoveCollaborator        = "cannot remove collaborator"
+	errDeleteInvitation          = "cannot delete invitation"
+	errListCustomRoles           = "cannot list custom repository roles"
+	errFmtUnknownRole            = "permission %q is neither pull, triage, push, maintain, admin, nor a custom repository role of organization %s"
+	errFmtNoCustomRoles          = "permission %q must be pull, triage, push, maintain, or admin because %s is not an organization"
+)
+
+// SetupRepositoryCollaborator adds a controller that reconciles
+// RepositoryCollaborator managed resources.
+func SetupRepositoryCollaborator(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RepositoryCollaboratorGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RepositoryCollaboratorKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RepositoryCollaboratorGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RepositoryCollaborator{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RepositoryCollaboratorGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RepositoryCollaboratorKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RepositoryCollaborator); !ok {
+		return nil, errors.New(errNotRepositoryCollaborator)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then either adds, updates, or removes a collaborator of
+// a repository.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCollaborator)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	collaborator, err := c.collaborator(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	var observed string
+	switch {
+	case collaborator != nil:
+		// The role name, unlike the permissions, distinguishes triage,
+		// maintain, and custom roles.
+		observed = collaborator.GetRoleName()
+		cr.Status.AtProvider = v1alpha1.RepositoryCollaboratorObservation{Permission: observed}
+		cr.SetConditions(xpv1.Available())
+	default:
+		inv, err := c.invitation(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if inv == nil {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		observed = inv.GetPermissions()
+		cr.Status.AtProvider = v1alpha1.RepositoryCollaboratorObservation{Permission: observed, InvitationID: inv.GetID()}
+		cr.SetConditions(xpv1.Unavailable().WithMessage("the invitation is pending"))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: roleName(permission(p.Permission)) == observed,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCollaborator)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryCollaborator)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	return managed.ExternalCreation{}, c.add(ctx, cr)
+}
+
+// Update changes the role of the collaborator, or of their pending
+// invitation, in place. Removing and adding the collaborator again would
+// cancel their invitation and drop their review requests.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCollaborator)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	if id := cr.Status.AtProvider.InvitationID; id != 0 {
+		if err := c.validate(ctx, p); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		_, _, err := c.service.Repositories.UpdateInvitation(ctx, p.Owner, p.Repository, id, roleName(permission(p.Permission)))
+		return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateInvitation)
+	}
+	// Adding an existing collaborator changes their role.
+	return managed.ExternalUpdate{}, c.add(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RepositoryCollaborator)
+	if !ok {
+		return errors.New(errNotRepositoryCollaborator)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	p := cr.Spec.ForProvider
+	if id := cr.Status.AtProvider.InvitationID; id != 0 {
+		_, err := c.service.Repositories.DeleteInvitation(ctx, p.Owner, p.Repository, id)
+		return kcgitclient.DeleteError(ctx, cr, err, errDeleteInvitation)
+	}
+	_, err := c.service.Repositories.RemoveCollaborator(ctx, p.Owner, p.Repository, p.Username)
+	return kcgitclient.DeleteError(ctx, cr, err, errRemoveCollaborator)
+}
+
+// add adds the collaborator with the desired role, or changes the role of an
+// existing collaborator.
+func (c *external) add(ctx context.Context, cr *v1alpha1.RepositoryCollaborator) error {
+	p := cr.Spec.ForProvider
+	if err := c.validate(ctx, p); err != nil {
+		return err
+	}
+	_, _, err := c.service.Repositories.AddCollaborator(ctx, p.Owner, p.Repository, p.Username, &github.RepositoryAddCollaboratorOptions{Permission: permission(p.Permission)})
+	return kcgitclient.WrapAPIError(err, errAddCollaborator)
+}
+
+// validate returns an error if the desired permission is neither a built-in
+// role nor a custom role of the organization, which GitHub would reject with
+// an error that does not name the permission.
+func (c *external) validate(ctx context.Context, p v1alpha1.RepositoryCollaboratorParameters) error {
+	if builtinRoles[permission(p.Permission)] {
+		return nil
+	}
+	roles, _, err := c.service.Organizations.ListCustomRepoRoles(ctx, p.Owner)
+	if kcgitclient.IsNotFound(err) {
+		return errors.Errorf(errFmtNoCustomRoles, p.Permission, p.Owner)
+	}
+	if err != nil {
+		return kcgitclient.WrapAPIError(err, errListCustomRoles)
+	}
+	for _, r := range roles.CustomRepoRoles {
+		if r.GetName() == p.Permission {
+			return nil
+		}
+	}
+	return errors.Errorf(errFmtUnknownRole, p.Permission, p.Owner)
+}
+
+// collaborator returns the supplied user as a direct collaborator of the
+// repository, or nil if they are not one. Users with access only through the
+// base permission of the organization, a team, or organization ownership are
+// not direct collaborators, and the role they are reported with is not the one
+// this resource grants.
+func (c *external) collaborator(ctx context.Context, cr *v1alpha1.RepositoryCollaborator) (*github.User, error) {
+	p := cr.Spec.ForProvider
+	users, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.User, *github.Response, error) {
+		return c.service.Repositories.ListCollaborators(ctx, p.Owner, p.Repository, &github.ListCollaboratorsOptions{Affiliation: "direct", ListOptions: *opts})
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListCollaborators)
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.GetLogin(), p.Username) {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+// invitation returns the pending invitation of the collaborator, or nil if
+// there is none.
+func (c *external) invitation(ctx context.Context, cr *v1alpha1.RepositoryCollaborator) (*github.RepositoryInvitation, error) {
+	p := cr.Spec.ForProvider
+	invs, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error) {
+		return c.service.Repositories.ListInvitations(ctx, p.Owner, p.Repository, opts)
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListInvitations)
+	}
+	for _, inv := range invs {
+		if strings.EqualFold(inv.GetInvitee().GetLogin(), p.Username) {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+// builtinRoles are the permissions every repository has.
+var builtinRoles = map[string]bool{"pull": true, "triage": true, "push": true, "maintain": true, "admin": true}
+
+// permission returns the supplied permission the way GitHub accepts it. The
+// built-in roles are case-insensitive and may be given by the read and write
+// names GitHub reports them by. Custom roles are returned unchanged.
+func permission(p string) string {
+	switch l := strings.ToLower(p); l {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	default:
+		if builtinRoles[l] {
+			return l
+		}
+		return p
+	}
+}
+
+// roleName returns the name GitHub reports the supplied permission by. The
+// pull and push permissions are reported as the read and write roles.
+func roleName(permission string) string {
+	switch permission {
+	case "pull":
+		return "read"
+	case "push":
+		return "write"
+	default:
+		return permission
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositorycollaborator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+)
+
+// A collaboratorServer is a fake GitHub API of the acme/platform repository
+// that tells the direct collaborators of the repository apart from users with
+// access through the organization, such as its base permission or a team.
+type collaboratorServer struct {
+	*httptest.Server
+	mu sync.Mutex
+	// direct are the roles of the direct collaborators by login.
+	direct map[string]string
+	// inherited are the roles users have through the organization by login.
+	inherited map[string]string
+	roles     []string
+}
+
+func newCollaboratorServer(t *testing.T, direct, inherited map[string]string, roles []string) *collaboratorServer {
+	t.Helper()
+	s := &collaboratorServer{direct: map[string]string{}, inherited: inherited, roles: roles}
+	for u, r := range direct {
+		s.direct[u] = r
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// effective returns the role GitHub reports the supplied user by, which is
+// the higher of their direct and inherited roles.
+func (s *collaboratorServer) effective(login string) string {
+	rank := map[string]int{"read": 1, "triage": 2, "write": 3, "maintain": 4, "admin": 5}
+	d, i := s.direct[login], s.inherited[login]
+	if rank[i] > rank[d] {
+		return i
+	}
+	return d
+}
+
+func (s *collaboratorServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/platform/collaborators":
+		// Direct collaborators are listed with the role granted to them,
+		// and all collaborators with the highest role they have.
+		roles := map[string]string{}
+		for u, role := range s.direct {
+			roles[u] = role
+		}
+		if r.URL.Query().Get("affiliation") != "direct" {
+			for u := range s.inherited {
+				roles[u] = ""
+			}
+			for u := range roles {
+				roles[u] = s.effective(u)
+			}
+		}
+		users := []*github.User{}
+		for u, role := range roles {
+			users = append(users, &github.User{Login: github.String(u), RoleName: github.String(role)})
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].GetLogin() < users[j].GetLogin() })
+		_ = json.NewEncoder(w).Encode(users)
+	case r.Method == http.MethodGet && len(p) == 5 && p[3] == "collaborators":
+		if s.effective(p[4]) == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && len(p) == 6 && p[5] == "permission":
+		_ = json.NewEncoder(w).Encode(&github.RepositoryPermissionLevel{RoleName: github.String(s.effective(p[4]))})
+	case r.Method == http.MethodPut && len(p) == 5 && p[3] == "collaborators":
+		opts := &github.RepositoryAddCollaboratorOptions{}
+		_ = json.NewDecoder(r.Body).Decode(opts)
+		s.direct[p[4]] = roleName(opts.Permission)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/platform/invitations":
+		_, _ = w.Write([]byte(`[]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/custom-repository-roles":
+		roles := &github.OrganizationCustomRepoRoles{TotalCount: github.Int(len(s.roles))}
+		for _, name := range s.roles {
+			roles.CustomRepoRoles = append(roles.CustomRepoRoles, &github.CustomRepoRoles{Name: github.String(name)})
+		}
+		_ = json.NewEncoder(w).Encode(roles)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// client returns a GitHub client of the server.
+func (s *collaboratorServer) client() *github.Client {
+	c := github.NewClient(s.Client())
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c
+}
+
+func TestTransitions(t *testing.T) {
+	type want struct {
+		obs    managed.ExternalObservation
+		direct string
+	}
+
+	cases := map[string]struct {
+		reason     string
+		direct     map[string]string
+		inherited  map[string]string
+		roles      []string
+		permission string
+		want       want
+	}{
+		"PullToAdmin": {
+			reason:     "A direct collaborator with the pull role should be out of date when admin is desired, and be granted admin.",
+			direct:     map[string]string{"alice": "read"},
+			permission: "admin",
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true},
+				direct: "admin",
+			},
+		},
+		"AdminToPull": {
+			reason:     "A direct collaborator with the admin role should be out of date when pull is desired, and be granted pull.",
+			direct:     map[string]string{"alice": "admin"},
+			permission: "pull",
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true},
+				direct: "read",
+			},
+		},
+		"AdminToPullOfOrganizationOwner": {
+			reason:     "A direct collaborator whose admin role is lowered to pull should be up to date once granted pull, although they remain an admin as an owner of the organization.",
+			direct:     map[string]string{"alice": "admin"},
+			inherited:  map[string]string{"alice": "admin"},
+			permission: "pull",
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true},
+				direct: "read",
+			},
+		},
+		"BaseToCustomRole": {
+			reason:     "A user with access only through the base permission of the organization is not a collaborator, and should be added with the desired custom role.",
+			inherited:  map[string]string{"alice": "read"},
+			roles:      []string{"reviewer"},
+			permission: "reviewer",
+			want: want{
+				obs:    managed.ExternalObservation{},
+				direct: "reviewer",
+			},
+		},
+		"BaseSameAsDesired": {
+			reason:     "A user whose base permission matches the desired role is still not a collaborator, and should be added so they keep access if the base permission changes.",
+			inherited:  map[string]string{"alice": "write"},
+			permission: "push",
+			want: want{
+				obs:    managed.ExternalObservation{},
+				direct: "write",
+			},
+		},
+		"UpToDateBelowTeamRole": {
+			reason:     "A direct collaborator with the desired role should be up to date even if a team grants them a higher one.",
+			direct:     map[string]string{"alice": "triage"},
+			inherited:  map[string]string{"alice": "maintain"},
+			permission: "triage",
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				direct: "triage",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newCollaboratorServer(t, tc.direct, tc.inherited, tc.roles)
+			e := &external{service: s.client()}
+			ctx := context.Background()
+			cr := &v1alpha1.RepositoryCollaborator{}
+			cr.Spec.ForProvider = v1alpha1.RepositoryCollaboratorParameters{Owner: "acme", Repository: "platform", Username: "alice", Permission: tc.permission}
+
+			obs, err := e.Observe(ctx, cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			switch {
+			case !obs.ResourceExists:
+				_, err = e.Create(ctx, cr)
+			case !obs.ResourceUpToDate:
+				_, err = e.Update(ctx, cr)
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nCreate or Update(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.direct, s.direct["alice"]); diff != "" {
+				t.Errorf("\n%s\nCreate or Update(...): -want direct role, +got:\n%s", tc.reason, diff)
+			}
+
+			obs, err = e.Observe(ctx, cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...) after reconciling: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		owner      string
+		permission string
+		want       string
+	}{
+		"BuiltinRole": {
+			reason:     "Built-in roles should be accepted regardless of case.",
+			owner:      "acme",
+			permission: "Maintain",
+		},
+		"ReadName": {
+			reason:     "The read name of the pull role should be accepted.",
+			owner:      "acme",
+			permission: "read",
+		},
+		"CustomRole": {
+			reason:     "A custom repository role of the organization should be accepted.",
+			owner:      "acme",
+			permission: "reviewer",
+		},
+		"UnknownRole": {
+			reason:     "A permission that is neither built in nor a custom role of the organization should be rejected.",
+			owner:      "acme",
+			permission: "superuser",
+			want:       `permission "superuser" is neither pull, triage, push, maintain, admin, nor a custom repository role of organization acme`,
+		},
+		"CustomRoleCase": {
+			reason:     "Custom roles are case-sensitive, unlike built-in roles.",
+			owner:      "acme",
+			permission: "Reviewer",
+			want:       `permission "Reviewer" is neither pull, triage, push, maintain, admin, nor a custom repository role of organization acme`,
+		},
+		"CustomRoleOfUser": {
+			reason:     "A repository owned by a user has no custom roles, so only built-in roles should be accepted.",
+			owner:      "alice",
+			permission: "reviewer",
+			want:       `permission "reviewer" must be pull, triage, push, maintain, or admin because alice is not an organization`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newCollaboratorServer(t, nil, nil, []string{"reviewer"})
+			e := &external{service: s.client()}
+			p := v1alpha1.RepositoryCollaboratorParameters{Owner: tc.owner, Repository: "platform", Username: "bob", Permission: tc.permission}
+
+			err := e.validate(context.Background(), p)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.validate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositorycustompropertyvalues
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRepositoryCustomPropertyValues = "managed resource is not a RepositoryCustomPropertyValues custom resource"
+	errCreateService                     = "failed to create client service"
+	errGetSchema                         = "cannot get custom properties of organization"
+	errGetValues                         = "cannot get custom property values of repository"
+	errSetValues                         = "cannot set custom property values of repository"
+	errUndefinedProperty                 = "custom property %q is not defined by organization %q"
+	errNotAllowed                        = "%q is not an allowed value of custom property %q"
+	errWrongType                         = "custom property %q has value type %s"
+)
+
+// SetupRepositoryCustomPropertyValues adds a controller that reconciles
+// RepositoryCustomPropertyValues managed resources.
+func SetupRepositoryCustomPropertyValues(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RepositoryCustomPropertyValuesGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RepositoryCustomPropertyValuesKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RepositoryCustomPropertyValuesGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RepositoryCustomPropertyValues{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RepositoryCustomPropertyValuesGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RepositoryCustomPropertyValuesKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RepositoryCustomPropertyValues); !ok {
+		return nil, errors.New(errNotRepositoryCustomPropertyValues)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes, then sets or unsets the values of custom properties of
+// a repository.
+type external struct {
+	service *github.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCustomPropertyValues)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryCustomPropertyValues)
+	}
+	p := cr.Spec.ForProvider
+
+	schema, _, err := c.service.Organizations.GetAllCustomProperties(ctx, p.Owner)
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetSchema)
+	}
+	defs := map[string]*github.CustomProperty{}
+	for _, d := range schema {
+		defs[d.GetPropertyName()] = d
+	}
+	// Setting a value GitHub does not accept would only fail later with a
+	// less helpful error, so we validate the values against the schema
+	// first.
+	if err := validate(p, defs); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	values, _, err := c.service.Repositories.GetAllCustomPropertyValues(ctx, p.Owner, p.Repository)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetValues)
+	}
+
+	current := map[string]interface{}{}
+	cr.Status.AtProvider.Properties = make([]v1alpha1.ObservedCustomPropertyValue, 0, len(values))
+	for _, v := range values {
+		current[v.PropertyName] = v.Value
+		cr.Status.AtProvider.Properties = append(cr.Status.AtProvider.Properties, observe(v))
+	}
+
+	exists, upToDate := false, true
+	for _, pv := range p.Properties {
+		// Required properties always have a value, so only the values of
+		// optional properties tell whether this resource set any.
+		if current[pv.Name] != nil && !defs[pv.Name].GetRequired() {
+			exists = true
+		}
+		if !equal(generate(pv), current[pv.Name]) {
+			upToDate = false
+		}
+	}
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: exists}, nil
+	}
+
+	// The values of a repository always exist. We report them as missing
+	// only while none of them are set so that the initial values are
+	// applied through Create.
+	return managed.ExternalObservation{
+		ResourceExists:   exists || upToDate,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCustomPropertyValues)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryCustomPropertyValues)
+	}
+
+	return managed.ExternalCreation{}, kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider, false), errSetValues)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCustomPropertyValues)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryCustomPropertyValues)
+	}
+
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider, false), errSetValues)
+}
+
+// Delete unsets the values of the listed properties. Required properties fall
+// back to their default value.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RepositoryCustomPropertyValues)
+	if !ok {
+		return errors.New(errNotRepositoryCustomPropertyValues)
+	}
+
+	return kcgitclient.WrapAPIError(c.set(ctx, cr.Spec.ForProvider, true), errSetValues)
+}
+
+// set sets the listed properties to their values or, when unset is true,
+// removes their values.
+func (c *external) set(ctx context.Context, p v1alpha1.RepositoryCustomPropertyValuesParameters, unset bool) error {
+	values := make([]*github.CustomPropertyValue, 0, len(p.Properties))
+	for _, pv := range p.Properties {
+		v := &github.CustomPropertyValue{PropertyName: pv.Name}
+		if !unset {
+			v.Value = generate(pv)
+		}
+		values = append(values, v)
+	}
+	_, err := c.service.Repositories.CreateOrUpdateCustomProperties(ctx, p.Owner, p.Repository, values)
+	return err
+}
+
+// validate returns an error if any of the listed properties is not defined
+// by the organization or has a value its definition does not allow.
+func validate(p v1alpha1.RepositoryCustomPropertyValuesParameters, defs map[string]*github.CustomProperty) error {
+	for _, pv := range p.Properties {
+		d, ok := defs[pv.Name]
+		if !ok {
+			return errors.Errorf(errUndefinedProperty, pv.Name, p.Owner)
+		}
+		var candidates []string
+		switch d.ValueType {
+		case "multi_select":
+			if pv.Value != nil || pv.BoolValue != nil {
+				return errors.Errorf(errWrongType, pv.Name, d.ValueType)
+			}
+			candidates = pv.Values
+		case "true_false":
+			if pv.Values != nil {
+				return errors.Errorf(errWrongType, pv.Name, d.ValueType)
+			}
+			if pv.Value != nil {
+				candidates = []string{*pv.Value}
+			}
+			d = &github.CustomProperty{AllowedValues: []string{"true", "false"}}
+		default:
+			if pv.Values != nil || pv.BoolValue != nil {
+				return errors.Errorf(errWrongType, pv.Name, d.ValueType)
+			}
+			if pv.Value != nil && d.ValueType == "single_select" {
+				candidates = []string{*pv.Value}
+			}
+		}
+		for _, v := range candidates {
+			if !contains(d.AllowedValues, v) {
+				return errors.Errorf(errNotAllowed, v, pv.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// generate returns the value of the supplied property in the representation
+// GitHub uses: a string, a slice of strings, or nil.
+func generate(pv v1alpha1.CustomPropertyValue) interface{} {
+	switch {
+	case pv.Values != nil:
+		return pv.Values
+	case pv.BoolValue != nil:
+		return strconv.FormatBool(*pv.BoolValue)
+	case pv.Value != nil:
+		return *pv.Value
+	}
+	return nil
+}
+
+// equal reports whether the desired and current values of a property are
+// the same. The values of multi_select properties are compared regardless of
+// their order.
+func equal(desired, current interface{}) bool {
+	return cmp.Equal(desired, current, cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func observe(v *github.CustomPropertyValue) v1alpha1.ObservedCustomPropertyValue {
+	o := v1alpha1.ObservedCustomPropertyValue{Name: v.PropertyName}
+	switch val := v.Value.(type) {
+	case string:
+		o.Value = &val
+	case []string:
+		o.Values = val
+	}
+	return o
+}
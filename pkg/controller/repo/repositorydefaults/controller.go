@@ -0,0 +1,381 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositorydefaults
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/repositorysettings"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRepositoryDefaults = "managed resource is not a RepositoryDefaults custom resource"
+	errCreateService         = "failed to create client service"
+	errListRepositories      = "cannot list repositories"
+	errListCustomProperties  = "cannot list custom property values"
+	errGetAlerts             = "cannot get vulnerability alerts"
+	errEditRepository        = "cannot edit repository"
+	errReplaceTopics         = "cannot replace topics"
+	errSetAlerts             = "cannot set vulnerability alerts"
+	errFmtBadPattern         = "cannot match namePattern %q"
+	errFmtApply              = "cannot apply defaults to %d of %d drifted repositories"
+)
+
+// SetupRepositoryDefaults adds a controller that reconciles RepositoryDefaults
+// managed resources, if they are enabled. The repositories they select are
+// swept every poll interval.
+func SetupRepositoryDefaults(mgr ctrl.Manager, o controller.Options) error {
+	if !o.Features.Enabled(features.EnableAlphaRepositoryDefaults) {
+		return nil
+	}
+	name := managed.ControllerName(v1alpha1.RepositoryDefaultsGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RepositoryDefaultsKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RepositoryDefaultsGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient()})))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RepositoryDefaults{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RepositoryDefaultsGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RepositoryDefaultsKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube client.Client
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.RepositoryDefaults); !ok {
+		return nil, errors.New(errNotRepositoryDefaults)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc}, nil
+}
+
+// An external observes the repositories of an organization that a
+// RepositoryDefaults selects, then changes the ones that drifted from its
+// template.
+type external struct {
+	service *github.Client
+	// drifted are the repositories that drifted from the template when they
+	// were last observed.
+	drifted []*drift
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryDefaults)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryDefaults)
+	}
+
+	// Deleting a RepositoryDefaults leaves the repositories as they are.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	repos, err := c.selected(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	c.drifted = nil
+	results := make([]v1alpha1.RepositoryDefaultsResult, 0, len(repos))
+	for _, r := range repos {
+		d, err := c.drift(ctx, cr.Spec.ForProvider, r)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		res := v1alpha1.RepositoryDefaultsResult{Name: r.GetName(), Result: v1alpha1.RepositoryDefaultsCompliant}
+		if !d.UpToDate() {
+			res.Result, res.Message = v1alpha1.RepositoryDefaultsDrifted, d.String()
+			c.drifted = append(c.drifted, d)
+		}
+		results = append(results, res)
+	}
+	cr.Status.AtProvider.Repositories = results
+	cr.SetConditions(xpv1.Available())
+
+	var diff []string
+	for _, d := range c.drifted {
+		diff = append(diff, d.repo.GetName()+":\n"+d.String())
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(c.drifted) == 0,
+		Diff:             strings.Join(diff, "\n"),
+	}, nil
+}
+
+// Create does nothing. The defaults apply to repositories that already
+// exist, so there is nothing to create.
+func (c *external) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.RepositoryDefaults); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryDefaults)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update changes every repository that drifted from the template when it was
+// observed. A repository that cannot be changed does not keep the others from
+// being changed, and is recorded as failed.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryDefaults)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryDefaults)
+	}
+
+	result := map[string]v1alpha1.RepositoryDefaultsResult{}
+	failed := 0
+	for _, d := range c.drifted {
+		res := v1alpha1.RepositoryDefaultsResult{Name: d.repo.GetName(), Result: v1alpha1.RepositoryDefaultsUpdated}
+		if err := c.apply(ctx, cr.Spec.ForProvider, d); err != nil {
+			res.Result, res.Message = v1alpha1.RepositoryDefaultsFailed, err.Error()
+			failed++
+		}
+		result[res.Name] = res
+	}
+	for i, r := range cr.Status.AtProvider.Repositories {
+		if res, ok := result[r.Name]; ok {
+			cr.Status.AtProvider.Repositories[i] = res
+		}
+	}
+	if failed > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf(errFmtApply, failed, len(c.drifted))
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing. The repositories keep their current settings.
+func (c *external) Delete(_ context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.RepositoryDefaults); !ok {
+		return errors.New(errNotRepositoryDefaults)
+	}
+	return nil
+}
+
+// A drift is how a repository differs from the template.
+type drift struct {
+	*compare.Diff
+
+	repo *github.Repository
+
+	// settings is true if settings edited through the repository differ.
+	settings bool
+
+	// topics are the topics the repository should have, if it lacks any.
+	topics []string
+
+	// alerts is true if its vulnerability alerts differ.
+	alerts bool
+}
+
+// selected returns the repositories of the organization the supplied
+// parameters select, ordered by name. Archived repositories cannot be
+// changed, and are never selected.
+func (c *external) selected(ctx context.Context, p v1alpha1.RepositoryDefaultsParameters) ([]*github.Repository, error) {
+	repos, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.service.Repositories.ListByOrg(ctx, p.Org, &github.RepositoryListByOrgOptions{ListOptions: *opts})
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListRepositories)
+	}
+
+	var matching map[string]bool
+	if m := p.Selector.CustomProperty; m != nil {
+		if matching, err = c.matching(ctx, p.Org, *m); err != nil {
+			return nil, err
+		}
+	}
+
+	sel := make([]*github.Repository, 0, len(repos))
+	for _, r := range repos {
+		if r.GetArchived() || (matching != nil && !matching[r.GetName()]) {
+			continue
+		}
+		if p.Selector.NamePattern != nil {
+			ok, err := path.Match(*p.Selector.NamePattern, r.GetName())
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtBadPattern, *p.Selector.NamePattern)
+			}
+			if !ok {
+				continue
+			}
+		}
+		sel = append(sel, r)
+	}
+	sort.Slice(sel, func(i, j int) bool { return sel[i].GetName() < sel[j].GetName() })
+	return sel, nil
+}
+
+// matching returns the names of the repositories of the supplied organization
+// whose custom property matches.
+func (c *external) matching(ctx context.Context, org string, m v1alpha1.CustomPropertyMatch) (map[string]bool, error) {
+	values, err := kcgitclient.ListAll(ctx, func(opts *github.ListOptions) ([]*github.RepoCustomPropertyValue, *github.Response, error) {
+		return c.service.Organizations.ListCustomPropertyValues(ctx, org, opts)
+	})
+	if err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListCustomProperties)
+	}
+	matching := map[string]bool{}
+	for _, r := range values {
+		for _, v := range r.Properties {
+			if v.PropertyName == m.Name && hasValue(v.Value, m.Value) {
+				matching[r.RepositoryName] = true
+			}
+		}
+	}
+	return matching, nil
+}
+
+// hasValue returns true if the supplied custom property value, which is a
+// string or a list of strings, is or contains the supplied value.
+func hasValue(v interface{}, want string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == want
+	case []string:
+		for _, s := range v {
+			if s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// drift returns how the supplied repository differs from the template of the
+// supplied parameters.
+func (c *external) drift(ctx context.Context, p v1alpha1.RepositoryDefaultsParameters, r *github.Repository) (*drift, error) {
+	d := &drift{Diff: repositorysettings.Diff(settings(p.Template), r), repo: r}
+	d.settings = !d.UpToDate()
+
+	if topics := union(r.Topics, p.Template.Topics); len(topics) != len(r.Topics) {
+		d.topics = topics
+		d.Add("topics", topics, r.Topics)
+	}
+
+	if p.Template.VulnerabilityAlerts != nil {
+		enabled, _, err := c.service.Repositories.GetVulnerabilityAlerts(ctx, p.Org, r.GetName())
+		if err != nil {
+			return nil, kcgitclient.WrapAPIError(err, errGetAlerts)
+		}
+		if enabled != *p.Template.VulnerabilityAlerts {
+			d.alerts = true
+			d.Add("vulnerabilityAlerts", *p.Template.VulnerabilityAlerts, enabled)
+		}
+	}
+	return d, nil
+}
+
+// apply changes the supplied repository to match the template of the
+// supplied parameters.
+func (c *external) apply(ctx context.Context, p v1alpha1.RepositoryDefaultsParameters, d *drift) error {
+	name := d.repo.GetName()
+	if d.settings {
+		if _, _, err := c.service.Repositories.Edit(ctx, p.Org, name, repositorysettings.Edit(settings(p.Template))); err != nil {
+			return kcgitclient.WrapAPIError(err, errEditRepository)
+		}
+	}
+	if d.topics != nil {
+		if _, _, err := c.service.Repositories.ReplaceAllTopics(ctx, p.Org, name, d.topics); err != nil {
+			return kcgitclient.WrapAPIError(err, errReplaceTopics)
+		}
+	}
+	if d.alerts {
+		var err error
+		if *p.Template.VulnerabilityAlerts {
+			_, err = c.service.Repositories.EnableVulnerabilityAlerts(ctx, p.Org, name)
+		} else {
+			_, err = c.service.Repositories.DisableVulnerabilityAlerts(ctx, p.Org, name)
+		}
+		if err != nil {
+			return kcgitclient.WrapAPIError(err, errSetAlerts)
+		}
+	}
+	return nil
+}
+
+// settings returns the settings of the supplied template that are edited
+// through the repository itself.
+func settings(t v1alpha1.RepositoryDefaultsTemplate) repositorysettings.Settings {
+	return repositorysettings.Settings{
+		HasIssues:           t.HasIssues,
+		HasProjects:         t.HasProjects,
+		HasWiki:             t.HasWiki,
+		AllowMergeCommit:    t.AllowMergeCommit,
+		AllowSquashMerge:    t.AllowSquashMerge,
+		AllowRebaseMerge:    t.AllowRebaseMerge,
+		AllowAutoMerge:      t.AllowAutoMerge,
+		DeleteBranchOnMerge: t.DeleteBranchOnMerge,
+	}
+}
+
+// union returns the supplied topics with the supplied additional topics
+// appended, unless they already are among them. GitHub stores topics in
+// lower case.
+func union(topics, add []string) []string {
+	have := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		have[t] = true
+	}
+	u := append([]string{}, topics...)
+	for _, t := range add {
+		t = strings.ToLower(t)
+		if !have[t] {
+			have[t] = true
+			u = append(u, t)
+		}
+	}
+	return u
+}
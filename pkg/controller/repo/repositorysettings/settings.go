@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package repositorysettings compares and edits the settings of a repository
+// that are edited through the repository itself. It is shared by the kinds
+// that manage them, so that they treat them the same way.
+package repositorysettings
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+)
+
+// Settings are the settings of a repository that are edited through the
+// repository itself. Settings that are nil are not managed.
+type Settings struct {
+	Description *string
+	Homepage    *string
+	Visibility  *string
+
+	HasIssues   *bool
+	HasProjects *bool
+	HasWiki     *bool
+	IsTemplate  *bool
+
+	AllowMergeCommit    *bool
+	AllowSquashMerge    *bool
+	AllowRebaseMerge    *bool
+	AllowAutoMerge      *bool
+	DeleteBranchOnMerge *bool
+
+	SquashMergeCommitTitle   *string
+	SquashMergeCommitMessage *string
+	MergeCommitTitle         *string
+	MergeCommitMessage       *string
+}
+
+// The combinations of commit title and message GitHub accepts. A squash
+// merge commit titled with the commit or pull request title lists the
+// messages of the commits, while a merge commit titled with the merge message
+// has the pull request title as its message.
+var (
+	squashMergeCommitMessages = map[string][]string{
+		"PR_TITLE":           {"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+		"COMMIT_OR_PR_TITLE": {"COMMIT_MESSAGES"},
+	}
+	mergeCommitMessages = map[string][]string{
+		"PR_TITLE":      {"PR_BODY", "BLANK"},
+		"MERGE_MESSAGE": {"PR_TITLE"},
+	}
+)
+
+const errFmtCommitMessage = "%sMessage %s cannot be combined with %sTitle %s, which requires one of %s"
+
+// Diff returns the settings that are set and differ from the supplied
+// repository, named as the fields of the supplied spec.
+func Diff(s Settings, r *github.Repository) *compare.Diff {
+	d := &compare.Diff{}
+	compare.DiffOptional(d, "description", s.Description, r.Description)
+	compare.DiffOptional(d, "homepage", s.Homepage, r.Homepage)
+	compare.DiffOptional(d, "visibility", s.Visibility, r.Visibility)
+	compare.DiffOptional(d, "hasIssues", s.HasIssues, r.HasIssues)
+	compare.DiffOptional(d, "hasProjects", s.HasProjects, r.HasProjects)
+	compare.DiffOptional(d, "hasWiki", s.HasWiki, r.HasWiki)
+	compare.DiffOptional(d, "isTemplate", s.IsTemplate, r.IsTemplate)
+	compare.DiffOptional(d, "allowMergeCommit", s.AllowMergeCommit, r.AllowMergeCommit)
+	compare.DiffOptional(d, "allowSquashMerge", s.AllowSquashMerge, r.AllowSquashMerge)
+	compare.DiffOptional(d, "allowRebaseMerge", s.AllowRebaseMerge, r.AllowRebaseMerge)
+	compare.DiffOptional(d, "allowAutoMerge", s.AllowAutoMerge, r.AllowAutoMerge)
+	compare.DiffOptional(d, "deleteBranchOnMerge", s.DeleteBranchOnMerge, r.DeleteBranchOnMerge)
+	compare.DiffOptional(d, "squashMergeCommitTitle", s.SquashMergeCommitTitle, r.SquashMergeCommitTitle)
+	compare.DiffOptional(d, "squashMergeCommitMessage", s.SquashMergeCommitMessage, r.SquashMergeCommitMessage)
+	compare.DiffOptional(d, "mergeCommitTitle", s.MergeCommitTitle, r.MergeCommitTitle)
+	compare.DiffOptional(d, "mergeCommitMessage", s.MergeCommitMessage, r.MergeCommitMessage)
+	return d
+}
+
+// Edit returns the edit payload for the supplied settings. Only settings that
+// are set are sent, so the edit never touches unmanaged settings.
+func Edit(s Settings) *github.Repository {
+	return &github.Repository{
+		Description:         s.Description,
+		Homepage:            s.Homepage,
+		Visibility:          s.Visibility,
+		HasIssues:           s.HasIssues,
+		HasProjects:         s.HasProjects,
+		HasWiki:             s.HasWiki,
+		IsTemplate:          s.IsTemplate,
+		AllowMergeCommit:    s.AllowMergeCommit,
+		AllowSquashMerge:    s.AllowSquashMerge,
+		AllowRebaseMerge:    s.AllowRebaseMerge,
+		AllowAutoMerge:      s.AllowAutoMerge,
+		DeleteBranchOnMerge: s.DeleteBranchOnMerge,
+
+		SquashMergeCommitTitle:   s.SquashMergeCommitTitle,
+		SquashMergeCommitMessage: s.SquashMergeCommitMessage,
+		MergeCommitTitle:         s.MergeCommitTitle,
+		MergeCommitMessage:       s.MergeCommitMessage,
+	}
+}
+
+// Validate rejects combinations of commit titles and messages GitHub would
+// reject. GitHub only names the field it rejects, not the one it conflicts
+// with. A title or message alone cannot conflict.
+func Validate(s Settings) error {
+	if err := validateCommit("squashMergeCommit", s.SquashMergeCommitTitle, s.SquashMergeCommitMessage, squashMergeCommitMessages); err != nil {
+		return err
+	}
+	return validateCommit("mergeCommit", s.MergeCommitTitle, s.MergeCommitMessage, mergeCommitMessages)
+}
+
+func validateCommit(field string, title, message *string, valid map[string][]string) error {
+	if title == nil || message == nil {
+		return nil
+	}
+	for _, m := range valid[*title] {
+		if m == *message {
+			return nil
+		}
+	}
+	return errors.Errorf(errFmtCommitMessage, field, *message, field, *title, strings.Join(valid[*title], ", "))
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+	"github.com/hasheddan/kc-provider-github/pkg/compare"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/branchconflict"
+	"github.com/hasheddan/kc-provider-github/pkg/controller/repo/statuschecks"
+	"github.com/hasheddan/kc-provider-github/pkg/webhook"
+)
+
+const (
+	errNotRuleset      = "managed resource is not a Ruleset custom resource"
+	errCreateService   = "failed to create client service"
+	errGetRuleset      = "cannot get ruleset"
+	errCreateRuleset   = "cannot create ruleset"
+	errUpdateRuleset   = "cannot update ruleset"
+	errDeleteRuleset   = "cannot delete ruleset"
+	errListProtections = "cannot list branch protections"
+	errResolveChecks   = "cannot resolve required status checks"
+	errDecodeRule      = "cannot decode rule parameters"
+	errInvalidID       = "external name is not a ruleset ID"
+)
+
+const reasonPromoted event.Reason = "PromotedRuleset"
+
+// SetupRuleset adds a controller that reconciles Ruleset managed resources.
+func SetupRuleset(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RulesetGroupKind)
+	kcgitclient.RequireScopes(v1alpha1.RulesetKind, "repo")
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RulesetGroupVersionKind),
+		managed.WithExternalConnecter(kcgitclient.WaitForRepository(kcgitclient.WithCallTimeout(kcgitclient.WithSyncStatus(kcgitclient.WithDryRun(mgr, name, o.Logger, &connector{kube: mgr.GetClient(), record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name))}))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Ruleset{}, builder.WithPredicates(kcgitclient.DesiredStateChanged()))
+	if webhook.Enabled(o.Features) {
+		b = b.Watches(webhook.Source(v1alpha1.RulesetGroupVersionKind), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, kcgitclient.RequeueOnRateLimit(kcgitclient.RequeueOnForbiddenDelete(kcgitclient.Trace(v1alpha1.RulesetKind, r))), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	record event.Recorder
+}
+
+// Connect produces an ExternalClient using the credentials of the managed
+// resource's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.Ruleset); !ok {
+		return nil, errors.New(errNotRuleset)
+	}
+	svc, err := kcgitclient.UseProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateService)
+	}
+	return &external{service: svc, kube: c.kube, record: c.record, checks: statuschecks.NewResolver(svc)}, nil
+}
+
+// An external observes, then either creates, updates, or deletes a ruleset of a
+// repository.
+type external struct {
+	service *github.Client
+	kube    client.Client
+	record  event.Recorder
+	checks  *statuschecks.Resolver
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Ruleset)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRuleset)
+	}
+
+	// GitHub assigns the ID of a ruleset when it is created.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInvalidID)
+	}
+
+	p := cr.Spec.ForProvider
+	rs, _, err := c.service.Repositories.GetRuleset(ctx, p.Owner, p.Repository, id, false)
+	if kcgitclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, kcgitclient.WrapAPIError(err, errGetRuleset)
+	}
+
+	cr.Status.AtProvider.ID = rs.GetID()
+	cr.Status.AtProvider.NodeID = rs.GetNodeID()
+	cr.SetConditions(xpv1.Available())
+
+	if err := c.observeEvaluation(ctx, cr, rs); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	promoted := promote(cr.Spec.ForProvider, cr.Status.AtProvider.Evaluation, time.Now())
+	if promoted {
+		cr.Spec.ForProvider.Enforcement = enforcementActive
+		c.record.Event(cr, event.Normal(reasonPromoted, fmt.Sprintf("Promoted ruleset to active after it was evaluated without failing for %s", cr.Spec.ForProvider.Evaluation.PromoteWhenCleanFor.Duration)))
+	}
+
+	others, err := branchconflict.ForRuleset(ctx, c.kube, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListProtections)
+	}
+	branchconflict.Report(c.record, cr, v1alpha1.BranchProtectionKind, others, fmt.Sprintf("branches of repository %s/%s this ruleset applies to", p.Owner, p.Repository))
+
+	got, err := observed(rs)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired := cr.Spec.ForProvider
+	sc, err := c.checks.Resolve(ctx, desired.Rules.RequiredStatusChecks)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveChecks)
+	}
+	desired.Rules.RequiredStatusChecks = statuschecks.Match(sc, got.Rules.RequiredStatusChecks)
+
+	// A promoted ruleset is persisted like a late initialized one, so that
+	// its spec keeps it active.
+	d := diff(desired, got)
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        d.UpToDate(),
+		ResourceLateInitialized: promoted,
+		Diff:                    d.String(),
+	}, nil
+}
+
+// observeEvaluation records the summary of the results of the supplied ruleset
+// while it is evaluated, if the supplied Ruleset asks for it.
+func (c *external) observeEvaluation(ctx context.Context, cr *v1alpha1.Ruleset, rs *github.Ruleset) error {
+	if cr.Spec.ForProvider.Evaluation == nil || rs.Enforcement != enforcementEvaluate {
+		cr.Status.AtProvider.Evaluation = nil
+		return nil
+	}
+	ev, err := c.evaluation(ctx, cr.Spec.ForProvider, rs.GetID(), cr.Status.AtProvider.Evaluation)
+	if err != nil {
+		return err
+	}
+	cr.Status.AtProvider.Evaluation = ev
+	return nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Ruleset)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRuleset)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	p, err := c.resolved(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	rs, _, err := c.service.Repositories.CreateRuleset(ctx, p.Owner, p.Repository, generate(p))
+	if err != nil {
+		return managed.ExternalCreation{}, kcgitclient.WrapAPIError(err, errCreateRuleset)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(rs.GetID(), 10))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Ruleset)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRuleset)
+	}
+
+	p, err := c.resolved(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err = c.service.Repositories.UpdateRuleset(ctx, p.Owner, p.Repository, cr.Status.AtProvider.ID, generate(p))
+	return managed.ExternalUpdate{}, kcgitclient.WrapAPIError(err, errUpdateRuleset)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Ruleset)
+	if !ok {
+		return errors.New(errNotRuleset)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	p := cr.Spec.ForProvider
+	_, err := c.service.Repositories.DeleteRuleset(ctx, p.Owner, p.Repository, cr.Status.AtProvider.ID)
+	return kcgitclient.DeleteError(ctx, cr, err, errDeleteRuleset)
+}
+
+// resolved returns the parameters of the supplied Ruleset with their required
+// status checks resolved.
+func (c *external) resolved(ctx context.Context, cr *v1alpha1.Ruleset) (v1alpha1.RulesetParameters, error) {
+	p := cr.Spec.ForProvider
+	sc, err := c.checks.Resolve(ctx, p.Rules.RequiredStatusChecks)
+	if err != nil {
+		return p, errors.Wrap(err, errResolveChecks)
+	}
+	p.Rules.RequiredStatusChecks = sc
+	return p, nil
+}
+
+// generate returns the desired ruleset, whose required status checks were
+// resolved. GitHub replaces all rules of a
+// ruleset, so unset rules are removed.
+func generate(p v1alpha1.RulesetParameters) *github.Ruleset {
+	rs := &github.Ruleset{
+		Name:        p.Name,
+		Target:      github.String("branch"),
+		Enforcement: p.Enforcement,
+		Conditions: &github.RulesetConditions{RefName: &github.RulesetRefConditionParameters{
+			Include: refs(p.IncludeBranches),
+			Exclude: refs(p.ExcludeBranches),
+		}},
+		Rules: []*github.RepositoryRule{},
+	}
+	r := p.Rules
+	if r.Deletion {
+		rs.Rules = append(rs.Rules, github.NewDeletionRule())
+	}
+	if r.NonFastForward {
+		rs.Rules = append(rs.Rules, github.NewNonFastForwardRule())
+	}
+	if r.RequiredLinearHistory {
+		rs.Rules = append(rs.Rules, github.NewRequiredLinearHistoryRule())
+	}
+	if r.RequiredSignatures {
+		rs.Rules = append(rs.Rules, github.NewRequiredSignaturesRule())
+	}
+	if pr := r.PullRequest; pr != nil {
+		rs.Rules = append(rs.Rules, github.NewPullRequestRule(&github.PullRequestRuleParameters{
+			RequiredApprovingReviewCount:   pr.RequiredApprovingReviewCount,
+			DismissStaleReviewsOnPush:      pr.DismissStaleReviewsOnPush,
+			RequireCodeOwnerReview:         pr.RequireCodeOwnerReview,
+			RequireLastPushApproval:        pr.RequireLastPushApproval,
+			RequiredReviewThreadResolution: pr.RequiredReviewThreadResolution,
+		}))
+	}
+	if sc := r.RequiredStatusChecks; sc != nil {
+		checks := make([]github.RuleRequiredStatusChecks, 0, len(sc.Checks))
+		for _, c := range sc.Checks {
+			checks = append(checks, github.RuleRequiredStatusChecks{Context: c.Context, IntegrationID: c.AppID})
+		}
+		rs.Rules = append(rs.Rules, github.NewRequiredStatusChecksRule(&github.RequiredStatusChecksRuleParameters{
+			RequiredStatusChecks:             checks,
+			StrictRequiredStatusChecksPolicy: sc.Strict,
+		}))
+	}
+	return rs
+}
+
+// observed returns the parameters of the supplied ruleset. Rules this
+// provider does not manage are ignored.
+func observed(rs *github.Ruleset) (v1alpha1.RulesetParameters, error) {
+	p := v1alpha1.RulesetParameters{Name: rs.Name, Enforcement: rs.Enforcement}
+	if c := rs.GetConditions(); c != nil && c.RefName != nil {
+		p.IncludeBranches = branches(c.RefName.Include)
+		p.ExcludeBranches = branches(c.RefName.Exclude)
+	}
+	for _, r := range rs.Rules {
+		switch r.Type {
+		case "deletion":
+			p.Rules.Deletion = true
+		case "non_fast_forward":
+			p.Rules.NonFastForward = true
+		case "required_linear_history":
+			p.Rules.RequiredLinearHistory = true
+		case "required_signatures":
+			p.Rules.RequiredSignatures = true
+		case "pull_request":
+			pr := github.PullRequestRuleParameters{}
+			if err := decode(r, &pr); err != nil {
+				return p, err
+			}
+			p.Rules.PullRequest = &v1alpha1.RulesetPullRequest{
+				RequiredApprovingReviewCount:   pr.RequiredApprovingReviewCount,
+				DismissStaleReviewsOnPush:      pr.DismissStaleReviewsOnPush,
+				RequireCodeOwnerReview:         pr.RequireCodeOwnerReview,
+				RequireLastPushApproval:        pr.RequireLastPushApproval,
+				RequiredReviewThreadResolution: pr.RequiredReviewThreadResolution,
+			}
+		case "required_status_checks":
+			sc := github.RequiredStatusChecksRuleParameters{}
+			if err := decode(r, &sc); err != nil {
+				return p, err
+			}
+			p.Rules.RequiredStatusChecks = &v1alpha1.RequiredStatusChecks{Strict: sc.StrictRequiredStatusChecksPolicy}
+			for _, c := range sc.RequiredStatusChecks {
+				p.Rules.RequiredStatusChecks.Checks = append(p.Rules.RequiredStatusChecks.Checks, v1alpha1.RequiredStatusCheck{Context: c.Context, AppID: c.IntegrationID})
+			}
+		}
+	}
+	return p, nil
+}
+
+// diff returns the differences between the desired and the observed ruleset.
+// The order of branches and checks does not matter.
+func diff(desired, got v1alpha1.RulesetParameters) *compare.Diff {
+	opts := []cmp.Option{cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }), statuschecks.Order}
+	d := &compare.Diff{}
+	for _, f := range []struct {
+		name          string
+		desired, have interface{}
+	}{
+		{"name", desired.Name, got.Name},
+		{"enforcement", desired.Enforcement, got.Enforcement},
+		{"includeBranches", branches(desired.IncludeBranches), got.IncludeBranches},
+		{"excludeBranches", branches(desired.ExcludeBranches), got.ExcludeBranches},
+		{"rules", desired.Rules, got.Rules},
+	} {
+		if !cmp.Equal(f.desired, f.have, opts...) {
+			d.Add(f.name, f.desired, f.have)
+		}
+	}
+	return d
+}
+
+// decode decodes the parameters of the supplied rule into v.
+func decode(r *github.RepositoryRule, v interface{}) error {
+	if r.Parameters == nil {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(*r.Parameters, v), errDecodeRule)
+}
+
+// refs returns the ref name patterns of the supplied branch patterns. GitHub
+// rejects null where it expects a list.
+func refs(patterns []string) []string {
+	r := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "~") && !strings.HasPrefix(p, "refs/") {
+			p = "refs/heads/" + p
+		}
+		r = append(r, p)
+	}
+	return r
+}
+
+// branches returns the branch patterns of the supplied ref name patterns.
+func branches(refs []string) []string {
+	b := make([]string, 0, len(refs))
+	for _, r := range refs {
+		b = append(b, strings.TrimPrefix(r, "refs/heads/"))
+	}
+	return b
+}
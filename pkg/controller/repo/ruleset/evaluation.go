@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+)
+
+const (
+	errListRuleSuites = "cannot list rule suites"
+	errGetRuleSuite   = "cannot get rule suite"
+)
+
+const (
+	enforcementActive   = "active"
+	enforcementEvaluate = "evaluate"
+
+	defaultSamples = 20
+
+	resultFail = "fail"
+)
+
+// A ruleSuite is the result of evaluating the rulesets of a repository for a
+// push. The go-github client does not support rule suites.
+type ruleSuite struct {
+	ID              int64            `json:"id"`
+	PushedAt        time.Time        `json:"pushed_at"`
+	RuleEvaluations []ruleEvaluation `json:"rule_evaluations,omitempty"`
+}
+
+// A ruleEvaluation is the result of evaluating one rule for a push.
+type ruleEvaluation struct {
+	RuleSource struct {
+		Type string `json:"type"`
+		ID   int64  `json:"id"`
+	} `json:"rule_source"`
+	Result string `json:"result"`
+}
+
+// evaluation returns the summary of the most recent results of the supplied
+// evaluated ruleset. It carries over when the ruleset was first evaluated and
+// last failed from the supplied previous summary, since both may predate the
+// sampled pushes.
+func (c *external) evaluation(ctx context.Context, p v1alpha1.RulesetParameters, id int64, prev *v1alpha1.RulesetEvaluationObservation) (*v1alpha1.RulesetEvaluationObservation, error) {
+	ev := &v1alpha1.RulesetEvaluationObservation{}
+	now := metav1.Now()
+	ev.EvaluatingSince = &now
+	if prev != nil {
+		if prev.EvaluatingSince != nil {
+			ev.EvaluatingSince = prev.EvaluatingSince
+		}
+		ev.LastFailureTime = prev.LastFailureTime
+	}
+
+	samples := p.Evaluation.Samples
+	if samples == 0 {
+		samples = defaultSamples
+	}
+	var suites []ruleSuite
+	if err := c.get(ctx, fmt.Sprintf("repos/%v/%v/rulesets/rule-suites?per_page=%d", p.Owner, p.Repository, samples), &suites); err != nil {
+		return nil, kcgitclient.WrapAPIError(err, errListRuleSuites)
+	}
+	for _, s := range suites {
+		// Only the details of a rule suite name the rulesets it evaluated.
+		rs := ruleSuite{}
+		if err := c.get(ctx, fmt.Sprintf("repos/%v/%v/rulesets/rule-suites/%d", p.Owner, p.Repository, s.ID), &rs); err != nil {
+			return nil, kcgitclient.WrapAPIError(err, errGetRuleSuite)
+		}
+		evaluated, failed := false, false
+		for _, e := range rs.RuleEvaluations {
+			if e.RuleSource.Type != "ruleset" || e.RuleSource.ID != id {
+				continue
+			}
+			evaluated = true
+			failed = failed || e.Result == resultFail
+		}
+		switch {
+		case !evaluated:
+		case failed:
+			ev.Failed++
+			if t := metav1.NewTime(s.PushedAt); t.After(ev.EvaluatingSince.Time) && (ev.LastFailureTime == nil || ev.LastFailureTime.Before(&t)) {
+				ev.LastFailureTime = &t
+			}
+		default:
+			ev.Passed++
+		}
+	}
+	return ev, nil
+}
+
+// get decodes the response to a GET request of the supplied URL into the
+// supplied value.
+func (c *external) get(ctx context.Context, url string, v interface{}) error {
+	req, err := c.service.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.service.Do(ctx, req, v)
+	return err
+}
+
+// promote returns true if the supplied evaluated ruleset opted in to
+// promotion and was evaluated without failing for long enough.
+func promote(p v1alpha1.RulesetParameters, ev *v1alpha1.RulesetEvaluationObservation, now time.Time) bool {
+	if p.Enforcement != enforcementEvaluate || ev == nil || p.Evaluation.PromoteWhenCleanFor == nil {
+		return false
+	}
+	window := p.Evaluation.PromoteWhenCleanFor.Duration
+	switch {
+	case ev.Passed == 0,
+		now.Sub(ev.EvaluatingSince.Time) < window,
+		ev.LastFailureTime != nil && now.Sub(ev.LastFailureTime.Time) < window:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statuschecks resolves and compares the required status checks of
+// BranchProtections and Rulesets. GitHub Apps may report checks with the same
+// name, so a check may require the app that provides it.
+package statuschecks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v66/github"
+	"github.com/pkg/errors"
+
+	"github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+	kcgitclient "github.com/hasheddan/kc-provider-github/pkg/client"
+)
+
+const (
+	errFmtGetApp       = "cannot get GitHub App %q of required check %q"
+	errFmtAppExclusive = "appID and appSlug of required check %q are mutually exclusive"
+)
+
+// Order sorts required checks by their name and app, so that their order does
+// not matter when comparing them.
+var Order = cmpopts.SortSlices(less)
+
+// A Resolver resolves the slugs of the GitHub Apps required checks must be
+// provided by to their IDs. Each slug is resolved once, so a Resolver should
+// not outlive a reconcile in which apps may be renamed.
+type Resolver struct {
+	service *github.Client
+	ids     map[string]int64
+}
+
+// NewResolver returns a Resolver that gets GitHub Apps with the supplied
+// client.
+func NewResolver(c *github.Client) *Resolver {
+	return &Resolver{service: c, ids: map[string]int64{}}
+}
+
+// Resolve returns the supplied required status checks with their contexts
+// as checks that any app may provide, and the app slugs of their checks
+// resolved to app IDs.
+func (r *Resolver) Resolve(ctx context.Context, sc *v1alpha1.RequiredStatusChecks) (*v1alpha1.RequiredStatusChecks, error) {
+	if sc == nil {
+		return nil, nil
+	}
+	out := &v1alpha1.RequiredStatusChecks{Strict: sc.Strict, Checks: make([]v1alpha1.RequiredStatusCheck, 0, len(sc.Contexts)+len(sc.Checks))}
+	for _, c := range sc.Contexts {
+		out.Checks = append(out.Checks, v1alpha1.RequiredStatusCheck{Context: c})
+	}
+	for _, c := range sc.Checks {
+		if c.AppID != nil && c.AppSlug != nil {
+			return nil, errors.Errorf(errFmtAppExclusive, c.Context)
+		}
+		check := v1alpha1.RequiredStatusCheck{Context: c.Context, AppID: c.AppID}
+		if c.AppSlug != nil {
+			id, err := r.appID(ctx, *c.AppSlug)
+			if err != nil {
+				return nil, kcgitclient.WrapAPIError(err, fmt.Sprintf(errFmtGetApp, *c.AppSlug, c.Context))
+			}
+			check.AppID = &id
+		}
+		out.Checks = append(out.Checks, check)
+	}
+	return out, nil
+}
+
+func (r *Resolver) appID(ctx context.Context, slug string) (int64, error) {
+	if id, ok := r.ids[slug]; ok {
+		return id, nil
+	}
+	app, _, err := r.service.Apps.Get(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	r.ids[slug] = app.GetID()
+	return app.GetID(), nil
+}
+
+// Match returns the supplied resolved checks as they compare to the
+// observed ones. A check any app may provide takes the app of an observed
+// check with the same name, so that it matches whichever app GitHub reports
+// for it.
+func Match(resolved, observed *v1alpha1.RequiredStatusChecks) *v1alpha1.RequiredStatusChecks {
+	if resolved == nil || observed == nil {
+		return resolved
+	}
+	out := &v1alpha1.RequiredStatusChecks{Strict: resolved.Strict, Checks: make([]v1alpha1.RequiredStatusCheck, len(resolved.Checks))}
+	copy(out.Checks, resolved.Checks)
+
+	// Checks that require an app are matched first, so that a check any app
+	// may provide does not take the observed check they require.
+	unmatched := append([]v1alpha1.RequiredStatusCheck{}, observed.Checks...)
+	take := func(match func(v1alpha1.RequiredStatusCheck) bool) (v1alpha1.RequiredStatusCheck, bool) {
+		for i, c := range unmatched {
+			if match(c) {
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				return c, true
+			}
+		}
+		return v1alpha1.RequiredStatusCheck{}, false
+	}
+	for _, c := range out.Checks {
+		if c.AppID != nil {
+			take(func(o v1alpha1.RequiredStatusCheck) bool { return cmp.Equal(c, o) })
+		}
+	}
+	for i, c := range out.Checks {
+		if c.AppID != nil {
+			continue
+		}
+		if o, ok := take(func(o v1alpha1.RequiredStatusCheck) bool { return o.Context == c.Context }); ok {
+			out.Checks[i].AppID = o.AppID
+		}
+	}
+	sort.Slice(out.Checks, func(i, j int) bool { return less(out.Checks[i], out.Checks[j]) })
+	return out
+}
+
+func less(a, b v1alpha1.RequiredStatusCheck) bool {
+	if a.Context != b.Context {
+		return a.Context < b.Context
+	}
+	return a.AppID != nil && (b.AppID == nil || *a.AppID < *b.AppID)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const errFmtNearDuplicate = "%q is named differently than the existing %q, but GitHub considers them the same; set the external name to %q to manage the existing one"
+
+// reasonNearDuplicate is the reason of the event emitted when creating a
+// managed resource would create a near-duplicate of an existing one.
+const reasonNearDuplicate event.Reason = "NearDuplicateExternalName"
+
+// A ListFn returns the identities of the existing external resources a
+// managed resource could be a near-duplicate of, such as the names of the
+// teams of an organization.
+type ListFn func(ctx context.Context) ([]string, error)
+
+// PreventNearDuplicates is a hook run before creating a managed resource of a
+// kind whose identity GitHub normalizes, for example the name of a team that
+// GitHub derives its slug from. It returns an error if an existing external
+// resource has a different identity than the supplied one that normalizes to
+// the same, so that a near-duplicate is not created. It also emits an event
+// that names the existing external resource and the external name that
+// manages it.
+func PreventNearDuplicates(ctx context.Context, r event.Recorder, mg resource.Managed, identity string, n NormalizeFn, existing ListFn) error {
+	names, err := existing(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range names {
+		if e == identity || n(e) != n(identity) {
+			continue
+		}
+		err := errors.Errorf(errFmtNearDuplicate, identity, e, e)
+		r.Event(mg, event.Warning(reasonNearDuplicate, err))
+		return err
+	}
+	return nil
+}
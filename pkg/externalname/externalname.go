@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalname contains helpers to build, parse, and normalize the
+// external names of managed resources whose identity has several parts, such
+// as owner/repo/branch.
+package externalname
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	separator = "/"
+
+	errFormat        = "external name %q is not of the form %s"
+	errUpdateManaged = "cannot update managed resource"
+	errInvalid       = "invalid external name"
+
+	errFmtNoSlug           = "team name %q contains no letters or digits"
+	errFmtRepositoryName   = "repository name %q may only contain letters, digits, '.', '-', and '_', and at most 100 characters"
+	errFmtSecretName       = "secret name %q may only contain uppercase letters, digits, and '_', and must not start with a digit"
+	errFmtSecretNamePrefix = "secret name %q must not start with GITHUB_"
+)
+
+// Format joins the supplied parts into an external name.
+func Format(parts ...string) string {
+	return strings.Join(parts, separator)
+}
+
+// Parse splits the supplied external name into as many parts as the supplied
+// format has. The format names the parts, for example owner/repo/branch, and
+// is used in the error returned when the external name does not match it.
+// None of the parts may be empty.
+func Parse(name, format string) ([]string, error) {
+	n := strings.Count(format, separator) + 1
+	parts := strings.SplitN(name, separator, n)
+	if len(parts) != n {
+		return nil, errors.Errorf(errFormat, name, format)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, errors.Errorf(errFormat, name, format)
+		}
+	}
+	return parts, nil
+}
+
+// Parse2 splits the supplied external name into the two parts of the
+// supplied format.
+func Parse2(name, format string) (string, string, error) {
+	p, err := Parse(name, format)
+	if err != nil {
+		return "", "", err
+	}
+	return p[0], p[1], nil
+}
+
+// Parse3 splits the supplied external name into the three parts of the
+// supplied format.
+func Parse3(name, format string) (string, string, string, error) {
+	p, err := Parse(name, format)
+	if err != nil {
+		return "", "", "", err
+	}
+	return p[0], p[1], p[2], nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Slug returns the slug GitHub derives from the supplied name, for example
+// my-team for My Team.
+func Slug(name string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// A NormalizeFn returns the normalized form of an external name.
+type NormalizeFn func(name string) string
+
+// A ValidateFn returns an error if an external name is not valid.
+type ValidateFn func(name string) error
+
+var (
+	nonRepositoryName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	repositoryName    = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+	nonSecretName     = regexp.MustCompile(`[^A-Z0-9_]+`)
+	secretName        = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+)
+
+// RepositoryName normalizes a repository name the way GitHub does, by
+// replacing the characters it does not allow with '-'.
+func RepositoryName(name string) string {
+	return nonRepositoryName.ReplaceAllString(name, "-")
+}
+
+// SecretName normalizes a secret name to the uppercase letters, digits, and
+// underscores GitHub allows, for example MY_SECRET for my-secret.
+func SecretName(name string) string {
+	return nonSecretName.ReplaceAllString(strings.ToUpper(name), "_")
+}
+
+// ValidateTeamName returns an error if GitHub cannot derive a slug from the
+// supplied team name.
+func ValidateTeamName(name string) error {
+	if Slug(name) == "" {
+		return errors.Errorf(errFmtNoSlug, name)
+	}
+	return nil
+}
+
+// ValidateRepositoryName returns an error if the supplied repository name is
+// not allowed by GitHub.
+func ValidateRepositoryName(name string) error {
+	if !repositoryName.MatchString(name) || name == "." || name == ".." {
+		return errors.Errorf(errFmtRepositoryName, name)
+	}
+	return nil
+}
+
+// ValidateSecretName returns an error if the supplied secret name is not
+// allowed by GitHub.
+func ValidateSecretName(name string) error {
+	if !secretName.MatchString(name) {
+		return errors.Errorf(errFmtSecretName, name)
+	}
+	if strings.HasPrefix(name, "GITHUB_") {
+		return errors.Errorf(errFmtSecretNamePrefix, name)
+	}
+	return nil
+}
+
+// Unchanged is a NormalizeFn that returns the supplied name unchanged.
+func Unchanged(name string) string {
+	return name
+}
+
+// Lowercase normalizes case-insensitive external names such as slugs and
+// logins.
+func Lowercase(name string) string {
+	return strings.ToLower(name)
+}
+
+// StripBranchRef normalizes a branch name given as a fully qualified ref,
+// such as refs/heads/main, to the branch name.
+func StripBranchRef(name string) string {
+	return strings.TrimPrefix(name, "refs/heads/")
+}
+
+// A Normalizer is an initializer that normalizes the external name of a
+// managed resource, so that equivalent names given by users do not identify
+// different external resources.
+type Normalizer struct {
+	kube client.Client
+	fns  []NormalizeFn
+}
+
+// NewNormalizer returns a Normalizer that applies the supplied functions in
+// order.
+func NewNormalizer(c client.Client, fns ...NormalizeFn) *Normalizer {
+	return &Normalizer{kube: c, fns: fns}
+}
+
+// Initialize normalizes the external name of the supplied managed resource
+// and persists it if it changed.
+func (n *Normalizer) Initialize(ctx context.Context, mg resource.Managed) error {
+	name := meta.GetExternalName(mg)
+	if name == "" {
+		return nil
+	}
+	norm := name
+	for _, fn := range n.fns {
+		norm = fn(norm)
+	}
+	if norm == name {
+		return nil
+	}
+	meta.SetExternalName(mg, norm)
+	return errors.Wrap(n.kube.Update(ctx, mg), errUpdateManaged)
+}
+
+// A Defaulter is an initializer that sets the external name of a managed
+// resource from its metadata.name, normalized to the naming rules of its
+// kind, if it has none. It rejects external names that break these rules.
+type Defaulter struct {
+	kube      client.Client
+	normalize NormalizeFn
+	validate  ValidateFn
+}
+
+// NewDefaulter returns a Defaulter that normalizes metadata.name with the
+// supplied function and validates external names with the other.
+func NewDefaulter(c client.Client, n NormalizeFn, v ValidateFn) *Defaulter {
+	return &Defaulter{kube: c, normalize: n, validate: v}
+}
+
+// Initialize defaults and validates the external name of the supplied
+// managed resource, and persists it if it was defaulted.
+func (d *Defaulter) Initialize(ctx context.Context, mg resource.Managed) error {
+	name := meta.GetExternalName(mg)
+	if name != "" {
+		return errors.Wrap(d.validate(name), errInvalid)
+	}
+	name = d.normalize(mg.GetName())
+	if err := d.validate(name); err != nil {
+		return errors.Wrap(err, errInvalid)
+	}
+	meta.SetExternalName(mg, name)
+	return errors.Wrap(d.kube.Update(ctx, mg), errUpdateManaged)
+}
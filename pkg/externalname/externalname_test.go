@@ -0,0 +1,324 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fn     NormalizeFn
+		name   string
+		want   string
+	}{
+		"SlugLowercases": {
+			reason: "Slugs should be lowercase.",
+			fn:     Slug,
+			name:   "Platform",
+			want:   "platform",
+		},
+		"SlugReplacesSpaces": {
+			reason: "Runs of characters slugs do not allow should be replaced with a single '-'.",
+			fn:     Slug,
+			name:   "Platform  &  Infra",
+			want:   "platform-infra",
+		},
+		"SlugTrims": {
+			reason: "Slugs should not start or end with '-'.",
+			fn:     Slug,
+			name:   "!Platform!",
+			want:   "platform",
+		},
+		"SlugKeepsUnderscores": {
+			reason: "Underscores are allowed in slugs.",
+			fn:     Slug,
+			name:   "platform_team",
+			want:   "platform_team",
+		},
+		"RepositoryNameKeepsCase": {
+			reason: "Repository names may contain uppercase letters, '.', '-', and '_'.",
+			fn:     RepositoryName,
+			name:   "My_Repo.go-1",
+			want:   "My_Repo.go-1",
+		},
+		"RepositoryNameReplacesSpaces": {
+			reason: "Runs of characters repository names do not allow should be replaced with a single '-'.",
+			fn:     RepositoryName,
+			name:   "my  cool/repo",
+			want:   "my-cool-repo",
+		},
+		"SecretNameUppercases": {
+			reason: "Secret names should be uppercase, with '_' instead of other characters.",
+			fn:     SecretName,
+			name:   "my-api.token",
+			want:   "MY_API_TOKEN",
+		},
+		"Lowercase": {
+			reason: "Lowercase should only change the case.",
+			fn:     Lowercase,
+			name:   "OctoCat",
+			want:   "octocat",
+		},
+		"StripBranchRef": {
+			reason: "A fully qualified branch ref should be normalized to the branch name.",
+			fn:     StripBranchRef,
+			name:   "refs/heads/release/v1",
+			want:   "release/v1",
+		},
+		"StripBranchRefName": {
+			reason: "A branch name should be left as it is.",
+			fn:     StripBranchRef,
+			name:   "main",
+			want:   "main",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.fn(tc.name)); diff != "" {
+				t.Errorf("\n%s\nfn(%q): -want, +got:\n%s", tc.reason, tc.name, diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fn     ValidateFn
+		name   string
+		want   error
+	}{
+		"TeamName": {
+			reason: "A team name with letters or digits should be valid.",
+			fn:     ValidateTeamName,
+			name:   "Platform Team",
+		},
+		"TeamNameNoSlug": {
+			reason: "A team name GitHub cannot derive a slug from should be rejected.",
+			fn:     ValidateTeamName,
+			name:   "!!!",
+			want:   errors.Errorf(errFmtNoSlug, "!!!"),
+		},
+		"RepositoryName": {
+			reason: "A repository name of allowed characters should be valid.",
+			fn:     ValidateRepositoryName,
+			name:   "My_Repo.go-1",
+		},
+		"RepositoryNameSpace": {
+			reason: "A repository name with a space should be rejected.",
+			fn:     ValidateRepositoryName,
+			name:   "my repo",
+			want:   errors.Errorf(errFmtRepositoryName, "my repo"),
+		},
+		"RepositoryNameDot": {
+			reason: "A repository cannot be named '.'.",
+			fn:     ValidateRepositoryName,
+			name:   ".",
+			want:   errors.Errorf(errFmtRepositoryName, "."),
+		},
+		"RepositoryNameTooLong": {
+			reason: "A repository name longer than 100 characters should be rejected.",
+			fn:     ValidateRepositoryName,
+			name:   strings.Repeat("a", 101),
+			want:   errors.Errorf(errFmtRepositoryName, strings.Repeat("a", 101)),
+		},
+		"SecretName": {
+			reason: "A secret name of uppercase letters, digits, and underscores should be valid.",
+			fn:     ValidateSecretName,
+			name:   "_MY_TOKEN_2",
+		},
+		"SecretNameLowercase": {
+			reason: "A lowercase secret name should be rejected.",
+			fn:     ValidateSecretName,
+			name:   "my_token",
+			want:   errors.Errorf(errFmtSecretName, "my_token"),
+		},
+		"SecretNameDigit": {
+			reason: "A secret name starting with a digit should be rejected.",
+			fn:     ValidateSecretName,
+			name:   "2FA_TOKEN",
+			want:   errors.Errorf(errFmtSecretName, "2FA_TOKEN"),
+		},
+		"SecretNameReserved": {
+			reason: "Secret names starting with GITHUB_ are reserved.",
+			fn:     ValidateSecretName,
+			name:   "GITHUB_TOKEN",
+			want:   errors.Errorf(errFmtSecretNamePrefix, "GITHUB_TOKEN"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.fn(tc.name), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nfn(%q): -want error, +got error:\n%s", tc.reason, tc.name, diff)
+			}
+		})
+	}
+}
+
+// managed returns a managed resource with the supplied name and, unless it
+// is empty, external name.
+func managed(name, external string) *fake.Managed {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if external != "" {
+		meta.SetExternalName(mg, external)
+	}
+	return mg
+}
+
+func TestDefaulterInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err      error
+		external string
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		n      NormalizeFn
+		v      ValidateFn
+		mg     *fake.Managed
+		want   want
+	}{
+		"Defaulted": {
+			reason: "A missing external name should be defaulted to the normalized metadata.name.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			n:      SecretName,
+			v:      ValidateSecretName,
+			mg:     managed("my-token", ""),
+			want:   want{external: "MY_TOKEN"},
+		},
+		"Valid": {
+			reason: "A valid external name should be left as it is.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			n:      Slug,
+			v:      ValidateTeamName,
+			mg:     managed("platform", "Platform Team"),
+			want:   want{external: "Platform Team"},
+		},
+		"Invalid": {
+			reason: "An external name that breaks the naming rules should be rejected.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			n:      SecretName,
+			v:      ValidateSecretName,
+			mg:     managed("token", "GITHUB_TOKEN"),
+			want: want{
+				err:      errors.Wrap(errors.Errorf(errFmtSecretNamePrefix, "GITHUB_TOKEN"), errInvalid),
+				external: "GITHUB_TOKEN",
+			},
+		},
+		"InvalidDefault": {
+			reason: "A metadata.name that cannot be normalized into a valid external name should be rejected.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			n:      SecretName,
+			v:      ValidateSecretName,
+			mg:     managed("2fa", ""),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtSecretName, "2FA"), errInvalid)},
+		},
+		"UpdateError": {
+			reason: "Errors persisting the defaulted external name should be returned.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			n:      RepositoryName,
+			v:      ValidateRepositoryName,
+			mg:     managed("my-repo", ""),
+			want:   want{err: errors.Wrap(errBoom, errUpdateManaged), external: "my-repo"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewDefaulter(tc.kube, tc.n, tc.v).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.external, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizerInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err      error
+		external string
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		fns    []NormalizeFn
+		mg     *fake.Managed
+		want   want
+	}{
+		"NoExternalName": {
+			reason: "A managed resource without an external name should be left as it is.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			fns:    []NormalizeFn{Lowercase},
+			mg:     managed("octocat", ""),
+		},
+		"Normalized": {
+			reason: "An external name that is already normalized should not be persisted again.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			fns:    []NormalizeFn{Lowercase},
+			mg:     managed("octocat", "octocat"),
+			want:   want{external: "octocat"},
+		},
+		"InOrder": {
+			reason: "All functions should be applied in order, and the result persisted.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			fns:    []NormalizeFn{StripBranchRef, Lowercase},
+			mg:     managed("branch", "refs/heads/Main"),
+			want:   want{external: "main"},
+		},
+		"UpdateError": {
+			reason: "Errors persisting the normalized external name should be returned.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			fns:    []NormalizeFn{Lowercase},
+			mg:     managed("octocat", "OctoCat"),
+			want:   want{err: errors.Wrap(errBoom, errUpdateManaged), external: "octocat"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewNormalizer(tc.kube, tc.fns...).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.external, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,705 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ghserver implements a fake GitHub API that keeps its state in
+// memory. It serves the subset of endpoints the controllers use, so they can
+// be exercised without a real organization.
+package ghserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+)
+
+// DefaultRateLimit is the primary rate limit the server simulates by default.
+const DefaultRateLimit = 5000
+
+// Login is the login of the user the server authenticates every request as.
+const Login = "fake"
+
+// A Server is a fake GitHub API.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	teams       map[string]map[string]*github.Team
+	repos       map[string]*github.Repository
+	hooks       map[string]map[int64]*github.Hook
+	protections map[string]*github.Protection
+	members     map[string]map[string]string
+	failures    []*failure
+
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// A failure is an error response the server returns instead of handling the
+// requests it matches.
+type failure struct {
+	method string
+	path   string
+	status int
+	times  int
+}
+
+// New starts and returns a new Server. Callers should Close it when done.
+func New() *Server {
+	s := &Server{
+		teams:       map[string]map[string]*github.Team{},
+		repos:       map[string]*github.Repository{},
+		hooks:       map[string]map[int64]*github.Hook{},
+		protections: map[string]*github.Protection{},
+		members:     map[string]map[string]string{},
+		limit:       DefaultRateLimit,
+		remaining:   DefaultRateLimit,
+		reset:       time.Now().Add(time.Hour),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// GitHubClient returns a GitHub client that sends requests to the server.
+func (s *Server) GitHubClient() *github.Client {
+	c := github.NewClient(s.Server.Client())
+	u, _ := url.Parse(s.URL + "/")
+	c.BaseURL, c.UploadURL = u, u
+	return c
+}
+
+// Fail makes the server respond to the next times requests with the supplied
+// method and path with the supplied status, instead of handling them. The
+// path is relative to the root of the API, for example /orgs/o/teams/t.
+func (s *Server) Fail(method, path string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{method: method, path: path, status: status, times: times})
+}
+
+// SetRateLimit sets the primary rate limit the server simulates. Requests are
+// rejected as rate limited once no requests remain, until the reset time.
+func (s *Server) SetRateLimit(limit, remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.remaining, s.reset = limit, remaining, reset
+}
+
+// AddTeam adds a team with the supplied name to the supplied organization,
+// as if it had been created outside of the provider.
+func (s *Server) AddTeam(org, name string) *github.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &github.Team{ID: github.Int64(s.id()), Slug: github.String(externalname.Slug(name)), Name: github.String(name), Privacy: github.String("secret")}
+	t.NodeID = github.String(fmt.Sprintf("T_%d", t.GetID()))
+	t.HTMLURL = github.String(fmt.Sprintf("%s/orgs/%s/teams/%s", s.URL, org, t.GetSlug()))
+	if s.teams[org] == nil {
+		s.teams[org] = map[string]*github.Team{}
+	}
+	s.teams[org][t.GetSlug()] = t
+	return t
+}
+
+// AddMember adds the user with the supplied login to the supplied
+// organization with the supplied role, admin or member.
+func (s *Server) AddMember(org, login, role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members[org] == nil {
+		s.members[org] = map[string]string{}
+	}
+	s.members[org][login] = role
+}
+
+// Member returns the role of the user with the supplied login in the
+// supplied organization, or an empty string if they are not a member.
+func (s *Server) Member(org, login string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.members[org][login]
+}
+
+// Team returns the team with the supplied slug, or nil if it does not exist.
+func (s *Server) Team(org, slug string) *github.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.teams[org][slug]
+}
+
+// Repository returns the repository with the supplied owner and name, or nil
+// if it does not exist.
+func (s *Server) Repository(owner, name string) *github.Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.repos[owner+"/"+name]
+}
+
+// Protection returns the protection of the supplied branch, or nil if it is
+// not protected.
+func (s *Server) Protection(owner, repo, branch string) *github.Protection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.protections[owner+"/"+repo+"/"+branch]
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().After(s.reset) {
+		s.remaining, s.reset = s.limit, time.Now().Add(time.Hour)
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	if s.remaining <= 0 {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		writeError(w, http.StatusForbidden, "API rate limit exceeded")
+		return
+	}
+	s.remaining--
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+
+	// GitHub Enterprise serves the REST API below /api/v3, which is where
+	// clients configured with a base URL send their requests.
+	path := strings.TrimPrefix(r.URL.Path, "/api/v3")
+
+	for i, f := range s.failures {
+		if f.method == r.Method && f.path == path {
+			if f.times--; f.times <= 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+			writeError(w, f.status, http.StatusText(f.status))
+			return
+		}
+	}
+
+	p := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(p) == 1 && p[0] == "rate_limit":
+		s.rateLimit(w)
+	case len(p) == 1 && p[0] == "user":
+		writeJSON(w, http.StatusOK, &github.User{Login: github.String(Login)})
+	case len(p) == 3 && p[0] == "orgs" && p[2] == "teams":
+		s.teamCollection(w, r, p[1])
+	case len(p) == 4 && p[0] == "orgs" && p[2] == "teams":
+		s.team(w, r, p[1], p[3])
+	case len(p) == 3 && p[0] == "orgs" && p[2] == "members":
+		s.memberCollection(w, r, p[1])
+	case len(p) == 4 && p[0] == "orgs" && p[2] == "memberships":
+		s.membership(w, r, p[1], p[3])
+	case len(p) == 3 && p[0] == "orgs" && p[2] == "invitations":
+		// Users are added as members right away, so nobody is invited.
+		page(w, r, []*github.Invitation{})
+	case len(p) == 3 && p[0] == "orgs" && p[2] == "repos":
+		s.createRepository(w, r, p[1])
+	case len(p) == 2 && p[0] == "user" && p[1] == "repos":
+		s.createRepository(w, r, Login)
+	case len(p) == 3 && p[0] == "repos":
+		s.repository(w, r, p[1], p[2])
+	case len(p) == 4 && p[0] == "repos" && p[3] == "hooks":
+		s.hookCollection(w, r, p[1]+"/"+p[2])
+	case len(p) == 5 && p[0] == "repos" && p[3] == "hooks":
+		s.hook(w, r, p[1]+"/"+p[2], p[4])
+	case len(p) == 6 && p[0] == "repos" && p[3] == "branches" && p[5] == "protection":
+		s.protection(w, r, p[1]+"/"+p[2], p[4])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) rateLimit(w http.ResponseWriter) {
+	rate := &github.Rate{Limit: s.limit, Remaining: s.remaining, Reset: github.Timestamp{Time: s.reset}}
+	writeJSON(w, http.StatusOK, &github.RateLimits{Core: rate, Search: rate, GraphQL: rate})
+}
+
+func (s *Server) teamCollection(w http.ResponseWriter, r *http.Request, org string) {
+	switch r.Method {
+	case http.MethodGet:
+		teams := []*github.Team{}
+		for _, t := range s.teams[org] {
+			teams = append(teams, t)
+		}
+		sort.Slice(teams, func(i, j int) bool { return teams[i].GetID() < teams[j].GetID() })
+		page(w, r, teams)
+	case http.MethodPost:
+		nt := &github.NewTeam{}
+		if !readJSON(w, r, nt) {
+			return
+		}
+		slug := externalname.Slug(nt.Name)
+		if s.teams[org][slug] != nil {
+			writeError(w, http.StatusUnprocessableEntity, "Name must be unique for this org")
+			return
+		}
+		t := &github.Team{ID: github.Int64(s.id()), Slug: github.String(slug)}
+		if !s.editTeam(w, org, t, nt) {
+			return
+		}
+		t.NodeID = github.String(fmt.Sprintf("T_%d", t.GetID()))
+		if s.teams[org] == nil {
+			s.teams[org] = map[string]*github.Team{}
+		}
+		s.teams[org][slug] = t
+		writeJSON(w, http.StatusCreated, t)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) team(w http.ResponseWriter, r *http.Request, org, slug string) {
+	t := s.teams[org][slug]
+	if t == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, t)
+	case http.MethodPatch:
+		raw := json.RawMessage{}
+		if !readJSON(w, r, &raw) {
+			return
+		}
+		nt := &github.NewTeam{}
+		_ = json.Unmarshal(raw, nt)
+		// A null parent team ID removes the team from its parent.
+		fields := map[string]json.RawMessage{}
+		_ = json.Unmarshal(raw, &fields)
+		if p, ok := fields["parent_team_id"]; ok && string(p) == "null" {
+			t.Parent = nil
+		}
+		if !s.editTeam(w, org, t, nt) {
+			return
+		}
+		// GitHub derives the slug from the name, so renaming a team moves it.
+		delete(s.teams[org], slug)
+		t.Slug = github.String(externalname.Slug(t.GetName()))
+		s.teams[org][t.GetSlug()] = t
+		writeJSON(w, http.StatusOK, t)
+	case http.MethodDelete:
+		delete(s.teams[org], slug)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// editTeam applies the supplied changes to the supplied team.
+func (s *Server) editTeam(w http.ResponseWriter, org string, t *github.Team, nt *github.NewTeam) bool {
+	if nt.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Name can't be blank")
+		return false
+	}
+	t.Name = github.String(nt.Name)
+	if nt.Description != nil {
+		t.Description = nt.Description
+	}
+	if nt.Privacy != nil {
+		t.Privacy = nt.Privacy
+	}
+	if t.Privacy == nil {
+		t.Privacy = github.String("secret")
+	}
+	if nt.ParentTeamID != nil {
+		t.Parent = nil
+		for _, p := range s.teams[org] {
+			if p.GetID() == *nt.ParentTeamID {
+				t.Parent = &github.Team{ID: p.ID, Slug: p.Slug, Name: p.Name}
+			}
+		}
+		if t.Parent == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Parent team does not exist")
+			return false
+		}
+	}
+	t.HTMLURL = github.String(fmt.Sprintf("%s/orgs/%s/teams/%s", s.URL, org, externalname.Slug(nt.Name)))
+	return true
+}
+
+func (s *Server) memberCollection(w http.ResponseWriter, r *http.Request, org string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	role := r.URL.Query().Get("role")
+	users := []*github.User{}
+	for login, rl := range s.members[org] {
+		if role == "" || role == "all" || role == rl {
+			users = append(users, &github.User{Login: github.String(login)})
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].GetLogin() < users[j].GetLogin() })
+	page(w, r, users)
+}
+
+func (s *Server) membership(w http.ResponseWriter, r *http.Request, org, login string) {
+	switch r.Method {
+	case http.MethodGet:
+		role, ok := s.members[org][login]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		writeJSON(w, http.StatusOK, &github.Membership{Role: github.String(role), State: github.String("active"), User: &github.User{Login: github.String(login)}})
+	case http.MethodPut:
+		m := &github.Membership{}
+		if !readJSON(w, r, m) {
+			return
+		}
+		if m.GetRole() != "admin" && m.GetRole() != "member" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+		if s.members[org] == nil {
+			s.members[org] = map[string]string{}
+		}
+		s.members[org][login] = m.GetRole()
+		writeJSON(w, http.StatusOK, &github.Membership{Role: m.Role, State: github.String("active"), User: &github.User{Login: github.String(login)}})
+	case http.MethodDelete:
+		if _, ok := s.members[org][login]; !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		delete(s.members[org], login)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request, owner string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	repo := &github.Repository{}
+	if !readJSON(w, r, repo) {
+		return
+	}
+	if repo.GetName() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Repository name can't be blank")
+		return
+	}
+	key := owner + "/" + repo.GetName()
+	if s.repos[key] != nil {
+		writeError(w, http.StatusUnprocessableEntity, "name already exists on this account")
+		return
+	}
+	repo.ID = github.Int64(s.id())
+	repo.NodeID = github.String(fmt.Sprintf("R_%d", repo.GetID()))
+	repo.Owner = &github.User{Login: github.String(owner)}
+	repo.FullName = github.String(key)
+	if repo.DefaultBranch == nil {
+		repo.DefaultBranch = github.String("main")
+	}
+	s.repos[key] = repo
+	writeJSON(w, http.StatusCreated, repo)
+}
+
+func (s *Server) repository(w http.ResponseWriter, r *http.Request, owner, name string) {
+	key := owner + "/" + name
+	repo := s.repos[key]
+	if repo == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, repo)
+	case http.MethodPatch:
+		edit := map[string]interface{}{}
+		if !readJSON(w, r, &edit) {
+			return
+		}
+		// Apply the edit by merging it into the JSON form of the repository,
+		// so every field the API accepts is supported.
+		raw, _ := json.Marshal(repo)
+		current := map[string]interface{}{}
+		_ = json.Unmarshal(raw, &current)
+		for k, v := range edit {
+			current[k] = v
+		}
+		raw, _ = json.Marshal(current)
+		updated := &github.Repository{}
+		_ = json.Unmarshal(raw, updated)
+		delete(s.repos, key)
+		updated.FullName = github.String(owner + "/" + updated.GetName())
+		s.repos[updated.GetFullName()] = updated
+		s.move(key, updated.GetFullName())
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		delete(s.repos, key)
+		s.forget(key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// move moves the hooks and branch protections of a renamed repository along
+// with it, as GitHub does.
+func (s *Server) move(from, to string) {
+	if from == to {
+		return
+	}
+	if h, ok := s.hooks[from]; ok {
+		delete(s.hooks, from)
+		s.hooks[to] = h
+	}
+	for k, p := range s.protections {
+		if branch := strings.TrimPrefix(k, from+"/"); branch != k {
+			delete(s.protections, k)
+			s.protections[to+"/"+branch] = p
+		}
+	}
+}
+
+// forget removes the hooks and branch protections of a deleted repository,
+// as GitHub does.
+func (s *Server) forget(repo string) {
+	delete(s.hooks, repo)
+	for k := range s.protections {
+		if strings.HasPrefix(k, repo+"/") {
+			delete(s.protections, k)
+		}
+	}
+}
+
+func (s *Server) hookCollection(w http.ResponseWriter, r *http.Request, repo string) {
+	if s.repos[repo] == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		hooks := []*github.Hook{}
+		for _, h := range s.hooks[repo] {
+			hooks = append(hooks, h)
+		}
+		sort.Slice(hooks, func(i, j int) bool { return hooks[i].GetID() < hooks[j].GetID() })
+		page(w, r, hooks)
+	case http.MethodPost:
+		h := &github.Hook{}
+		if !readJSON(w, r, h) {
+			return
+		}
+		h.ID = github.Int64(s.id())
+		if h.Active == nil {
+			h.Active = github.Bool(true)
+		}
+		if s.hooks[repo] == nil {
+			s.hooks[repo] = map[int64]*github.Hook{}
+		}
+		s.hooks[repo][h.GetID()] = h
+		writeJSON(w, http.StatusCreated, h)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) hook(w http.ResponseWriter, r *http.Request, repo, id string) {
+	n, _ := strconv.ParseInt(id, 10, 64)
+	h := s.hooks[repo][n]
+	if h == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, h)
+	case http.MethodPatch:
+		edit := &github.Hook{}
+		if !readJSON(w, r, edit) {
+			return
+		}
+		if edit.Config != nil {
+			h.Config = edit.Config
+		}
+		if edit.Events != nil {
+			h.Events = edit.Events
+		}
+		if edit.Active != nil {
+			h.Active = edit.Active
+		}
+		writeJSON(w, http.StatusOK, h)
+	case http.MethodDelete:
+		delete(s.hooks[repo], n)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) protection(w http.ResponseWriter, r *http.Request, repo, branch string) {
+	if s.repos[repo] == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	key := repo + "/" + branch
+	switch r.Method {
+	case http.MethodGet:
+		p := s.protections[key]
+		if p == nil {
+			writeError(w, http.StatusNotFound, "Branch not protected")
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodPut:
+		req := &github.ProtectionRequest{}
+		if !readJSON(w, r, req) {
+			return
+		}
+		p := &github.Protection{
+			RequiredStatusChecks: req.RequiredStatusChecks,
+			EnforceAdmins:        &github.AdminEnforcement{Enabled: req.EnforceAdmins},
+			AllowForcePushes:     &github.AllowForcePushes{Enabled: req.GetAllowForcePushes()},
+			AllowDeletions:       &github.AllowDeletions{Enabled: req.GetAllowDeletions()},
+		}
+		if rv := req.RequiredPullRequestReviews; rv != nil {
+			p.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcement{
+				DismissStaleReviews:          rv.DismissStaleReviews,
+				RequireCodeOwnerReviews:      rv.RequireCodeOwnerReviews,
+				RequiredApprovingReviewCount: rv.RequiredApprovingReviewCount,
+			}
+			if d := rv.DismissalRestrictionsRequest; d != nil {
+				users, teams, apps := actors(deref(d.Users), deref(d.Teams), deref(d.Apps))
+				p.RequiredPullRequestReviews.DismissalRestrictions = &github.DismissalRestrictions{Users: users, Teams: teams, Apps: apps}
+			}
+			if b := rv.BypassPullRequestAllowancesRequest; b != nil {
+				users, teams, apps := actors(b.Users, b.Teams, b.Apps)
+				p.RequiredPullRequestReviews.BypassPullRequestAllowances = &github.BypassPullRequestAllowances{Users: users, Teams: teams, Apps: apps}
+			}
+		}
+		if rs := req.Restrictions; rs != nil {
+			users, teams, apps := actors(rs.Users, rs.Teams, rs.Apps)
+			p.Restrictions = &github.BranchRestrictions{Users: users, Teams: teams, Apps: apps}
+		}
+		s.protections[key] = p
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodDelete:
+		if s.protections[key] == nil {
+			writeError(w, http.StatusNotFound, "Branch not protected")
+			return
+		}
+		delete(s.protections, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// actors returns the users, teams, and apps with the supplied logins and
+// slugs, as GitHub returns them for the actors a protection names.
+func actors(logins, teams, apps []string) ([]*github.User, []*github.Team, []*github.App) {
+	u := make([]*github.User, 0, len(logins))
+	for _, l := range logins {
+		u = append(u, &github.User{Login: github.String(l)})
+	}
+	t := make([]*github.Team, 0, len(teams))
+	for _, slug := range teams {
+		t = append(t, &github.Team{Slug: github.String(slug), Name: github.String(slug)})
+	}
+	a := make([]*github.App, 0, len(apps))
+	for _, slug := range apps {
+		a = append(a, &github.App{Slug: github.String(slug), Name: github.String(slug)})
+	}
+	return u, t, a
+}
+
+func deref(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// Page sizes of list endpoints.
+const (
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+// page writes the page of the supplied items the request asks for. Like
+// GitHub, it links to the next and the last page if there are more.
+func page[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+	per, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || per <= 0 {
+		per = defaultPerPage
+	}
+	if per > maxPerPage {
+		per = maxPerPage
+	}
+	n, err := strconv.Atoi(q.Get("page"))
+	if err != nil || n <= 0 {
+		n = 1
+	}
+
+	last := (len(items) + per - 1) / per
+	if n < last {
+		link := func(p int, rel string) string {
+			q.Set("page", strconv.Itoa(p))
+			q.Set("per_page", strconv.Itoa(per))
+			u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: q.Encode()}
+			return fmt.Sprintf("<%s>; rel=%q", u.String(), rel)
+		}
+		w.Header().Set("Link", link(n+1, "next")+", "+link(last, "last"))
+	}
+
+	start, end := (n-1)*per, n*per
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, &github.ErrorResponse{Message: msg})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the feature flags of the provider.
+package features
+
+import "github.com/crossplane/crossplane-runtime/pkg/feature"
+
+// Feature flags.
+const (
+	// EnableAlphaWebhookSource enables reconciles triggered by GitHub
+	// webhook events.
+	EnableAlphaWebhookSource feature.Flag = "EnableAlphaWebhookSource"
+
+	// EnableAlphaETagCache enables caching of GitHub API responses for
+	// conditional requests.
+	EnableAlphaETagCache feature.Flag = "EnableAlphaETagCache"
+
+	// EnableAlphaTeamObservationCache enables observing teams using a cache
+	// of all teams of their organization.
+	EnableAlphaTeamObservationCache feature.Flag = "EnableAlphaTeamObservationCache"
+
+	// EnableAlphaDiscovery enables the discovery of existing GitHub resources
+	// configured by ProviderConfigs.
+	EnableAlphaDiscovery feature.Flag = "EnableAlphaDiscovery"
+
+	// EnableAlphaAuditLogPolling enables reconciles triggered by changes
+	// found in the audit logs of organizations.
+	EnableAlphaAuditLogPolling feature.Flag = "EnableAlphaAuditLogPolling"
+
+	// EnableAlphaRepositoryDefaults enables the RepositoryDefaults kind,
+	// which applies a template of settings to selected repositories of an
+	// organization.
+	EnableAlphaRepositoryDefaults feature.Flag = "EnableAlphaRepositoryDefaults"
+)
@@ -0,0 +1,441 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook receives GitHub webhook events and triggers reconciles of
+// the managed resources they concern, so that changes made outside of the
+// provider are corrected without waiting for the next poll.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/hasheddan/kc-provider-github/pkg/externalname"
+	"github.com/hasheddan/kc-provider-github/pkg/features"
+)
+
+const (
+	// maxPayloadSize is the largest payload GitHub sends.
+	maxPayloadSize = 25 << 20
+
+	// maxDeliveries is the number of delivery IDs remembered to detect
+	// replayed deliveries.
+	maxDeliveries = 10000
+
+	repositoryKind = "Repository"
+	teamKind       = "Team"
+
+	// The fields managed resources are indexed by. Repositories are indexed
+	// by owner/name, and teams by org/slug.
+	orgField        = "webhook.org"
+	repositoryField = "webhook.repository"
+	teamField       = "webhook.team"
+)
+
+const (
+	errFmtNewObject  = "cannot create an object of kind %s"
+	errFmtNotObject  = "kind %s is not an object"
+	errFmtIndexField = "cannot index field %s of kind %s"
+)
+
+var events = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "github_webhook_events_total",
+	Help: "Number of GitHub webhook events, by whether they were received, matched managed resources, or were dropped.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(events)
+}
+
+var (
+	kindsMu sync.RWMutex
+	kinds   = map[schema.GroupVersionKind]chan event.GenericEvent{}
+)
+
+// Enabled reports whether reconciles are triggered by changes made outside
+// of the provider, which is the case if webhook events are received or audit
+// logs are polled. Controllers watch their Source only if it is.
+func Enabled(f *feature.Flags) bool {
+	return f.Enabled(features.EnableAlphaWebhookSource) || f.Enabled(features.EnableAlphaAuditLogPolling)
+}
+
+// Source returns a source of reconciles of managed resources of the supplied
+// kind that webhook events concern. It is watched by the controller of the
+// kind.
+func Source(gvk schema.GroupVersionKind) source.Source {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	ch, ok := kinds[gvk]
+	if !ok {
+		ch = make(chan event.GenericEvent, 100)
+		kinds[gvk] = ch
+	}
+	return &source.Channel{Source: ch}
+}
+
+// A Server receives GitHub webhook events.
+type Server struct {
+	addr   string
+	secret []byte
+	kube   client.Client
+	scheme *runtime.Scheme
+	log    logging.Logger
+
+	mu         sync.Mutex
+	deliveries map[string]bool
+	order      []string
+}
+
+// NewServer returns a Server that listens on the supplied address and only
+// accepts events signed with the supplied secret.
+func NewServer(addr, secret string, c client.Client, s *runtime.Scheme, l logging.Logger) *Server {
+	return &Server{addr: addr, secret: []byte(secret), kube: c, scheme: s, log: l, deliveries: map[string]bool{}}
+}
+
+// Start serves webhook events until the supplied context is done.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{Addr: s.addr, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		sctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(sctx)
+	}()
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// A payload holds the parts of a webhook event that identify what it
+// concerns.
+type payload struct {
+	Organization *struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+	Repository *struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	Team *struct {
+		Slug string `json:"slug"`
+	} `json:"team"`
+}
+
+// ServeHTTP handles a webhook event.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	events.WithLabelValues("received").Inc()
+	if r.Method != http.MethodPost {
+		s.drop(w, http.StatusMethodNotAllowed, "unexpected method")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		s.drop(w, http.StatusBadRequest, "cannot read payload")
+		return
+	}
+	if !s.valid(r.Header.Get("X-Hub-Signature-256"), body) {
+		s.drop(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+	if !s.first(r.Header.Get("X-GitHub-Delivery")) {
+		s.drop(w, http.StatusConflict, "replayed delivery")
+		return
+	}
+	p := payload{}
+	if err := json.Unmarshal(body, &p); err != nil {
+		s.drop(w, http.StatusBadRequest, "cannot parse payload")
+		return
+	}
+
+	n := s.enqueue(r.Context(), p)
+	s.log.Debug("Received webhook event", "event", r.Header.Get("X-GitHub-Event"), "delivery", r.Header.Get("X-GitHub-Delivery"), "matched", n)
+	if n > 0 {
+		events.WithLabelValues("matched").Inc()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) drop(w http.ResponseWriter, code int, reason string) {
+	events.WithLabelValues("dropped").Inc()
+	s.log.Debug("Dropped webhook event", "reason", reason)
+	http.Error(w, reason, code)
+}
+
+// valid reports whether the supplied signature is the signature of the
+// supplied payload.
+func (s *Server) valid(signature string, body []byte) bool {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// first reports whether the delivery with the supplied ID is seen for the
+// first time. A signed delivery may be captured and sent again.
+func (s *Server) first(id string) bool {
+	if id == "" {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deliveries[id] {
+		return false
+	}
+	s.deliveries[id] = true
+	s.order = append(s.order, id)
+	if len(s.order) > maxDeliveries {
+		delete(s.deliveries, s.order[0])
+		s.order = s.order[1:]
+	}
+	return true
+}
+
+// An Event identifies what a change made outside of the provider concerns.
+type Event struct {
+	// Org is the organization the change was made in.
+	Org string
+
+	// Owner and Repository identify the repository the change was made to,
+	// if any.
+	Owner      string
+	Repository string
+
+	// Team is the slug of the team the change was made to, if any.
+	Team string
+}
+
+// event returns the Event the supplied payload is about.
+func (p payload) event() Event {
+	e := Event{}
+	if p.Organization != nil {
+		e.Org = p.Organization.Login
+	}
+	if p.Repository != nil {
+		e.Owner, e.Repository = p.Repository.Owner.Login, p.Repository.Name
+	}
+	if p.Team != nil {
+		e.Team = p.Team.Slug
+	}
+	return e
+}
+
+// enqueue triggers reconciles of the managed resources the supplied payload
+// concerns, and returns how many there are.
+func (s *Server) enqueue(ctx context.Context, p payload) int {
+	return Enqueue(ctx, s.kube, s.scheme, s.log, p.event())
+}
+
+// Enqueue triggers reconciles of the managed resources the supplied event
+// concerns, and returns how many there are. Events of a repository concern
+// the resources of that repository, and events of a team the resources of
+// that team. Events of a team that concern a repository, such as the team
+// being granted access to it, concern the resources of both. Other events of
+// an organization concern the resources of that organization.
+//
+// The resources are looked up by the fields IndexFields indexes them by.
+func Enqueue(ctx context.Context, c client.Reader, s *runtime.Scheme, l logging.Logger, e Event) int {
+	// Sending may block until the controller of a kind reads its channel, so
+	// the lock Source takes must not be held while sending.
+	kindsMu.RLock()
+	channels := make(map[schema.GroupVersionKind]chan event.GenericEvent, len(kinds))
+	for gvk, ch := range kinds {
+		channels[gvk] = ch
+	}
+	kindsMu.RUnlock()
+
+	n := 0
+	for gvk, ch := range channels {
+		for _, o := range concerned(ctx, c, s, l, gvk, e) {
+			select {
+			case ch <- event.GenericEvent{Object: o}:
+				n++
+			case <-ctx.Done():
+				return n
+			}
+		}
+	}
+	return n
+}
+
+// IndexFields indexes the managed resources of every kind a Source was
+// returned for by the organization, repository, and team they belong to, so
+// that Enqueue can look up the resources an event concerns.
+func IndexFields(ctx context.Context, i client.FieldIndexer, s *runtime.Scheme) error {
+	kindsMu.RLock()
+	gvks := make([]schema.GroupVersionKind, 0, len(kinds))
+	for gvk := range kinds {
+		gvks = append(gvks, gvk)
+	}
+	kindsMu.RUnlock()
+
+	for _, gvk := range gvks {
+		obj, err := s.New(gvk)
+		if err != nil {
+			return errors.Wrapf(err, errFmtNewObject, gvk)
+		}
+		o, ok := obj.(client.Object)
+		if !ok {
+			return errors.Errorf(errFmtNotObject, gvk)
+		}
+		for _, field := range []string{orgField, repositoryField, teamField} {
+			gvk, field := gvk, field
+			extract := func(o client.Object) []string {
+				if v, ok := indexed(gvk, o)[field]; ok {
+					return []string{v}
+				}
+				return nil
+			}
+			if err := i.IndexField(ctx, o, field, extract); err != nil {
+				return errors.Wrapf(err, errFmtIndexField, field, gvk)
+			}
+		}
+	}
+	return nil
+}
+
+// concerned returns the managed resources of the supplied kind the supplied
+// event concerns.
+func concerned(ctx context.Context, c client.Reader, s *runtime.Scheme, l logging.Logger, gvk schema.GroupVersionKind, e Event) []client.Object {
+	var concerned []client.Object
+	seen := map[string]bool{}
+	for _, q := range e.queries() {
+		li, err := s.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			return nil
+		}
+		list, ok := li.(client.ObjectList)
+		if !ok {
+			return nil
+		}
+		if err := c.List(ctx, list, q); err != nil {
+			l.Debug("Cannot list managed resources", "kind", gvk.String(), "error", err)
+			continue
+		}
+		items, err := kmeta.ExtractList(list)
+		if err != nil {
+			continue
+		}
+		for _, i := range items {
+			// A resource may match more than one query.
+			if o, ok := i.(client.Object); ok && !seen[o.GetName()] {
+				seen[o.GetName()] = true
+				concerned = append(concerned, o)
+			}
+		}
+	}
+	return concerned
+}
+
+// queries returns the fields the managed resources the supplied event
+// concerns are indexed by. A resource is concerned if it matches any of them.
+func (e Event) queries() []client.MatchingFields {
+	if e.Repository == "" && e.Team == "" {
+		if e.Org == "" {
+			return nil
+		}
+		return []client.MatchingFields{{orgField: strings.ToLower(e.Org)}}
+	}
+	var q []client.MatchingFields
+	if e.Repository != "" {
+		q = append(q, client.MatchingFields{repositoryField: strings.ToLower(e.Owner + "/" + e.Repository)})
+	}
+	if e.Team != "" {
+		q = append(q, client.MatchingFields{teamField: strings.ToLower(e.Org + "/" + e.Team)})
+	}
+	return q
+}
+
+// matches reports whether the supplied managed resource of the supplied kind
+// is concerned by the supplied event.
+func matches(gvk schema.GroupVersionKind, o client.Object, e Event) bool {
+	f := indexed(gvk, o)
+	for _, q := range e.queries() {
+		for k, v := range q {
+			if f[k] == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// indexed returns the values of the fields the supplied managed resource of
+// the supplied kind is indexed by. GitHub compares logins, names, and slugs
+// regardless of case, so the values are lowercase.
+func indexed(gvk schema.GroupVersionKind, o client.Object) map[string]string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil
+	}
+	spec, _ := u["spec"].(map[string]interface{})
+	params, _ := spec["forProvider"].(map[string]interface{})
+	org, _ := params["org"].(string)
+	owner, _ := params["owner"].(string)
+	repository, _ := params["repository"].(string)
+	team, _ := params["team"].(string)
+	if gvk.Kind == repositoryKind {
+		repository = meta.GetExternalName(o)
+	}
+	if gvk.Kind == teamKind {
+		// The slug GitHub reports is preferred, since the slug derived from
+		// the name of a team does not always match it.
+		status, _ := u["status"].(map[string]interface{})
+		observation, _ := status["atProvider"].(map[string]interface{})
+		if team, _ = observation["slug"].(string); team == "" {
+			team = externalname.Slug(meta.GetExternalName(o))
+		}
+	}
+
+	f := map[string]string{}
+	if org != "" {
+		f[orgField] = strings.ToLower(org)
+	}
+	if repository != "" {
+		f[repositoryField] = strings.ToLower(owner + "/" + repository)
+	}
+	if team != "" {
+		f[teamField] = strings.ToLower(org + "/" + team)
+	}
+	return f
+}
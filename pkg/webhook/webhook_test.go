@@ -0,0 +1,394 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/hasheddan/kc-provider-github/apis"
+	orgv1alpha1 "github.com/hasheddan/kc-provider-github/apis/org/v1alpha1"
+	orgv1beta1 "github.com/hasheddan/kc-provider-github/apis/org/v1beta1"
+	repov1alpha1 "github.com/hasheddan/kc-provider-github/apis/repo/v1alpha1"
+)
+
+func team(name, slug string) *orgv1beta1.Team {
+	t := &orgv1beta1.Team{}
+	t.SetName(name)
+	t.Spec.ForProvider.Org = "crossplane"
+	meta.SetExternalName(t, name)
+	t.Status.AtProvider.Slug = slug
+	return t
+}
+
+func membership(name, team string) *orgv1alpha1.Membership {
+	m := &orgv1alpha1.Membership{}
+	m.SetName(name)
+	m.Spec.ForProvider.Org = "crossplane"
+	m.Spec.ForProvider.Team = pointer.String(team)
+	return m
+}
+
+func teamRepositorySet(name, team string) *orgv1alpha1.TeamRepositorySet {
+	s := &orgv1alpha1.TeamRepositorySet{}
+	s.SetName(name)
+	s.Spec.ForProvider.Org = "crossplane"
+	s.Spec.ForProvider.Team = pointer.String(team)
+	return s
+}
+
+func repository(name string) *repov1alpha1.Repository {
+	r := &repov1alpha1.Repository{}
+	r.SetName(name)
+	r.Spec.ForProvider.Owner = "crossplane"
+	meta.SetExternalName(r, name)
+	return r
+}
+
+// indexedTeams returns a List function that lists those of the supplied teams
+// that match the field selector of the list, as the cache of a manager does
+// with the fields IndexFields indexes. It sends to listed, if any, before
+// returning.
+func indexedTeams(listed chan<- struct{}, teams ...orgv1beta1.Team) test.MockListFn {
+	return func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+		if l, ok := obj.(*orgv1beta1.TeamList); ok {
+			for i := range teams {
+				if lo.FieldSelector == nil || lo.FieldSelector.Matches(fields.Set(indexed(orgv1beta1.TeamGroupVersionKind, &teams[i]))) {
+					l.Items = append(l.Items, teams[i])
+				}
+			}
+		}
+		if listed != nil {
+			listed <- struct{}{}
+		}
+		return nil
+	}
+}
+
+// replaceKinds replaces the kinds Sources were returned for until the test
+// is done.
+func replaceKinds(t *testing.T, k map[schema.GroupVersionKind]chan event.GenericEvent) {
+	t.Helper()
+	kindsMu.Lock()
+	saved := kinds
+	kinds = k
+	kindsMu.Unlock()
+	t.Cleanup(func() {
+		kindsMu.Lock()
+		kinds = saved
+		kindsMu.Unlock()
+	})
+}
+
+func TestPayloadEvent(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want Event
+	}{
+		"Team": {
+			body: `{"action":"edited","team":{"slug":"platform","name":"Platform"},"organization":{"login":"crossplane"}}`,
+			want: Event{Org: "crossplane", Team: "platform"},
+		},
+		"TeamAddedToRepository": {
+			body: `{"action":"added_to_repository","team":{"slug":"platform"},"repository":{"name":"provider","owner":{"login":"crossplane"}},"organization":{"login":"crossplane"}}`,
+			want: Event{Org: "crossplane", Owner: "crossplane", Repository: "provider", Team: "platform"},
+		},
+		"Organization": {
+			body: `{"action":"member_added","organization":{"login":"crossplane"}}`,
+			want: Event{Org: "crossplane"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := payload{}
+			if err := json.Unmarshal([]byte(tc.body), &p); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, p.event()); diff != "" {
+				t.Errorf("event(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	teamEvent := Event{Org: "crossplane", Team: "platform"}
+
+	cases := map[string]struct {
+		reason string
+		gvk    schema.GroupVersionKind
+		o      client.Object
+		e      Event
+		want   bool
+	}{
+		"TeamBySlug": {
+			reason: "A team should match events of the slug GitHub reports for it.",
+			gvk:    orgv1beta1.TeamGroupVersionKind,
+			o:      team("Platform Team", "platform"),
+			e:      teamEvent,
+			want:   true,
+		},
+		"TeamByExternalName": {
+			reason: "A team that was not observed yet should match events of the slug of its external name.",
+			gvk:    orgv1beta1.TeamGroupVersionKind,
+			o:      team("Platform", ""),
+			e:      teamEvent,
+			want:   true,
+		},
+		"OtherTeam": {
+			reason: "A team should not match events of another team.",
+			gvk:    orgv1beta1.TeamGroupVersionKind,
+			o:      team("Security", "security"),
+			e:      teamEvent,
+		},
+		"TeamOfOtherOrg": {
+			reason: "A team should not match events of a team with the same slug in another organization.",
+			gvk:    orgv1beta1.TeamGroupVersionKind,
+			o:      team("Platform", "platform"),
+			e:      Event{Org: "other", Team: "platform"},
+		},
+		"Membership": {
+			reason: "A team membership should match events of its team.",
+			gvk:    orgv1alpha1.MembershipGroupVersionKind,
+			o:      membership("alice", "platform"),
+			e:      teamEvent,
+			want:   true,
+		},
+		"TeamRepositorySet": {
+			reason: "The repositories of a team should match events of the team.",
+			gvk:    orgv1alpha1.TeamRepositorySetGroupVersionKind,
+			o:      teamRepositorySet("platform", "platform"),
+			e:      Event{Org: "crossplane", Owner: "crossplane", Repository: "provider", Team: "platform"},
+			want:   true,
+		},
+		"RepositoryOfTeamEvent": {
+			reason: "A repository should match events of a team being granted access to it.",
+			gvk:    repov1alpha1.RepositoryGroupVersionKind,
+			o:      repository("provider"),
+			e:      Event{Org: "crossplane", Owner: "crossplane", Repository: "provider", Team: "platform"},
+			want:   true,
+		},
+		"RepositoryOfOrgTeamEvent": {
+			reason: "A repository should not match events of a team that do not concern it.",
+			gvk:    repov1alpha1.RepositoryGroupVersionKind,
+			o:      repository("provider"),
+			e:      teamEvent,
+		},
+		"MembershipOfOrgEvent": {
+			reason: "Resources of an organization should match events of the organization that concern no team or repository.",
+			gvk:    orgv1alpha1.MembershipGroupVersionKind,
+			o:      membership("alice", "security"),
+			e:      Event{Org: "crossplane"},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := matches(tc.gvk, tc.o, tc.e); got != tc.want {
+				t.Errorf("\n%s\nmatches(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestServeHTTP(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	kube := &test.MockClient{MockList: indexedTeams(nil, *team("Platform", "platform"), *team("Security", "security"))}
+
+	ch := make(chan event.GenericEvent, 10)
+	replaceKinds(t, map[schema.GroupVersionKind]chan event.GenericEvent{orgv1beta1.TeamGroupVersionKind: ch})
+
+	srv := NewServer(":0", "secret", kube, s, logging.NewNopLogger())
+	body := `{"action":"edited","team":{"slug":"platform"},"organization":{"login":"crossplane"}}`
+	send := func(signature, delivery string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("X-Hub-Signature-256", signature)
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-GitHub-Event", "team")
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if got := send(sign("wrong", body), "1"); got != http.StatusUnauthorized {
+		t.Errorf("ServeHTTP(...): want status %d for an invalid signature, got %d", http.StatusUnauthorized, got)
+	}
+	if got := send(sign("secret", body), "1"); got != http.StatusNoContent {
+		t.Errorf("ServeHTTP(...): want status %d, got %d", http.StatusNoContent, got)
+	}
+	if got := send(sign("secret", body), "1"); got != http.StatusConflict {
+		t.Errorf("ServeHTTP(...): want status %d for a replayed delivery, got %d", http.StatusConflict, got)
+	}
+
+	close(ch)
+	var got []string
+	for e := range ch {
+		got = append(got, e.Object.GetName())
+	}
+	if diff := cmp.Diff([]string{"Platform"}, got); diff != "" {
+		t.Errorf("ServeHTTP(...): -want enqueued, +got enqueued:\n%s", diff)
+	}
+}
+
+// A recordingIndexer records the fields it is asked to index by kind.
+type recordingIndexer struct {
+	extract map[string]client.IndexerFunc
+}
+
+func (i *recordingIndexer) IndexField(_ context.Context, o client.Object, field string, fn client.IndexerFunc) error {
+	i.extract[fmt.Sprintf("%T %s", o, field)] = fn
+	return nil
+}
+
+func TestIndexFields(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	replaceKinds(t, map[schema.GroupVersionKind]chan event.GenericEvent{
+		orgv1beta1.TeamGroupVersionKind:         make(chan event.GenericEvent),
+		repov1alpha1.RepositoryGroupVersionKind: make(chan event.GenericEvent),
+		orgv1alpha1.MembershipGroupVersionKind:  make(chan event.GenericEvent),
+	})
+
+	i := &recordingIndexer{extract: map[string]client.IndexerFunc{}}
+	if err := IndexFields(context.Background(), i, s); err != nil {
+		t.Fatalf("IndexFields(...): %v", err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      client.Object
+		field  string
+		want   []string
+	}{
+		"TeamOrg": {
+			reason: "A team should be indexed by its organization.",
+			o:      team("Platform", "platform"),
+			field:  orgField,
+			want:   []string{"crossplane"},
+		},
+		"TeamSlug": {
+			reason: "A team should be indexed by the slug GitHub reports for it, within its organization.",
+			o:      team("Platform Team", "Platform"),
+			field:  teamField,
+			want:   []string{"crossplane/platform"},
+		},
+		"TeamRepository": {
+			reason: "A team belongs to no repository.",
+			o:      team("Platform", "platform"),
+			field:  repositoryField,
+		},
+		"RepositoryExternalName": {
+			reason: "A repository should be indexed by its owner and external name.",
+			o:      repository("Provider"),
+			field:  repositoryField,
+			want:   []string{"crossplane/provider"},
+		},
+		"MembershipTeam": {
+			reason: "A team membership should be indexed by its team.",
+			o:      membership("alice", "platform"),
+			field:  teamField,
+			want:   []string{"crossplane/platform"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fn, ok := i.extract[fmt.Sprintf("%T %s", tc.o, tc.field)]
+			if !ok {
+				t.Fatalf("\n%s\nIndexFields(...): field %s of %T is not indexed", tc.reason, tc.field, tc.o)
+			}
+			if diff := cmp.Diff(tc.want, fn(tc.o)); diff != "" {
+				t.Errorf("\n%s\nIndexFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnqueueReleasesLock(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	listed := make(chan struct{}, 1)
+	kube := &test.MockClient{MockList: indexedTeams(listed, *team("Platform", "platform"))}
+
+	// No controller reads the channel yet, so Enqueue blocks sending to it.
+	ch := make(chan event.GenericEvent)
+	replaceKinds(t, map[schema.GroupVersionKind]chan event.GenericEvent{orgv1beta1.TeamGroupVersionKind: ch})
+
+	enqueued := make(chan int)
+	go func() {
+		enqueued <- Enqueue(context.Background(), kube, s, logging.NewNopLogger(), Event{Org: "crossplane", Team: "platform"})
+	}()
+	<-listed
+
+	sourced := make(chan struct{})
+	go func() {
+		Source(orgv1alpha1.MembershipGroupVersionKind)
+		close(sourced)
+	}()
+	select {
+	case <-sourced:
+	case <-time.After(5 * time.Second):
+		// Unblock Enqueue so that the test can clean up.
+		<-ch
+		t.Fatal("Source(...): blocked while Enqueue(...) was sending an event")
+	}
+
+	if got := (<-ch).Object.GetName(); got != "Platform" {
+		t.Errorf("Enqueue(...): want Platform to be enqueued, got %s", got)
+	}
+	if got := <-enqueued; got != 1 {
+		t.Errorf("Enqueue(...): want 1 enqueued, got %d", got)
+	}
+}